@@ -15,57 +15,465 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	httpclient "github.com/attestantio/go-eth2-client/http"
 	"github.com/attestantio/go-eth2-client/metrics"
 	multiclient "github.com/attestantio/go-eth2-client/multi"
+	"github.com/attestantio/vouch/services/eth2client/limited"
+	"github.com/attestantio/vouch/services/eth2client/proxy"
+	"github.com/attestantio/vouch/services/eth2client/recording"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	majordomo "github.com/wealdtech/go-majordomo"
 )
 
 var clients map[string]eth2client.Service
 var clientsMu sync.Mutex
 
+// clientCancels cancel the contexts of clients, keyed as for clients.
+var clientCancels map[string]context.CancelFunc
+
+// clientGeneration is the current generation of clients; it increases each time the beacon node configuration is reloaded.
+var clientGeneration uint64
+
+// clientGenerations are the generations in which clients were last fetched, keyed as for clients.
+var clientGenerations map[string]uint64
+
+// clientProxies are the addresses of local proxies for beacon nodes, keyed by beacon node address.
+var clientProxies map[string]string
+
+// clientProxy is a local proxy for a beacon node that requires authentication.
+type clientProxy struct {
+	address string
+	// config is a digest of the configuration with which the proxy was started.
+	config [32]byte
+	cancel context.CancelFunc
+}
+
+// clientProxyServices are the running proxies, keyed as for clientProxies.
+var clientProxyServices map[string]*clientProxy
+
+// retiredClientProxies are proxies that have been replaced or removed, keyed by the generation in which
+// they were retired.  They are closed along with the clients of that generation.
+var retiredClientProxies map[uint64][]context.CancelFunc
+
+// initClients initialises the client maps if required.
+// This assumes that the lock is held.
+func initClients() {
+	if clients == nil {
+		clients = make(map[string]eth2client.Service)
+		clientCancels = make(map[string]context.CancelFunc)
+		clientGenerations = make(map[string]uint64)
+	}
+}
+
 // fetchClient fetches a client service, instantiating it if required.
 func fetchClient(ctx context.Context, address string) (eth2client.Service, error) {
 	clientsMu.Lock()
 	defer clientsMu.Unlock()
-	if clients == nil {
-		clients = make(map[string]eth2client.Service)
-	}
+	initClients()
 
 	var client eth2client.Service
 	var exists bool
 	if client, exists = clients[address]; !exists {
+		// Each client has its own context, so that it can be closed if the beacon node is removed.
+		clientCtx, cancel := context.WithCancel(ctx)
 		var err error
-		client, err = httpclient.New(ctx,
+		client, err = httpclient.New(clientCtx,
 			httpclient.WithLogLevel(util.LogLevel("eth2client")),
 			httpclient.WithTimeout(util.Timeout("eth2client")),
-			httpclient.WithAddress(address))
+			httpclient.WithAddress(clientAddress(address)))
 		if err != nil {
+			cancel()
 			return nil, errors.Wrap(err, "failed to initiate client")
 		}
+		if viper.GetString("eth2client.recording.path") != "" {
+			client, err = recording.New(clientCtx,
+				recording.WithLogLevel(util.LogLevel("eth2client.recording")),
+				recording.WithClient(client),
+				recording.WithPath(clientRecordingPath(viper.GetString("eth2client.recording.path"), address)),
+			)
+			if err != nil {
+				cancel()
+				return nil, errors.Wrap(err, "failed to record client")
+			}
+		}
+		limits, err := fetchClientLimits(address)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if limits != nil {
+			client, err = limitClient(clientCtx, client, limits)
+			if err != nil {
+				cancel()
+				return nil, errors.Wrap(err, "failed to limit client")
+			}
+		}
 		clients[address] = client
+		clientCancels[address] = cancel
 	}
+	clientGenerations[address] = clientGeneration
 	return client, nil
 }
 
+// clientRecordingPath returns the path of the file holding recorded interactions with a beacon node.
+func clientRecordingPath(dir string, address string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, address)
+	return filepath.Join(resolvePath(dir), fmt.Sprintf("%s.json", name))
+}
+
+// nextClientGeneration starts a new generation of clients, returning its number.
+func nextClientGeneration() uint64 {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	clientGeneration++
+	return clientGeneration
+}
+
+// retainClients marks the clients with the given keys as part of the current generation.
+func retainClients(keys []string) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	initClients()
+	for _, key := range keys {
+		if _, exists := clients[key]; exists {
+			clientGenerations[key] = clientGeneration
+		}
+	}
+}
+
+// releaseClients closes clients last fetched before the given generation, returning their keys.
+func releaseClients(generation uint64) []string {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	initClients()
+	released := make([]string, 0)
+	for key := range clients {
+		if clientGenerations[key] >= generation {
+			continue
+		}
+		if cancel, exists := clientCancels[key]; exists {
+			cancel()
+		}
+		delete(clients, key)
+		delete(clientCancels, key)
+		delete(clientGenerations, key)
+		released = append(released, key)
+	}
+	for retiredGeneration, cancels := range retiredClientProxies {
+		if retiredGeneration >= generation {
+			continue
+		}
+		for _, cancel := range cancels {
+			cancel()
+		}
+		delete(retiredClientProxies, retiredGeneration)
+	}
+	return released
+}
+
+// retireClients removes clients that reach the beacon node at the given address, so that they are
+// created afresh when next fetched.  The removed clients are closed when their generation is released.
+// This assumes that the lock is held.
+func retireClients(address string) {
+	initClients()
+	for key, client := range clients {
+		if key != address && !multiClientContains(key, address) {
+			continue
+		}
+		retiredKey := fmt.Sprintf("%s (retired in generation %d)", key, clientGeneration)
+		clients[retiredKey] = client
+		if cancel, exists := clientCancels[key]; exists {
+			clientCancels[retiredKey] = cancel
+		}
+		clientGenerations[retiredKey] = clientGenerations[key]
+		delete(clients, key)
+		delete(clientCancels, key)
+		delete(clientGenerations, key)
+	}
+}
+
+// retireClientProxy retires the proxy for the beacon node at the given address, along with any clients that use it.
+// This assumes that the lock is held.
+func retireClientProxy(address string, clientProxy *clientProxy) {
+	if retiredClientProxies == nil {
+		retiredClientProxies = make(map[uint64][]context.CancelFunc)
+	}
+	retiredClientProxies[clientGeneration] = append(retiredClientProxies[clientGeneration], clientProxy.cancel)
+	retireClients(address)
+}
+
+// clientConnection is the configuration for connecting to a beacon node that requires authentication.
+type clientConnection struct {
+	Address string `mapstructure:"address"`
+	// Headers are added to each request.
+	Headers map[string]string `mapstructure:"headers"`
+	// SecretHeaders are added to each request, with values obtained through majordomo.
+	SecretHeaders map[string]string `mapstructure:"secret-headers"`
+	ClientCert    string            `mapstructure:"client-cert"`
+	ClientKey     string            `mapstructure:"client-key"`
+	CACert        string            `mapstructure:"ca-cert"`
+}
+
+// initClientConnections starts proxies for beacon nodes that require authentication.
+// Proxies whose configuration is unchanged are left as they are.  Proxies whose configuration has changed
+// are replaced, and those for beacon nodes that no longer require authentication are removed; in both cases
+// the previous proxy, and any clients that use it, are closed when the current generation of clients is released.
+func initClientConnections(ctx context.Context, majordomo majordomo.Service) error {
+	connections := make([]*clientConnection, 0)
+	if err := viper.UnmarshalKey("eth2client.connections", &connections); err != nil {
+		return errors.Wrap(err, "failed to obtain beacon node connections")
+	}
+
+	// Obtain the configuration for all connections before changing anything, so that a failure leaves the proxies as they are.
+	configs := make(map[string]*clientProxyConfig)
+	for _, connection := range connections {
+		if connection == nil || connection.Address == "" {
+			return errors.New("beacon node connection requires an address")
+		}
+		config, err := fetchClientProxyConfig(ctx, majordomo, connection)
+		if err != nil {
+			return err
+		}
+		configs[connection.Address] = config
+	}
+
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	// New maps are created rather than updating the existing ones, as the existing ones may be in use.
+	proxies := make(map[string]string)
+	proxyServices := make(map[string]*clientProxy)
+	for address, config := range configs {
+		digest := config.digest()
+		existing, exists := clientProxyServices[address]
+		if exists && existing.config == digest {
+			proxies[address] = existing.address
+			proxyServices[address] = existing
+			continue
+		}
+
+		proxyCtx, cancel := context.WithCancel(ctx)
+		params := []proxy.Parameter{
+			proxy.WithLogLevel(util.LogLevel("eth2client")),
+			proxy.WithAddress(address),
+			proxy.WithHeaders(config.Headers),
+		}
+		if config.ClientCert != nil {
+			params = append(params, proxy.WithClientCert(config.ClientCert), proxy.WithClientKey(config.ClientKey))
+		}
+		if config.CACert != nil {
+			params = append(params, proxy.WithCACert(config.CACert))
+		}
+		service, err := proxy.New(proxyCtx, params...)
+		if err != nil {
+			cancel()
+			// Stop any proxies started by this call, leaving the existing ones in place.
+			for proxyAddress, proxyService := range proxyServices {
+				if clientProxyServices[proxyAddress] != proxyService {
+					proxyService.cancel()
+				}
+			}
+			return errors.Wrap(err, "failed to start proxy for beacon node connection")
+		}
+		proxies[address] = service.Address()
+		proxyServices[address] = &clientProxy{
+			address: service.Address(),
+			config:  digest,
+			cancel:  cancel,
+		}
+	}
+	for address, existing := range clientProxyServices {
+		replacement, exists := proxyServices[address]
+		switch {
+		case !exists:
+			log.Info().Str("address", address).Msg("Beacon node connection removed; closing proxy")
+			retireClientProxy(address, existing)
+		case replacement != existing:
+			log.Info().Str("address", address).Msg("Beacon node connection configuration changed; replacing proxy")
+			retireClientProxy(address, existing)
+		}
+	}
+	clientProxies = proxies
+	clientProxyServices = proxyServices
+
+	return nil
+}
+
+// clientProxyConfig is the resolved configuration for a proxy.
+type clientProxyConfig struct {
+	Headers    map[string]string
+	ClientCert []byte
+	ClientKey  []byte
+	CACert     []byte
+}
+
+// digest provides a digest of the configuration, allowing changes to be detected without retaining secrets.
+func (c *clientProxyConfig) digest() [32]byte {
+	// JSON encodes map keys in sorted order, so the encoding is deterministic.
+	data, err := json.Marshal(c)
+	if err != nil {
+		// Cannot happen, as the configuration only contains strings and bytes.
+		panic(err)
+	}
+	return sha256.Sum256(data)
+}
+
+// fetchClientProxyConfig fetches the resolved configuration for a connection, obtaining secrets through majordomo.
+func fetchClientProxyConfig(ctx context.Context, majordomo majordomo.Service, connection *clientConnection) (*clientProxyConfig, error) {
+	config := &clientProxyConfig{
+		Headers: make(map[string]string),
+	}
+	for k, v := range connection.Headers {
+		config.Headers[k] = v
+	}
+	for k, v := range connection.SecretHeaders {
+		value, err := majordomo.Fetch(ctx, v)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain header %s for beacon node connection", k))
+		}
+		config.Headers[k] = strings.TrimSpace(string(value))
+	}
+
+	if connection.ClientCert != "" {
+		clientCert, err := majordomo.Fetch(ctx, connection.ClientCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain client certificate for beacon node connection")
+		}
+		clientKey, err := majordomo.Fetch(ctx, connection.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain client key for beacon node connection")
+		}
+		config.ClientCert = clientCert
+		config.ClientKey = clientKey
+	}
+	if connection.CACert != "" {
+		caCert, err := majordomo.Fetch(ctx, connection.CACert)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain CA certificate for beacon node connection")
+		}
+		config.CACert = caCert
+	}
+
+	return config, nil
+}
+
+// currentClientProxies provides the addresses of local proxies for beacon nodes, keyed by beacon node address.
+func currentClientProxies() map[string]string {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	return clientProxies
+}
+
+// clientAddress provides the address through which the beacon node at the given address is reached.
+// This assumes that the lock is held.
+func clientAddress(address string) string {
+	if proxyAddress, exists := clientProxies[address]; exists {
+		return proxyAddress
+	}
+	return address
+}
+
+// clientLimits are the limits applied to requests made to a beacon node.
+type clientLimits struct {
+	Address     string        `mapstructure:"address"`
+	MaxInFlight int           `mapstructure:"max-in-flight"`
+	Rate        float64       `mapstructure:"rate"`
+	Burst       int           `mapstructure:"burst"`
+	MaxWait     time.Duration `mapstructure:"max-wait"`
+}
+
+// fetchClientLimits fetches the limits for the beacon node at the given address.
+// Limits for individual nodes override the general limits.
+// This returns nil if no limits apply.
+func fetchClientLimits(address string) (*clientLimits, error) {
+	limits := &clientLimits{
+		MaxInFlight: viper.GetInt("eth2client.limits.max-in-flight"),
+		Rate:        viper.GetFloat64("eth2client.limits.rate"),
+		Burst:       viper.GetInt("eth2client.limits.burst"),
+		MaxWait:     viper.GetDuration("eth2client.limits.max-wait"),
+	}
+
+	// Node addresses contain characters that viper treats as separators, so node limits are a list.
+	nodeLimits := make([]*clientLimits, 0)
+	if err := viper.UnmarshalKey("eth2client.limits.nodes", &nodeLimits); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain beacon node limits")
+	}
+	for _, nodeLimit := range nodeLimits {
+		if nodeLimit == nil || nodeLimit.Address != address {
+			continue
+		}
+		if nodeLimit.MaxInFlight != 0 {
+			limits.MaxInFlight = nodeLimit.MaxInFlight
+		}
+		if nodeLimit.Rate != 0 {
+			limits.Rate = nodeLimit.Rate
+		}
+		if nodeLimit.Burst != 0 {
+			limits.Burst = nodeLimit.Burst
+		}
+		if nodeLimit.MaxWait != 0 {
+			limits.MaxWait = nodeLimit.MaxWait
+		}
+	}
+
+	if limits.MaxInFlight == 0 && limits.Rate == 0 {
+		return nil, nil
+	}
+	if limits.Burst == 0 {
+		limits.Burst = 1
+	}
+
+	return limits, nil
+}
+
+// limitClient wraps a client with the supplied limits.
+func limitClient(ctx context.Context, client eth2client.Service, limits *clientLimits) (eth2client.Service, error) {
+	// See fetchMultiClient for details of the monitor.
+	var monitor *consensusMonitor
+	if viper.Get("metrics.prometheus") != nil {
+		monitor = &consensusMonitor{}
+	}
+
+	params := []limited.Parameter{
+		limited.WithLogLevel(util.LogLevel("eth2client")),
+		limited.WithClient(client),
+		limited.WithMaxInFlight(limits.MaxInFlight),
+		limited.WithRate(limits.Rate),
+		limited.WithBurst(limits.Burst),
+		limited.WithMaxWait(limits.MaxWait),
+	}
+	if monitor != nil {
+		params = append(params, limited.WithMonitor(monitor))
+	}
+
+	return limited.New(ctx, params...)
+}
+
 // fetchMulticlient fetches a multiclient service, instantiating it if required.
 func fetchMultiClient(ctx context.Context, addresses []string) (eth2client.Service, error) {
 	clientsMu.Lock()
 	defer clientsMu.Unlock()
-	if clients == nil {
-		clients = make(map[string]eth2client.Service)
-	}
+	initClients()
 
 	var client eth2client.Service
 	var exists bool
-	multiID := fmt.Sprintf("multi:%s", strings.Join(addresses, ","))
+	multiID := multiClientKey(addresses)
 	if client, exists = clients[multiID]; !exists {
 		// The prometheus metrics service requires a client connection, and the client connection
 		// requires a prometheus metrics service.  Square the circle by creating a local metrics
@@ -75,20 +483,48 @@ func fetchMultiClient(ctx context.Context, addresses []string) (eth2client.Servi
 			monitor = &consensusMonitor{}
 		}
 
+		// Beacon nodes that require authentication are reached through their proxies.
+		clientAddresses := make([]string, len(addresses))
+		for i, address := range addresses {
+			clientAddresses[i] = clientAddress(address)
+		}
+
+		clientCtx, cancel := context.WithCancel(ctx)
 		var err error
-		client, err = multiclient.New(ctx,
+		client, err = multiclient.New(clientCtx,
 			multiclient.WithMonitor(monitor),
 			multiclient.WithLogLevel(util.LogLevel("eth2client")),
 			multiclient.WithTimeout(util.Timeout("eth2client")),
-			multiclient.WithAddresses(addresses))
+			multiclient.WithAddresses(clientAddresses))
 		if err != nil {
+			cancel()
 			return nil, errors.Wrap(err, "failed to initiate multiclient")
 		}
 		clients[multiID] = client
+		clientCancels[multiID] = cancel
 	}
+	clientGenerations[multiID] = clientGeneration
 	return client, nil
 }
 
+// multiClientKey provides the key for a multiclient with the given addresses.
+func multiClientKey(addresses []string) string {
+	return fmt.Sprintf("multi:%s", strings.Join(addresses, ","))
+}
+
+// multiClientContains returns true if the key is for a multiclient that includes the given address.
+func multiClientContains(key string, address string) bool {
+	if !strings.HasPrefix(key, "multi:") {
+		return false
+	}
+	for _, multiAddress := range strings.Split(strings.TrimPrefix(key, "multi:"), ",") {
+		if multiAddress == address {
+			return true
+		}
+	}
+	return false
+}
+
 // consensusMonitor is a monitor for the consensus client.
 type consensusMonitor struct{}
 
@@ -45,9 +45,21 @@ import (
 	standardbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/standard"
 	"github.com/attestantio/vouch/services/cache"
 	standardcache "github.com/attestantio/vouch/services/cache/standard"
+	"github.com/attestantio/vouch/services/capabilities"
+	nullcapabilities "github.com/attestantio/vouch/services/capabilities/null"
+	standardcapabilities "github.com/attestantio/vouch/services/capabilities/standard"
 	"github.com/attestantio/vouch/services/chaintime"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/clockdrift"
+	standardclockdrift "github.com/attestantio/vouch/services/clockdrift/standard"
+	"github.com/attestantio/vouch/services/consistency"
+	nullconsistency "github.com/attestantio/vouch/services/consistency/null"
+	standardconsistency "github.com/attestantio/vouch/services/consistency/standard"
 	standardcontroller "github.com/attestantio/vouch/services/controller/standard"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	standarderrorclassifier "github.com/attestantio/vouch/services/errorclassifier/standard"
+	"github.com/attestantio/vouch/services/eth2client/switchable"
+	"github.com/attestantio/vouch/services/eventaggregator"
 	"github.com/attestantio/vouch/services/feerecipientprovider"
 	remotefeerecipientprovider "github.com/attestantio/vouch/services/feerecipientprovider/remote"
 	staticfeerecipientprovider "github.com/attestantio/vouch/services/feerecipientprovider/static"
@@ -57,15 +69,27 @@ import (
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	prometheusmetrics "github.com/attestantio/vouch/services/metrics/prometheus"
+	"github.com/attestantio/vouch/services/nodehealth"
+	nullnodehealth "github.com/attestantio/vouch/services/nodehealth/null"
+	standardnodehealth "github.com/attestantio/vouch/services/nodehealth/standard"
 	"github.com/attestantio/vouch/services/proposalpreparer"
 	standardproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/standard"
+	"github.com/attestantio/vouch/services/recorder"
+	nullrecorder "github.com/attestantio/vouch/services/recorder/null"
+	standardrecorder "github.com/attestantio/vouch/services/recorder/standard"
 	"github.com/attestantio/vouch/services/scheduler"
 	advancedscheduler "github.com/attestantio/vouch/services/scheduler/advanced"
+	"github.com/attestantio/vouch/services/scorer"
+	nullscorer "github.com/attestantio/vouch/services/scorer/null"
+	standardscorer "github.com/attestantio/vouch/services/scorer/standard"
 	"github.com/attestantio/vouch/services/signer"
 	standardsigner "github.com/attestantio/vouch/services/signer/standard"
 	"github.com/attestantio/vouch/services/submitter"
+	dryrunsubmitter "github.com/attestantio/vouch/services/submitter/dryrun"
 	immediatesubmitter "github.com/attestantio/vouch/services/submitter/immediate"
+	journalsubmitter "github.com/attestantio/vouch/services/submitter/journal"
 	multinodesubmitter "github.com/attestantio/vouch/services/submitter/multinode"
+	"github.com/attestantio/vouch/services/submitter/retry"
 	"github.com/attestantio/vouch/services/synccommitteeaggregator"
 	standardsynccommitteeaggregator "github.com/attestantio/vouch/services/synccommitteeaggregator/standard"
 	"github.com/attestantio/vouch/services/synccommitteemessenger"
@@ -76,12 +100,16 @@ import (
 	standardvalidatorsmanager "github.com/attestantio/vouch/services/validatorsmanager/standard"
 	bestaggregateattestationstrategy "github.com/attestantio/vouch/strategies/aggregateattestation/best"
 	firstaggregateattestationstrategy "github.com/attestantio/vouch/strategies/aggregateattestation/first"
+	mergeaggregateattestationstrategy "github.com/attestantio/vouch/strategies/aggregateattestation/merge"
 	bestattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/best"
 	firstattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/first"
+	majorityattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/majority"
 	bestbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/best"
 	firstbeaconblockproposalstrategy "github.com/attestantio/vouch/strategies/beaconblockproposal/first"
+	crosscheckdutiesstrategy "github.com/attestantio/vouch/strategies/duties/crosscheck"
 	bestsynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/best"
 	firstsynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/first"
+	mergesynccommitteecontributionstrategy "github.com/attestantio/vouch/strategies/synccommitteecontribution/merge"
 	"github.com/attestantio/vouch/util"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	homedir "github.com/mitchellh/go-homedir"
@@ -121,8 +149,8 @@ func main2() int {
 		return 1
 	}
 
-	if exit := runCommands(ctx); exit {
-		return 0
+	if exit, code := runCommands(ctx); exit {
+		return code
 	}
 
 	if err := initLogging(); err != nil {
@@ -154,7 +182,7 @@ func main2() int {
 		return 1
 	}
 
-	chainTime, controller, err := startServices(ctx, majordomo)
+	chainTime, controller, reloader, err := startServices(ctx, majordomo)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to initialise services")
 		return 1
@@ -164,9 +192,14 @@ func main2() int {
 
 	// Wait for signal.
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, os.Interrupt, syscall.SIGHUP)
 	for {
 		sig := <-sigCh
+		if sig == syscall.SIGHUP {
+			// Received a signal to reload the beacon node configuration.  Failures are logged by the reloader.
+			_ = reloader.reload(ctx)
+			continue
+		}
 		if sig == syscall.SIGINT || sig == syscall.SIGTERM || sig == os.Interrupt || sig == os.Kill {
 			// Received a signal to stop, but don't do so until we have finished attesting for this slot.
 			slot := chainTime.CurrentSlot()
@@ -199,6 +232,8 @@ func fetchConfig() error {
 	pflag.String("tracing-address", "", "Address to which to send tracing data")
 	pflag.String("beacon-node-address", "", "Address on which to contact the beacon node")
 	pflag.Bool("version", false, "show Vouch version and exit")
+	pflag.String("report-from", "", "start of the time range for strategy-report, as a time or a duration before now")
+	pflag.String("report-to", "", "end of the time range for strategy-report, as a time or a duration before now")
 	pflag.Parse()
 	if err := viper.BindPFlags(pflag.CommandLine); err != nil {
 		return errors.Wrap(err, "failed to bind pflags to viper")
@@ -228,10 +263,38 @@ func fetchConfig() error {
 	viper.SetDefault("timeout", 2*time.Second)
 	viper.SetDefault("eth2client.timeout", 2*time.Minute)
 	viper.SetDefault("controller.max-proposal-delay", 0)
+	viper.SetDefault("beaconblockproposer.max-parent-distance", 64)
+	viper.SetDefault("clockdrift.enable", false)
+	viper.SetDefault("clockdrift.interval", time.Minute)
+	viper.SetDefault("clockdrift.warn-threshold", time.Second)
+	viper.SetDefault("clockdrift.max-drift", 0)
+	viper.SetDefault("nodehealth.enable", false)
+	viper.SetDefault("nodehealth.interval", 12*time.Second)
+	viper.SetDefault("nodehealth.degraded-head-lag", 2)
+	viper.SetDefault("nodehealth.excluded-head-lag", 8)
+	viper.SetDefault("nodehealth.min-peers", 0)
+	viper.SetDefault("nodehealth.exclude-optimistic", true)
+	viper.SetDefault("nodehealth.hysteresis", 3)
+	viper.SetDefault("consistency.enable", false)
+	viper.SetDefault("consistency.interval", 10*time.Minute)
+	viper.SetDefault("capabilities.enable", false)
+	viper.SetDefault("capabilities.interval", time.Hour)
 	viper.SetDefault("controller.max-attestation-delay", 4*time.Second)
 	viper.SetDefault("controller.max-sync-committee-message-delay", 4*time.Second)
 	viper.SetDefault("controller.attestation-aggregation-delay", 8*time.Second)
 	viper.SetDefault("controller.sync-committee-aggregation-delay", 8*time.Second)
+	viper.SetDefault("strategies.recorder.max-size", 100*1024*1024)
+	viper.SetDefault("strategies.recorder.max-files", 10)
+	viper.SetDefault("strategies.scorer.timeout", 100*time.Millisecond)
+	viper.SetDefault("submitter.dryrun.path", "dryrun.json")
+	viper.SetDefault("submitter.retry.max-attempts", 3)
+	viper.SetDefault("submitter.retry.initial-backoff", 100*time.Millisecond)
+	viper.SetDefault("submitter.retry.max-backoff", time.Second)
+	viper.SetDefault("submitter.beaconblock.retry.deadline", 4*time.Second)
+	viper.SetDefault("submitter.attestation.retry.deadline", 8*time.Second)
+	viper.SetDefault("submitter.aggregateattestation.retry.deadline", 12*time.Second)
+	viper.SetDefault("submitter.synccommitteemessage.retry.deadline", 8*time.Second)
+	viper.SetDefault("submitter.synccommitteecontribution.retry.deadline", 12*time.Second)
 
 	if err := viper.ReadInConfig(); err != nil {
 		switch err.(type) {
@@ -316,17 +379,45 @@ func startClient(ctx context.Context) (eth2client.Service, error) {
 	return consensusClient, nil
 }
 
+// consensusClientAddresses returns the addresses of the beacon nodes used by the consensus client.
+func consensusClientAddresses() []string {
+	if len(viper.GetStringSlice("beacon-node-addresses")) > 0 {
+		return viper.GetStringSlice("beacon-node-addresses")
+	}
+	return []string{viper.GetString("beacon-node-address")}
+}
+
+// consensusClientKey provides the key of the client started by startClient.
+func consensusClientKey() string {
+	if len(viper.GetStringSlice("beacon-node-addresses")) > 0 {
+		return multiClientKey(viper.GetStringSlice("beacon-node-addresses"))
+	}
+	return viper.GetString("beacon-node-address")
+}
+
 func startServices(ctx context.Context,
 	majordomo majordomo.Service,
 ) (
 	chaintime.Service,
 	*standardcontroller.Service,
+	*reloader,
 	error,
 ) {
-	eth2Client, err := startClient(ctx)
+	log.Trace().Msg("Initialising beacon node connections")
+	if err := initClientConnections(ctx, majordomo); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to initialise beacon node connections")
+	}
+
+	consensusClient, err := startClient(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// Clients, strategies and submitters are switchable, so that beacon nodes can be changed without a restart.
+	eth2ClientSwitch, err := switchableClient(ctx, "consensus client", consensusClient)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, errors.Wrap(err, "failed to start switchable client")
 	}
+	var eth2Client eth2client.Service = eth2ClientSwitch
 	log.Trace().Msg("Starting chain time service")
 	chainTime, err := standardchaintime.New(ctx,
 		standardchaintime.WithLogLevel(util.LogLevel("chaintime")),
@@ -335,16 +426,16 @@ func startServices(ctx context.Context,
 		standardchaintime.WithSlotsPerEpochProvider(eth2Client.(eth2client.SlotsPerEpochProvider)),
 	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start chain time service")
+		return nil, nil, nil, errors.Wrap(err, "failed to start chain time service")
 	}
 
 	log.Trace().Msg("Starting metrics service")
 	monitor, err := startMonitor(ctx, chainTime)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start metrics service")
+		return nil, nil, nil, errors.Wrap(err, "failed to start metrics service")
 	}
 	if err := registerMetrics(monitor); err != nil {
-		return nil, nil, errors.Wrap(err, "failed to register metrics")
+		return nil, nil, nil, errors.Wrap(err, "failed to register metrics")
 	}
 	setRelease(ReleaseVersion)
 	setReady(false)
@@ -352,56 +443,122 @@ func startServices(ctx context.Context,
 	log.Trace().Msg("Selecting scheduler")
 	scheduler, err := selectScheduler(ctx, monitor)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to select scheduler")
+		return nil, nil, nil, errors.Wrap(err, "failed to select scheduler")
+	}
+
+	log.Trace().Msg("Starting consistency service")
+	consistencySvc, err := startConsistency(ctx, monitor, scheduler)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start consistency service")
+	}
+
+	log.Trace().Msg("Starting event aggregator")
+	eventsProvider, err := startEventAggregator(ctx, monitor, chainTime, eth2Client)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start event aggregator")
 	}
 
 	log.Trace().Msg("Starting cache")
-	cache, err := startCache(ctx, monitor, chainTime, scheduler, eth2Client)
+	cacheSvc, err := startCache(ctx, monitor, chainTime, scheduler, eth2Client, eventsProvider)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start cache")
+		return nil, nil, nil, errors.Wrap(err, "failed to start cache")
 	}
 
 	log.Trace().Msg("Starting validators manager")
 	validatorsManager, err := startValidatorsManager(ctx, monitor, eth2Client)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start validators manager")
+		return nil, nil, nil, errors.Wrap(err, "failed to start validators manager")
+	}
+
+	log.Trace().Msg("Starting clock drift service")
+	clockDrift, err := startClockDrift(ctx, monitor, chainTime, scheduler)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start clock drift service")
 	}
 
 	log.Trace().Msg("Starting signer")
-	signerSvc, err := startSigner(ctx, monitor, eth2Client)
+	signerSvc, err := startSigner(ctx, monitor, eth2Client, clockDrift)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start signer")
+		return nil, nil, nil, errors.Wrap(err, "failed to start signer")
 	}
 
 	log.Trace().Msg("Starting account manager")
 	accountManager, err := startAccountManager(ctx, monitor, eth2Client, validatorsManager, majordomo, chainTime)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start account manager")
+		return nil, nil, nil, errors.Wrap(err, "failed to start account manager")
+	}
+
+	log.Trace().Msg("Starting error classifier")
+	errorClassifier, err := startErrorClassifier(ctx, monitor)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start error classifier")
+	}
+
+	log.Trace().Msg("Starting node health service")
+	nodeHealth, err := startNodeHealth(ctx, monitor, chainTime, scheduler)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start node health service")
+	}
+
+	log.Trace().Msg("Starting capabilities service")
+	nodeCapabilities, err := startCapabilities(ctx, monitor, scheduler)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start capabilities service")
 	}
 
 	log.Trace().Msg("Selecting submitter strategy")
-	submitterStrategy, err := selectSubmitterStrategy(ctx, monitor, eth2Client)
+	submitterStrategy, err := selectSubmitterStrategy(ctx, monitor, eth2Client, chainTime, errorClassifier, nodeHealth, consistencySvc, nodeCapabilities)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to select submitter")
+		return nil, nil, nil, errors.Wrap(err, "failed to select submitter")
+	}
+	submitterSwitch, err := switchableClient(ctx, "submitter", submitterStrategy)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start switchable submitter")
+	}
+	submitterStrategy = submitterSwitch
+
+	if viper.GetString("submitter.journal.path") != "" {
+		log.Trace().Msg("Starting submitter journal")
+		submitterStrategy, err = startSubmitterJournal(ctx, monitor, eth2Client, chainTime, submitterStrategy)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to start submitter journal")
+		}
 	}
 
 	log.Trace().Msg("Starting graffiti provider")
 	graffitiProvider, err := startGraffitiProvider(ctx, majordomo)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start graffiti provider")
+		return nil, nil, nil, errors.Wrap(err, "failed to start graffiti provider")
 	}
 
 	log.Trace().Msg("Starting fee recipient provider")
 	feeRecipientProvider, err := startFeeRecipientProvider(ctx, monitor, majordomo)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start fee recipient provider")
+		return nil, nil, nil, errors.Wrap(err, "failed to start fee recipient provider")
+	}
+
+	log.Trace().Msg("Starting strategy decision recorder")
+	strategyRecorder, err := startStrategyRecorder(ctx, monitor)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start strategy decision recorder")
+	}
+
+	log.Trace().Msg("Starting strategy external scorer")
+	strategyScorer, err := startStrategyScorer(ctx, monitor)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start strategy external scorer")
 	}
 
 	log.Trace().Msg("Selecting beacon block proposal provider")
-	beaconBlockProposalProvider, err := selectBeaconBlockProposalProvider(ctx, monitor, eth2Client, chainTime, cache)
+	beaconBlockProposalProvider, err := selectBeaconBlockProposalProvider(ctx, monitor, eth2Client, eventsProvider, chainTime, cacheSvc, strategyRecorder, strategyScorer, errorClassifier, nodeHealth, consistencySvc)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to select beacon block proposal provider")
+		return nil, nil, nil, errors.Wrap(err, "failed to select beacon block proposal provider")
 	}
+	beaconBlockProposalProviderSwitch, err := switchableClient(ctx, "beacon block proposal provider", beaconBlockProposalProvider)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start switchable beacon block proposal provider")
+	}
+	beaconBlockProposalProvider = beaconBlockProposalProviderSwitch
 
 	log.Trace().Msg("Starting beacon block proposer")
 	beaconBlockProposer, err := standardbeaconblockproposer.New(ctx,
@@ -415,16 +572,23 @@ func startServices(ctx context.Context,
 		standardbeaconblockproposer.WithBeaconBlockSubmitter(submitterStrategy.(submitter.BeaconBlockSubmitter)),
 		standardbeaconblockproposer.WithRANDAORevealSigner(signerSvc.(signer.RANDAORevealSigner)),
 		standardbeaconblockproposer.WithBeaconBlockSigner(signerSvc.(signer.BeaconBlockSigner)),
+		standardbeaconblockproposer.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+		standardbeaconblockproposer.WithMaxParentDistance(phase0.Slot(viper.GetUint64("beaconblockproposer.max-parent-distance"))),
 	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start beacon block proposer service")
+		return nil, nil, nil, errors.Wrap(err, "failed to start beacon block proposer service")
 	}
 
 	log.Trace().Msg("Selecting attestation data provider")
-	attestationDataProvider, err := selectAttestationDataProvider(ctx, monitor, eth2Client, chainTime, cache)
+	attestationDataProvider, err := selectAttestationDataProvider(ctx, monitor, eth2Client, chainTime, cacheSvc, strategyRecorder, strategyScorer, errorClassifier, nodeHealth, consistencySvc)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to select attestation data provider")
+	}
+	attestationDataProviderSwitch, err := switchableClient(ctx, "attestation data provider", attestationDataProvider)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to select attestation data provider")
+		return nil, nil, nil, errors.Wrap(err, "failed to start switchable attestation data provider")
 	}
+	attestationDataProvider = attestationDataProviderSwitch
 
 	log.Trace().Msg("Starting attester")
 	attester, err := standardattester.New(ctx,
@@ -438,14 +602,19 @@ func startServices(ctx context.Context,
 		standardattester.WithBeaconAttestationsSigner(signerSvc.(signer.BeaconAttestationsSigner)),
 	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start attester service")
+		return nil, nil, nil, errors.Wrap(err, "failed to start attester service")
 	}
 
 	log.Trace().Msg("Selecting aggregate attestation provider")
-	aggregateAttestationProvider, err := selectAggregateAttestationProvider(ctx, monitor, eth2Client)
+	aggregateAttestationProvider, err := selectAggregateAttestationProvider(ctx, monitor, eth2Client, chainTime, strategyRecorder, strategyScorer, errorClassifier, nodeHealth, consistencySvc)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to select aggregate attestation provider")
+		return nil, nil, nil, errors.Wrap(err, "failed to select aggregate attestation provider")
 	}
+	aggregateAttestationProviderSwitch, err := switchableClient(ctx, "aggregate attestation provider", aggregateAttestationProvider)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start switchable aggregate attestation provider")
+	}
+	aggregateAttestationProvider = aggregateAttestationProviderSwitch
 
 	log.Trace().Msg("Starting beacon attestation aggregator")
 	attestationAggregator, err := standardattestationaggregator.New(ctx,
@@ -460,8 +629,19 @@ func startServices(ctx context.Context,
 		standardattestationaggregator.WithSlotsPerEpochProvider(eth2Client.(eth2client.SlotsPerEpochProvider)),
 	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start beacon attestation aggregator service")
+		return nil, nil, nil, errors.Wrap(err, "failed to start beacon attestation aggregator service")
+	}
+
+	log.Trace().Msg("Selecting duties provider")
+	dutiesProvider, err := selectDutiesProvider(ctx, monitor, eth2Client, nodeHealth, consistencySvc)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to select duties provider")
+	}
+	dutiesProviderSwitch, err := switchableClient(ctx, "duties provider", dutiesProvider)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to start switchable duties provider")
 	}
+	dutiesProvider = dutiesProviderSwitch
 
 	log.Trace().Msg("Starting beacon committee subscriber service")
 	beaconCommitteeSubscriber, err := standardbeaconcommitteesubscriber.New(ctx,
@@ -469,19 +649,19 @@ func startServices(ctx context.Context,
 		standardbeaconcommitteesubscriber.WithProcessConcurrency(util.ProcessConcurrency("beaconcommitteesubscriber")),
 		standardbeaconcommitteesubscriber.WithMonitor(monitor.(metrics.BeaconCommitteeSubscriptionMonitor)),
 		standardbeaconcommitteesubscriber.WithChainTimeService(chainTime),
-		standardbeaconcommitteesubscriber.WithAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
+		standardbeaconcommitteesubscriber.WithAttesterDutiesProvider(dutiesProvider),
 		standardbeaconcommitteesubscriber.WithAttestationAggregator(attestationAggregator),
 		standardbeaconcommitteesubscriber.WithBeaconCommitteeSubmitter(submitterStrategy.(submitter.BeaconCommitteeSubscriptionsSubmitter)),
 	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start beacon committee subscriber service")
+		return nil, nil, nil, errors.Wrap(err, "failed to start beacon committee subscriber service")
 	}
 
 	// Decide if the ETH2 client is capable of Altair.
 	altairCapable := false
 	spec, err := eth2Client.(eth2client.SpecProvider).Spec(ctx)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to obtain spec")
+		return nil, nil, nil, errors.Wrap(err, "failed to obtain spec")
 	}
 	if _, exists := spec["INACTIVITY_PENALTY_QUOTIENT_ALTAIR"]; exists {
 		altairCapable = true
@@ -503,6 +683,7 @@ func startServices(ctx context.Context,
 	var syncCommitteeSubscriber synccommitteesubscriber.Service
 	var syncCommitteeMessenger synccommitteemessenger.Service
 	var syncCommitteeAggregator synccommitteeaggregator.Service
+	var syncCommitteeContributionProviderSwitch *switchable.Service
 	if altairCapable {
 		log.Trace().Msg("Starting sync committee subscriber service")
 		syncCommitteeSubscriber, err = standardsynccommitteesubscriber.New(ctx,
@@ -511,13 +692,17 @@ func startServices(ctx context.Context,
 			standardsynccommitteesubscriber.WithSyncCommitteeSubmitter(submitterStrategy.(submitter.SyncCommitteeSubscriptionsSubmitter)),
 		)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to start beacon committee subscriber service")
+			return nil, nil, nil, errors.Wrap(err, "failed to start beacon committee subscriber service")
 		}
 
 		log.Trace().Msg("Selecting sync committee contribution provider")
-		syncCommitteeContributionProvider, err := selectSyncCommitteeContributionProvider(ctx, monitor, eth2Client)
+		syncCommitteeContributionProvider, err := selectSyncCommitteeContributionProvider(ctx, monitor, eth2Client, chainTime, strategyRecorder, strategyScorer, errorClassifier, nodeHealth, consistencySvc, nodeCapabilities)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to select sync committee contribution provider")
+			return nil, nil, nil, errors.Wrap(err, "failed to select sync committee contribution provider")
+		}
+		syncCommitteeContributionProviderSwitch, err = switchableClient(ctx, "sync committee contribution provider", syncCommitteeContributionProvider)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to start switchable sync committee contribution provider")
 		}
 
 		log.Trace().Msg("Starting sync committee aggregator")
@@ -528,11 +713,11 @@ func startServices(ctx context.Context,
 			standardsynccommitteeaggregator.WithBeaconBlockRootProvider(eth2Client.(eth2client.BeaconBlockRootProvider)),
 			standardsynccommitteeaggregator.WithContributionAndProofSigner(signerSvc.(signer.ContributionAndProofSigner)),
 			standardsynccommitteeaggregator.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
-			standardsynccommitteeaggregator.WithSyncCommitteeContributionProvider(syncCommitteeContributionProvider),
+			standardsynccommitteeaggregator.WithSyncCommitteeContributionProvider(syncCommitteeContributionProviderSwitch),
 			standardsynccommitteeaggregator.WithSyncCommitteeContributionsSubmitter(submitterStrategy.(submitter.SyncCommitteeContributionsSubmitter)),
 		)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to start sync committee aggregator service")
+			return nil, nil, nil, errors.Wrap(err, "failed to start sync committee aggregator service")
 		}
 
 		log.Trace().Msg("Starting sync committee messenger")
@@ -551,7 +736,7 @@ func startServices(ctx context.Context,
 			standardsynccommitteemessenger.WithSyncCommitteeSubscriptionsSubmitter(submitterStrategy.(submitter.SyncCommitteeSubscriptionsSubmitter)),
 		)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to start sync committee messenger service")
+			return nil, nil, nil, errors.Wrap(err, "failed to start sync committee messenger service")
 		}
 	}
 
@@ -567,7 +752,7 @@ func startServices(ctx context.Context,
 			standardproposalpreparer.WithProposalPreparationsSubmitter(submitterStrategy.(eth2client.ProposalPreparationsSubmitter)),
 		)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to start proposal preparer service")
+			return nil, nil, nil, errors.Wrap(err, "failed to start proposal preparer service")
 		}
 	}
 
@@ -578,10 +763,10 @@ func startServices(ctx context.Context,
 		standardcontroller.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
 		standardcontroller.WithForkScheduleProvider(eth2Client.(eth2client.ForkScheduleProvider)),
 		standardcontroller.WithChainTimeService(chainTime),
-		standardcontroller.WithProposerDutiesProvider(eth2Client.(eth2client.ProposerDutiesProvider)),
-		standardcontroller.WithAttesterDutiesProvider(eth2Client.(eth2client.AttesterDutiesProvider)),
-		standardcontroller.WithSyncCommitteeDutiesProvider(eth2Client.(eth2client.SyncCommitteeDutiesProvider)),
-		standardcontroller.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
+		standardcontroller.WithProposerDutiesProvider(dutiesProvider),
+		standardcontroller.WithAttesterDutiesProvider(dutiesProvider),
+		standardcontroller.WithSyncCommitteeDutiesProvider(dutiesProvider),
+		standardcontroller.WithEventsProvider(eventsProvider),
 		standardcontroller.WithScheduler(scheduler),
 		standardcontroller.WithValidatingAccountsProvider(accountManager.(accountmanager.ValidatingAccountsProvider)),
 		standardcontroller.WithAttester(attester),
@@ -603,10 +788,46 @@ func startServices(ctx context.Context,
 		standardcontroller.WithReorgs(viper.GetBool("controller.reorgs")),
 	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to start controller service")
+		return nil, nil, nil, errors.Wrap(err, "failed to start controller service")
+	}
+
+	// Clients used by services that are not reloaded are retained on reload.
+	retained := util.BeaconNodeAddresses("clockdrift")
+	eventAggregator, isAggregator := eventsProvider.(*eventaggregator.Service)
+	if !isAggregator {
+		// Events are obtained from the original client, so it must be retained.
+		retained = append(retained, consensusClientKey())
+	}
+	reloader := &reloader{
+		majordomo:                         majordomo,
+		monitor:                           monitor,
+		chainTime:                         chainTime,
+		cacheSvc:                          cacheSvc,
+		strategyRecorder:                  strategyRecorder,
+		strategyScorer:                    strategyScorer,
+		errorClassifier:                   errorClassifier,
+		clockDrift:                        clockDrift,
+		consistency:                       consistencySvc,
+		nodeHealth:                        nodeHealth,
+		nodeCapabilities:                  nodeCapabilities,
+		eventsProvider:                    eventsProvider,
+		eventAggregator:                   eventAggregator,
+		retained:                          retained,
+		eth2Client:                        eth2ClientSwitch,
+		submitter:                         submitterSwitch,
+		beaconBlockProposalProvider:       beaconBlockProposalProviderSwitch,
+		attestationDataProvider:           attestationDataProviderSwitch,
+		dutiesProvider:                    dutiesProviderSwitch,
+		aggregateAttestationProvider:      aggregateAttestationProviderSwitch,
+		syncCommitteeContributionProvider: syncCommitteeContributionProviderSwitch,
+	}
+	if viper.GetString("reload.listen-address") != "" {
+		if err := startReloadServer(ctx, reloader, viper.GetString("reload.listen-address")); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to start reload server")
+		}
 	}
 
-	return chainTime, controller, nil
+	return chainTime, controller, reloader, nil
 }
 
 // logModules logs a list of modules with their versions.
@@ -752,12 +973,62 @@ func selectScheduler(ctx context.Context, monitor metrics.Service) (scheduler.Se
 	return scheduler, nil
 }
 
+// startEventAggregator starts the event aggregator given user input.
+// If events are obtained from a single beacon node this returns the beacon node directly.
+func startEventAggregator(ctx context.Context,
+	monitor metrics.Service,
+	chainTime chaintime.Service,
+	eth2Client eth2client.Service,
+) (
+	eth2client.EventsProvider,
+	error,
+) {
+	addresses := util.BeaconNodeAddresses("eventaggregator")
+	if len(addresses) < 2 {
+		return eth2Client.(eth2client.EventsProvider), nil
+	}
+
+	eventsProviders, err := fetchEventsProviders(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	eventAggregator, err := eventaggregator.New(ctx,
+		eventaggregator.WithLogLevel(util.LogLevel("eventaggregator")),
+		eventaggregator.WithMonitor(monitor),
+		eventaggregator.WithChainTime(chainTime),
+		eventaggregator.WithEventsProviders(eventsProviders),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventAggregator, nil
+}
+
+// fetchEventsProviders fetches the events providers for the event aggregator.
+func fetchEventsProviders(ctx context.Context, addresses []string) (map[string]eth2client.EventsProvider, error) {
+	eventsProviders := make(map[string]eth2client.EventsProvider)
+	for _, address := range addresses {
+		client, err := fetchClient(ctx, address)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for event aggregator", address))
+		}
+		if eventsProvider, isProvider := client.(eth2client.EventsProvider); isProvider {
+			eventsProviders[address] = eventsProvider
+		}
+	}
+
+	return eventsProviders, nil
+}
+
 // startCache starts the relevant cache given user input.
 func startCache(ctx context.Context,
 	monitor metrics.Service,
 	chainTime chaintime.Service,
 	scheduler scheduler.Service,
 	consensusClient eth2client.Service,
+	eventsProvider eth2client.EventsProvider,
 ) (cache.Service, error) {
 	log.Trace().Msg("Starting cache")
 	cache, err := standardcache.New(ctx,
@@ -766,6 +1037,7 @@ func startCache(ctx context.Context,
 		standardcache.WithScheduler(scheduler),
 		standardcache.WithChainTime(chainTime),
 		standardcache.WithConsensusClient(consensusClient),
+		standardcache.WithEventsProvider(eventsProvider),
 	)
 	if err != nil {
 		return nil, err
@@ -774,6 +1046,133 @@ func startCache(ctx context.Context,
 	return cache, nil
 }
 
+// startClockDrift starts the clock drift service given user input.
+// This returns nil if clock drift monitoring is disabled.
+func startClockDrift(ctx context.Context,
+	monitor metrics.Service,
+	chainTime chaintime.Service,
+	scheduler scheduler.Service,
+) (clockdrift.Service, error) {
+	if !viper.GetBool("clockdrift.enable") {
+		log.Debug().Msg("Clock drift monitoring disabled")
+		return nil, nil
+	}
+
+	addresses := util.BeaconNodeAddresses("clockdrift")
+	eventsProviders := make(map[string]eth2client.EventsProvider)
+	for _, address := range addresses {
+		client, err := fetchClient(ctx, address)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for clock drift", address))
+		}
+		if eventsProvider, isProvider := client.(eth2client.EventsProvider); isProvider {
+			eventsProviders[address] = eventsProvider
+		}
+	}
+
+	clockDrift, err := standardclockdrift.New(ctx,
+		standardclockdrift.WithLogLevel(util.LogLevel("clockdrift")),
+		standardclockdrift.WithMonitor(monitor),
+		standardclockdrift.WithScheduler(scheduler),
+		standardclockdrift.WithChainTime(chainTime),
+		standardclockdrift.WithTimeout(util.Timeout("clockdrift")),
+		standardclockdrift.WithInterval(viper.GetDuration("clockdrift.interval")),
+		standardclockdrift.WithAddresses(addresses),
+		standardclockdrift.WithEndpoints(currentClientProxies()),
+		standardclockdrift.WithEventsProviders(eventsProviders),
+		standardclockdrift.WithWarnThreshold(viper.GetDuration("clockdrift.warn-threshold")),
+		standardclockdrift.WithMaxDrift(viper.GetDuration("clockdrift.max-drift")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return clockDrift, nil
+}
+
+// startConsistency starts the consistency service given user input.
+// This returns a service that considers all beacon nodes consistent if consistency checks are disabled.
+func startConsistency(ctx context.Context,
+	monitor metrics.Service,
+	scheduler scheduler.Service,
+) (
+	consistency.Service,
+	error,
+) {
+	if !viper.GetBool("consistency.enable") {
+		log.Debug().Msg("Consistency checks disabled")
+		return nullconsistency.New(ctx), nil
+	}
+
+	return standardconsistency.New(ctx,
+		standardconsistency.WithLogLevel(util.LogLevel("consistency")),
+		standardconsistency.WithMonitor(monitor),
+		standardconsistency.WithScheduler(scheduler),
+		standardconsistency.WithTimeout(util.Timeout("consistency")),
+		standardconsistency.WithInterval(viper.GetDuration("consistency.interval")),
+		standardconsistency.WithAddresses(util.BeaconNodeAddresses("consistency")),
+		standardconsistency.WithMainAddresses(consensusClientAddresses()),
+		standardconsistency.WithEndpoints(currentClientProxies()),
+	)
+}
+
+// startNodeHealth starts the node health service given user input.
+// This returns a service that considers all beacon nodes healthy if node health checks are disabled.
+func startNodeHealth(ctx context.Context,
+	monitor metrics.Service,
+	chainTime chaintime.Service,
+	scheduler scheduler.Service,
+) (
+	nodehealth.Service,
+	error,
+) {
+	if !viper.GetBool("nodehealth.enable") {
+		log.Debug().Msg("Node health checks disabled")
+		return nullnodehealth.New(ctx), nil
+	}
+
+	return standardnodehealth.New(ctx,
+		standardnodehealth.WithLogLevel(util.LogLevel("nodehealth")),
+		standardnodehealth.WithMonitor(monitor),
+		standardnodehealth.WithScheduler(scheduler),
+		standardnodehealth.WithChainTime(chainTime),
+		standardnodehealth.WithTimeout(util.Timeout("nodehealth")),
+		standardnodehealth.WithInterval(viper.GetDuration("nodehealth.interval")),
+		standardnodehealth.WithAddresses(util.BeaconNodeAddresses("nodehealth")),
+		standardnodehealth.WithEndpoints(currentClientProxies()),
+		standardnodehealth.WithDegradedHeadLag(viper.GetUint64("nodehealth.degraded-head-lag")),
+		standardnodehealth.WithExcludedHeadLag(viper.GetUint64("nodehealth.excluded-head-lag")),
+		standardnodehealth.WithMinPeers(viper.GetUint64("nodehealth.min-peers")),
+		standardnodehealth.WithExcludeOptimistic(viper.GetBool("nodehealth.exclude-optimistic")),
+		standardnodehealth.WithHysteresis(viper.GetInt("nodehealth.hysteresis")),
+	)
+}
+
+// startCapabilities starts the capabilities service given user input.
+// This returns a service that considers all beacon nodes capable if capability probing is disabled.
+func startCapabilities(ctx context.Context,
+	monitor metrics.Service,
+	scheduler scheduler.Service,
+) (
+	capabilities.Service,
+	error,
+) {
+	if !viper.GetBool("capabilities.enable") {
+		log.Debug().Msg("Capability probing disabled")
+		return nullcapabilities.New(ctx), nil
+	}
+
+	return standardcapabilities.New(ctx,
+		standardcapabilities.WithLogLevel(util.LogLevel("capabilities")),
+		standardcapabilities.WithMonitor(monitor),
+		standardcapabilities.WithScheduler(scheduler),
+		standardcapabilities.WithTimeout(util.Timeout("capabilities")),
+		standardcapabilities.WithInterval(viper.GetDuration("capabilities.interval")),
+		standardcapabilities.WithAddresses(util.BeaconNodeAddresses("capabilities")),
+		standardcapabilities.WithEndpoints(currentClientProxies()),
+	)
+}
+
 // startFeeRecipientProvider starts the appropriate fee recipient provider given user input.
 func startFeeRecipientProvider(ctx context.Context, monitor metrics.Service, majordomo majordomo.Service) (feerecipientprovider.Service, error) {
 	addr := viper.GetString("feerecipient.default-address")
@@ -888,14 +1287,18 @@ func startValidatorsManager(ctx context.Context, monitor metrics.Service, eth2Cl
 	return validatorsManager, nil
 }
 
-func startSigner(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service) (signer.Service, error) {
-	signer, err := standardsigner.New(ctx,
+func startSigner(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service, clockDrift clockdrift.Service) (signer.Service, error) {
+	params := []standardsigner.Parameter{
 		standardsigner.WithLogLevel(util.LogLevel("signer")),
 		standardsigner.WithMonitor(monitor.(metrics.SignerMonitor)),
 		standardsigner.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 		standardsigner.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
 		standardsigner.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
-	)
+	}
+	if checker, isChecker := clockDrift.(clockdrift.Checker); isChecker {
+		params = append(params, standardsigner.WithClockDriftChecker(checker))
+	}
+	signer, err := standardsigner.New(ctx, params...)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to start signer provider service")
@@ -981,12 +1384,64 @@ func startAccountManager(ctx context.Context, monitor metrics.Service, eth2Clien
 	return nil, errors.New("no account manager defined")
 }
 
+// startStrategyRecorder starts the strategy decision recorder.
+// This returns a recorder that records nothing if no path is configured.
+func startStrategyRecorder(ctx context.Context, monitor metrics.Service) (recorder.Service, error) {
+	if viper.GetString("strategies.recorder.path") == "" {
+		return nullrecorder.New(ctx), nil
+	}
+
+	log.Info().Msg("Starting strategy decision recorder")
+	return standardrecorder.New(ctx,
+		standardrecorder.WithLogLevel(util.LogLevel("strategies.recorder")),
+		standardrecorder.WithMonitor(monitor),
+		standardrecorder.WithPath(resolvePath(viper.GetString("strategies.recorder.path"))),
+		standardrecorder.WithMaxSize(viper.GetInt64("strategies.recorder.max-size")),
+		standardrecorder.WithMaxFiles(viper.GetInt("strategies.recorder.max-files")),
+	)
+}
+
+// startErrorClassifier starts the classifier for errors returned by beacon nodes.
+func startErrorClassifier(ctx context.Context, monitor metrics.Service) (errorclassifier.Service, error) {
+	rules := make([]*errorclassifier.Rule, 0)
+	if err := viper.UnmarshalKey("errorclassifier.rules", &rules); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain error classifier rules")
+	}
+
+	return standarderrorclassifier.New(ctx,
+		standarderrorclassifier.WithLogLevel(util.LogLevel("errorclassifier")),
+		standarderrorclassifier.WithMonitor(monitor),
+		standarderrorclassifier.WithRules(rules),
+	)
+}
+
+// startStrategyScorer starts the external scorer for strategies.
+// This returns a scorer that always uses the built-in score if no address is configured.
+func startStrategyScorer(ctx context.Context, monitor metrics.Service) (scorer.Service, error) {
+	if viper.GetString("strategies.scorer.address") == "" {
+		return nullscorer.New(ctx), nil
+	}
+
+	log.Info().Msg("Starting strategy external scorer")
+	return standardscorer.New(ctx,
+		standardscorer.WithLogLevel(util.LogLevel("strategies.scorer")),
+		standardscorer.WithMonitor(monitor),
+		standardscorer.WithAddress(viper.GetString("strategies.scorer.address")),
+		standardscorer.WithTimeout(viper.GetDuration("strategies.scorer.timeout")),
+	)
+}
+
 // selectAttestationDataProvider selects the appropriate attestation data provider given user input.
 func selectAttestationDataProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
 	chainTime chaintime.Service,
 	cacheSvc cache.Service,
+	strategyRecorder recorder.Service,
+	strategyScorer scorer.Service,
+	errorClassifier errorclassifier.Service,
+	nodeHealth nodehealth.Service,
+	consistencySvc consistency.Service,
 ) (eth2client.AttestationDataProvider, error) {
 	var attestationDataProvider eth2client.AttestationDataProvider
 	var err error
@@ -1003,16 +1458,50 @@ func selectAttestationDataProvider(ctx context.Context,
 		}
 		attestationDataProvider, err = bestattestationdatastrategy.New(ctx,
 			bestattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			bestattestationdatastrategy.WithMonitor(monitor),
+			bestattestationdatastrategy.WithRecorder(strategyRecorder),
+			bestattestationdatastrategy.WithScorer(strategyScorer),
+			bestattestationdatastrategy.WithClassifier(errorClassifier),
 			bestattestationdatastrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
 			bestattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.best")),
+			bestattestationdatastrategy.WithNodeHealth(nodeHealth),
+			bestattestationdatastrategy.WithConsistency(consistencySvc),
+			bestattestationdatastrategy.WithDeadline(util.Deadline("strategies.attestationdata.best")),
 			bestattestationdatastrategy.WithChainTime(chainTime),
 			bestattestationdatastrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best attestation data strategy")
 		}
+	case "majority":
+		log.Info().Msg("Starting majority attestation data strategy")
+		attestationDataProviders := make(map[string]eth2client.AttestationDataProvider)
+		for _, address := range util.BeaconNodeAddresses("strategies.attestationdata.majority") {
+			client, err := fetchClient(ctx, address)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for attestation data strategy", address))
+			}
+			attestationDataProviders[address] = client.(eth2client.AttestationDataProvider)
+		}
+		attestationDataProvider, err = majorityattestationdatastrategy.New(ctx,
+			majorityattestationdatastrategy.WithMonitor(monitor),
+			majorityattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			majorityattestationdatastrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.attestationdata.majority")),
+			majorityattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.majority")),
+			majorityattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
+			majorityattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.majority")),
+			majorityattestationdatastrategy.WithNodeHealth(nodeHealth),
+			majorityattestationdatastrategy.WithConsistency(consistencySvc),
+			majorityattestationdatastrategy.WithDeadline(util.Deadline("strategies.attestationdata.majority")),
+			majorityattestationdatastrategy.WithQuorum(viper.GetInt("strategies.attestationdata.majority.quorum")),
+			majorityattestationdatastrategy.WithChainTime(chainTime),
+			majorityattestationdatastrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start majority attestation data strategy")
+		}
 	case "first":
 		log.Info().Msg("Starting first attestation data strategy")
 		attestationDataProviders := make(map[string]eth2client.AttestationDataProvider)
@@ -1025,9 +1514,15 @@ func selectAttestationDataProvider(ctx context.Context,
 		}
 		attestationDataProvider, err = firstattestationdatastrategy.New(ctx,
 			firstattestationdatastrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			firstattestationdatastrategy.WithMonitor(monitor),
 			firstattestationdatastrategy.WithLogLevel(util.LogLevel("strategies.attestationdata.first")),
 			firstattestationdatastrategy.WithAttestationDataProviders(attestationDataProviders),
 			firstattestationdatastrategy.WithTimeout(util.Timeout("strategies.attestationdata.first")),
+			firstattestationdatastrategy.WithNodeHealth(nodeHealth),
+			firstattestationdatastrategy.WithConsistency(consistencySvc),
+			firstattestationdatastrategy.WithHedgeDelay(viper.GetDuration("strategies.attestationdata.first.hedge-delay")),
+			firstattestationdatastrategy.WithChainTime(chainTime),
+			firstattestationdatastrategy.WithDeadline(util.Deadline("strategies.attestationdata.first")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first attestation data strategy")
@@ -1040,10 +1535,69 @@ func selectAttestationDataProvider(ctx context.Context,
 	return attestationDataProvider, nil
 }
 
+// dutiesProvider provides attester, proposer and sync committee duties.
+type dutiesProvider interface {
+	eth2client.AttesterDutiesProvider
+	eth2client.ProposerDutiesProvider
+	eth2client.SyncCommitteeDutiesProvider
+}
+
+// selectDutiesProvider selects the appropriate duties provider given user input.
+func selectDutiesProvider(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	nodeHealth nodehealth.Service,
+	consistencySvc consistency.Service,
+) (dutiesProvider, error) {
+	var provider dutiesProvider
+	var err error
+	switch viper.GetString("strategies.duties.style") {
+	case "crosscheck":
+		log.Info().Msg("Starting crosscheck duties strategy")
+		attesterDutiesProviders := make(map[string]eth2client.AttesterDutiesProvider)
+		proposerDutiesProviders := make(map[string]eth2client.ProposerDutiesProvider)
+		syncCommitteeDutiesProviders := make(map[string]eth2client.SyncCommitteeDutiesProvider)
+		for _, address := range util.BeaconNodeAddresses("strategies.duties.crosscheck") {
+			client, err := fetchClient(ctx, address)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for duties strategy", address))
+			}
+			attesterDutiesProviders[address] = client.(eth2client.AttesterDutiesProvider)
+			proposerDutiesProviders[address] = client.(eth2client.ProposerDutiesProvider)
+			syncCommitteeDutiesProviders[address] = client.(eth2client.SyncCommitteeDutiesProvider)
+		}
+		provider, err = crosscheckdutiesstrategy.New(ctx,
+			crosscheckdutiesstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			crosscheckdutiesstrategy.WithMonitor(monitor),
+			crosscheckdutiesstrategy.WithLogLevel(util.LogLevel("strategies.duties.crosscheck")),
+			crosscheckdutiesstrategy.WithAttesterDutiesProviders(attesterDutiesProviders),
+			crosscheckdutiesstrategy.WithProposerDutiesProviders(proposerDutiesProviders),
+			crosscheckdutiesstrategy.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+			crosscheckdutiesstrategy.WithTimeout(util.Timeout("strategies.duties.crosscheck")),
+			crosscheckdutiesstrategy.WithNodeHealth(nodeHealth),
+			crosscheckdutiesstrategy.WithConsistency(consistencySvc),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start crosscheck duties strategy")
+		}
+	default:
+		log.Info().Msg("Starting simple duties strategy")
+		provider = eth2Client.(dutiesProvider)
+	}
+
+	return provider, nil
+}
+
 // selectAggregateAttestationProvider selects the appropriate aggregate attestation provider given user input.
 func selectAggregateAttestationProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	strategyRecorder recorder.Service,
+	strategyScorer scorer.Service,
+	errorClassifier errorclassifier.Service,
+	nodeHealth nodehealth.Service,
+	consistencySvc consistency.Service,
 ) (
 	eth2client.AggregateAttestationProvider,
 	error,
@@ -1063,14 +1617,51 @@ func selectAggregateAttestationProvider(ctx context.Context,
 		}
 		aggregateAttestationProvider, err = bestaggregateattestationstrategy.New(ctx,
 			bestaggregateattestationstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			bestaggregateattestationstrategy.WithMonitor(monitor),
+			bestaggregateattestationstrategy.WithRecorder(strategyRecorder),
+			bestaggregateattestationstrategy.WithScorer(strategyScorer),
+			bestaggregateattestationstrategy.WithClassifier(errorClassifier),
 			bestaggregateattestationstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.aggregateattestation.best")),
 			bestaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.best")),
 			bestaggregateattestationstrategy.WithAggregateAttestationProviders(aggregateAttestationProviders),
 			bestaggregateattestationstrategy.WithTimeout(util.Timeout("strategies.aggregateattestation.best")),
+			bestaggregateattestationstrategy.WithNodeHealth(nodeHealth),
+			bestaggregateattestationstrategy.WithConsistency(consistencySvc),
+			bestaggregateattestationstrategy.WithDeadline(util.Deadline("strategies.aggregateattestation.best")),
+			bestaggregateattestationstrategy.WithChainTime(chainTime),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best aggregate attestation strategy")
 		}
+	case "merge":
+		log.Info().Msg("Starting merge aggregate attestation strategy")
+		aggregateAttestationProviders := make(map[string]eth2client.AggregateAttestationProvider)
+		for _, address := range util.BeaconNodeAddresses("strategies.aggregateattestation.merge") {
+			client, err := fetchClient(ctx, address)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for aggregate attestation strategy", address))
+			}
+			aggregateAttestationProviders[address] = client.(eth2client.AggregateAttestationProvider)
+		}
+		aggregateAttestationProvider, err = mergeaggregateattestationstrategy.New(ctx,
+			mergeaggregateattestationstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			mergeaggregateattestationstrategy.WithMonitor(monitor),
+			mergeaggregateattestationstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.aggregateattestation.merge")),
+			mergeaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.merge")),
+			mergeaggregateattestationstrategy.WithAggregateAttestationProviders(aggregateAttestationProviders),
+			mergeaggregateattestationstrategy.WithTimeout(util.Timeout("strategies.aggregateattestation.merge")),
+			mergeaggregateattestationstrategy.WithNodeHealth(nodeHealth),
+			mergeaggregateattestationstrategy.WithConsistency(consistencySvc),
+			mergeaggregateattestationstrategy.WithDeadline(util.Deadline("strategies.aggregateattestation.merge")),
+			mergeaggregateattestationstrategy.WithChainTime(chainTime),
+			mergeaggregateattestationstrategy.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+			mergeaggregateattestationstrategy.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
+			mergeaggregateattestationstrategy.WithBeaconCommitteesProvider(eth2Client.(eth2client.BeaconCommitteesProvider)),
+			mergeaggregateattestationstrategy.WithValidatorsProvider(eth2Client.(eth2client.ValidatorsProvider)),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start merge aggregate attestation strategy")
+		}
 	case "first":
 		log.Info().Msg("Starting first aggregate attestation strategy")
 		aggregateAttestationProviders := make(map[string]eth2client.AggregateAttestationProvider)
@@ -1083,9 +1674,15 @@ func selectAggregateAttestationProvider(ctx context.Context,
 		}
 		aggregateAttestationProvider, err = firstaggregateattestationstrategy.New(ctx,
 			firstaggregateattestationstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			firstaggregateattestationstrategy.WithMonitor(monitor),
 			firstaggregateattestationstrategy.WithLogLevel(util.LogLevel("strategies.aggregateattestation.first")),
 			firstaggregateattestationstrategy.WithAggregateAttestationProviders(aggregateAttestationProviders),
 			firstaggregateattestationstrategy.WithTimeout(util.Timeout("strategies.aggregateattestation.first")),
+			firstaggregateattestationstrategy.WithNodeHealth(nodeHealth),
+			firstaggregateattestationstrategy.WithConsistency(consistencySvc),
+			firstaggregateattestationstrategy.WithHedgeDelay(viper.GetDuration("strategies.aggregateattestation.first.hedge-delay")),
+			firstaggregateattestationstrategy.WithChainTime(chainTime),
+			firstaggregateattestationstrategy.WithDeadline(util.Deadline("strategies.aggregateattestation.first")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first aggregate attestation strategy")
@@ -1102,8 +1699,14 @@ func selectAggregateAttestationProvider(ctx context.Context,
 func selectBeaconBlockProposalProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	eventsProvider eth2client.EventsProvider,
 	chainTime chaintime.Service,
 	cacheSvc cache.Service,
+	strategyRecorder recorder.Service,
+	strategyScorer scorer.Service,
+	errorClassifier errorclassifier.Service,
+	nodeHealth nodehealth.Service,
+	consistencySvc consistency.Service,
 ) (eth2client.BeaconBlockProposalProvider, error) {
 	var beaconBlockProposalProvider eth2client.BeaconBlockProposalProvider
 	var err error
@@ -1120,14 +1723,21 @@ func selectBeaconBlockProposalProvider(ctx context.Context,
 		}
 		beaconBlockProposalProvider, err = bestbeaconblockproposalstrategy.New(ctx,
 			bestbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			bestbeaconblockproposalstrategy.WithMonitor(monitor),
+			bestbeaconblockproposalstrategy.WithRecorder(strategyRecorder),
+			bestbeaconblockproposalstrategy.WithScorer(strategyScorer),
+			bestbeaconblockproposalstrategy.WithClassifier(errorClassifier),
 			bestbeaconblockproposalstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.beaconblockproposal.best")),
 			bestbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockproposal.best")),
-			bestbeaconblockproposalstrategy.WithEventsProvider(eth2Client.(eth2client.EventsProvider)),
+			bestbeaconblockproposalstrategy.WithEventsProvider(eventsProvider),
 			bestbeaconblockproposalstrategy.WithChainTimeService(chainTime),
 			bestbeaconblockproposalstrategy.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
 			bestbeaconblockproposalstrategy.WithBeaconBlockProposalProviders(beaconBlockProposalProviders),
 			bestbeaconblockproposalstrategy.WithSignedBeaconBlockProvider(eth2Client.(eth2client.SignedBeaconBlockProvider)),
 			bestbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.beaconblockproposal.best")),
+			bestbeaconblockproposalstrategy.WithNodeHealth(nodeHealth),
+			bestbeaconblockproposalstrategy.WithConsistency(consistencySvc),
+			bestbeaconblockproposalstrategy.WithDeadline(util.Deadline("strategies.beaconblockproposal.best")),
 			bestbeaconblockproposalstrategy.WithBlockRootToSlotCache(cacheSvc.(cache.BlockRootToSlotProvider)),
 		)
 		if err != nil {
@@ -1145,9 +1755,15 @@ func selectBeaconBlockProposalProvider(ctx context.Context,
 		}
 		beaconBlockProposalProvider, err = firstbeaconblockproposalstrategy.New(ctx,
 			firstbeaconblockproposalstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			firstbeaconblockproposalstrategy.WithMonitor(monitor),
 			firstbeaconblockproposalstrategy.WithLogLevel(util.LogLevel("strategies.beaconblockproposal.first")),
 			firstbeaconblockproposalstrategy.WithBeaconBlockProposalProviders(beaconBlockProposalProviders),
 			firstbeaconblockproposalstrategy.WithTimeout(util.Timeout("strategies.beaconblockproposal.first")),
+			firstbeaconblockproposalstrategy.WithNodeHealth(nodeHealth),
+			firstbeaconblockproposalstrategy.WithConsistency(consistencySvc),
+			firstbeaconblockproposalstrategy.WithHedgeDelay(viper.GetDuration("strategies.beaconblockproposal.first.hedge-delay")),
+			firstbeaconblockproposalstrategy.WithChainTime(chainTime),
+			firstbeaconblockproposalstrategy.WithDeadline(util.Deadline("strategies.beaconblockproposal.first")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first beacon block proposal strategy")
@@ -1164,6 +1780,13 @@ func selectBeaconBlockProposalProvider(ctx context.Context,
 func selectSyncCommitteeContributionProvider(ctx context.Context,
 	monitor metrics.Service,
 	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	strategyRecorder recorder.Service,
+	strategyScorer scorer.Service,
+	errorClassifier errorclassifier.Service,
+	nodeHealth nodehealth.Service,
+	consistencySvc consistency.Service,
+	nodeCapabilities capabilities.Service,
 ) (eth2client.SyncCommitteeContributionProvider, error) {
 	var syncCommitteeContributionProvider eth2client.SyncCommitteeContributionProvider
 	var err error
@@ -1180,14 +1803,53 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 		}
 		syncCommitteeContributionProvider, err = bestsynccommitteecontributionstrategy.New(ctx,
 			bestsynccommitteecontributionstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			bestsynccommitteecontributionstrategy.WithMonitor(monitor),
+			bestsynccommitteecontributionstrategy.WithRecorder(strategyRecorder),
+			bestsynccommitteecontributionstrategy.WithScorer(strategyScorer),
+			bestsynccommitteecontributionstrategy.WithClassifier(errorClassifier),
 			bestsynccommitteecontributionstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.synccommitteecontribution.best")),
 			bestsynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.best")),
 			bestsynccommitteecontributionstrategy.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
 			bestsynccommitteecontributionstrategy.WithTimeout(util.Timeout("strategies.synccommitteecontribution.best")),
+			bestsynccommitteecontributionstrategy.WithNodeHealth(nodeHealth),
+			bestsynccommitteecontributionstrategy.WithConsistency(consistencySvc),
+			bestsynccommitteecontributionstrategy.WithCapabilities(nodeCapabilities),
+			bestsynccommitteecontributionstrategy.WithDeadline(util.Deadline("strategies.synccommitteecontribution.best")),
+			bestsynccommitteecontributionstrategy.WithChainTime(chainTime),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start best sync committee contribution strategy")
 		}
+	case "merge":
+		log.Info().Msg("Starting merge sync committee contribution strategy")
+		syncCommitteeContributionProviders := make(map[string]eth2client.SyncCommitteeContributionProvider)
+		for _, address := range util.BeaconNodeAddresses("strategies.synccommitteecontribution.merge") {
+			client, err := fetchClient(ctx, address)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch client %s for sync committee contribution strategy", address))
+			}
+			syncCommitteeContributionProviders[address] = client.(eth2client.SyncCommitteeContributionProvider)
+		}
+		syncCommitteeContributionProvider, err = mergesynccommitteecontributionstrategy.New(ctx,
+			mergesynccommitteecontributionstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			mergesynccommitteecontributionstrategy.WithMonitor(monitor),
+			mergesynccommitteecontributionstrategy.WithProcessConcurrency(util.ProcessConcurrency("strategies.synccommitteecontribution.merge")),
+			mergesynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.merge")),
+			mergesynccommitteecontributionstrategy.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
+			mergesynccommitteecontributionstrategy.WithTimeout(util.Timeout("strategies.synccommitteecontribution.merge")),
+			mergesynccommitteecontributionstrategy.WithNodeHealth(nodeHealth),
+			mergesynccommitteecontributionstrategy.WithConsistency(consistencySvc),
+			mergesynccommitteecontributionstrategy.WithCapabilities(nodeCapabilities),
+			mergesynccommitteecontributionstrategy.WithDeadline(util.Deadline("strategies.synccommitteecontribution.merge")),
+			mergesynccommitteecontributionstrategy.WithChainTime(chainTime),
+			mergesynccommitteecontributionstrategy.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+			mergesynccommitteecontributionstrategy.WithDomainProvider(eth2Client.(eth2client.DomainProvider)),
+			mergesynccommitteecontributionstrategy.WithSyncCommitteesProvider(eth2Client.(eth2client.SyncCommitteesProvider)),
+			mergesynccommitteecontributionstrategy.WithValidatorsProvider(eth2Client.(eth2client.ValidatorsProvider)),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start merge sync committee contribution strategy")
+		}
 	case "first":
 		log.Info().Msg("Starting first sync committee contribution strategy")
 		syncCommitteeContributionProviders := make(map[string]eth2client.SyncCommitteeContributionProvider)
@@ -1200,9 +1862,16 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 		}
 		syncCommitteeContributionProvider, err = firstsynccommitteecontributionstrategy.New(ctx,
 			firstsynccommitteecontributionstrategy.WithClientMonitor(monitor.(metrics.ClientMonitor)),
+			firstsynccommitteecontributionstrategy.WithMonitor(monitor),
 			firstsynccommitteecontributionstrategy.WithLogLevel(util.LogLevel("strategies.synccommitteecontribution.first")),
 			firstsynccommitteecontributionstrategy.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
 			firstsynccommitteecontributionstrategy.WithTimeout(util.Timeout("strategies.synccommitteecontribution.first")),
+			firstsynccommitteecontributionstrategy.WithNodeHealth(nodeHealth),
+			firstsynccommitteecontributionstrategy.WithConsistency(consistencySvc),
+			firstsynccommitteecontributionstrategy.WithCapabilities(nodeCapabilities),
+			firstsynccommitteecontributionstrategy.WithHedgeDelay(viper.GetDuration("strategies.synccommitteecontribution.first.hedge-delay")),
+			firstsynccommitteecontributionstrategy.WithChainTime(chainTime),
+			firstsynccommitteecontributionstrategy.WithDeadline(util.Deadline("strategies.synccommitteecontribution.first")),
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start first sync committee contribution strategy")
@@ -1216,9 +1885,24 @@ func selectSyncCommitteeContributionProvider(ctx context.Context,
 }
 
 // selectSubmitterStrategy selects the appropriate submitter strategy given user input.
-func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2Client eth2client.Service) (submitter.Service, error) {
+func selectSubmitterStrategy(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	errorClassifier errorclassifier.Service,
+	nodeHealth nodehealth.Service,
+	consistencySvc consistency.Service,
+	nodeCapabilities capabilities.Service,
+) (
+	submitter.Service,
+	error,
+) {
+	retrier, err := startSubmitterRetrier(ctx, monitor, chainTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start submitter retrier")
+	}
+
 	var submitter submitter.Service
-	var err error
 	switch viper.GetString("submitter.style") {
 	case "multinode", "all":
 		log.Info().Msg("Starting multinode submitter strategy")
@@ -1296,10 +1980,14 @@ func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2C
 		}
 
 		submitter, err = multinodesubmitter.New(ctx,
+			multinodesubmitter.WithMonitor(monitor),
 			multinodesubmitter.WithClientMonitor(monitor.(metrics.ClientMonitor)),
 			multinodesubmitter.WithProcessConcurrency(util.ProcessConcurrency("submitter.multinode")),
 			multinodesubmitter.WithLogLevel(util.LogLevel("submitter.multinode")),
 			multinodesubmitter.WithTimeout(util.Timeout("submitter.multinode")),
+			multinodesubmitter.WithNodeHealth(nodeHealth),
+			multinodesubmitter.WithConsistency(consistencySvc),
+			multinodesubmitter.WithCapabilities(nodeCapabilities),
 			multinodesubmitter.WithBeaconBlockSubmitters(beaconBlockSubmitters),
 			multinodesubmitter.WithAttestationsSubmitters(attestationsSubmitters),
 			multinodesubmitter.WithSyncCommitteeMessagesSubmitters(syncCommitteeMessagesSubmitters),
@@ -1308,6 +1996,15 @@ func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2C
 			multinodesubmitter.WithAggregateAttestationsSubmitters(aggregateAttestationSubmitters),
 			multinodesubmitter.WithBeaconCommitteeSubscriptionsSubmitters(beaconCommitteeSubscriptionsSubmitters),
 			multinodesubmitter.WithProposalPreparationsSubmitters(proposalPreparationSubmitters),
+			multinodesubmitter.WithRetrier(retrier),
+			multinodesubmitter.WithClassifier(errorClassifier),
+			multinodesubmitter.WithQuorums(submitterQuorums()),
+		)
+	case "dryrun":
+		log.Warn().Msg("Starting dry run submitter strategy; messages will not be sent to beacon nodes")
+		submitter, err = dryrunsubmitter.New(ctx,
+			dryrunsubmitter.WithLogLevel(util.LogLevel("submitter.dryrun")),
+			dryrunsubmitter.WithPath(resolvePath(viper.GetString("submitter.dryrun.path"))),
 		)
 	default:
 		log.Info().Msg("Starting standard submitter strategy")
@@ -1322,6 +2019,8 @@ func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2C
 			immediatesubmitter.WithBeaconCommitteeSubscriptionsSubmitter(eth2Client.(eth2client.BeaconCommitteeSubscriptionsSubmitter)),
 			immediatesubmitter.WithAggregateAttestationsSubmitter(eth2Client.(eth2client.AggregateAttestationsSubmitter)),
 			immediatesubmitter.WithProposalPreparationsSubmitter(eth2Client.(eth2client.ProposalPreparationsSubmitter)),
+			immediatesubmitter.WithRetrier(retrier),
+			immediatesubmitter.WithClassifier(errorClassifier),
 		)
 	}
 	if err != nil {
@@ -1330,13 +2029,132 @@ func selectSubmitterStrategy(ctx context.Context, monitor metrics.Service, eth2C
 	return submitter, nil
 }
 
+// startSubmitterJournal starts the journal for submitted messages, wrapping the given submitter.
+func startSubmitterJournal(ctx context.Context,
+	monitor metrics.Service,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	submitterStrategy submitter.Service,
+) (
+	submitter.Service,
+	error,
+) {
+	log.Info().Msg("Starting submitter journal")
+	return journalsubmitter.New(ctx,
+		journalsubmitter.WithLogLevel(util.LogLevel("submitter.journal")),
+		journalsubmitter.WithMonitor(monitor),
+		journalsubmitter.WithChainTime(chainTime),
+		journalsubmitter.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		journalsubmitter.WithSubmitter(submitterStrategy),
+		journalsubmitter.WithPath(resolvePath(viper.GetString("submitter.journal.path"))),
+	)
+}
+
+// submitterMessageTypes are the types of message sent by the submitter, as used in its configuration.
+var submitterMessageTypes = []string{
+	"aggregateattestation",
+	"attestation",
+	"beaconblock",
+	"beaconcommitteesubscription",
+	"proposalpreparation",
+	"synccommitteecontribution",
+	"synccommitteemessage",
+	"synccommitteesubscription",
+}
+
+// submitterQuorums returns the number of beacon nodes that must accept each type of message.
+func submitterQuorums() map[string]int {
+	quorums := make(map[string]int)
+	for _, messageType := range submitterMessageTypes {
+		key := fmt.Sprintf("submitter.%s.quorum", messageType)
+		if viper.IsSet(key) {
+			quorums[messageType] = viper.GetInt(key)
+		}
+	}
+	return quorums
+}
+
+// startSubmitterRetrier starts the retrier for failed submissions.
+func startSubmitterRetrier(ctx context.Context, monitor metrics.Service, chainTime chaintime.Service) (*retry.Service, error) {
+	policies := make(map[string]*retry.Policy, len(submitterMessageTypes))
+	for _, messageType := range submitterMessageTypes {
+		policies[messageType] = &retry.Policy{
+			MaxAttempts:    viper.GetInt(submitterRetryKey(messageType, "max-attempts")),
+			InitialBackoff: viper.GetDuration(submitterRetryKey(messageType, "initial-backoff")),
+			MaxBackoff:     viper.GetDuration(submitterRetryKey(messageType, "max-backoff")),
+			Deadline:       viper.GetDuration(submitterRetryKey(messageType, "deadline")),
+		}
+	}
+
+	return retry.New(ctx,
+		retry.WithLogLevel(util.LogLevel("submitter.retry")),
+		retry.WithMonitor(monitor),
+		retry.WithChainTime(chainTime),
+		retry.WithPolicies(policies),
+	)
+}
+
+// submitterRetryKey returns the configuration key for a retry setting for the given message type,
+// falling back to the setting for all message types if there is no specific setting.
+func submitterRetryKey(messageType string, setting string) string {
+	key := fmt.Sprintf("submitter.%s.retry.%s", messageType, setting)
+	if viper.IsSet(key) {
+		return key
+	}
+	return fmt.Sprintf("submitter.retry.%s", setting)
+}
+
 // runCommands potentially runs commands.
 // Returns true if Vouch should exit.
-func runCommands(_ context.Context) bool {
+func runCommands(_ context.Context) (bool, int) {
 	if viper.GetBool("version") {
 		fmt.Printf("%s\n", ReleaseVersion)
-		return true
+		return true, 0
+	}
+
+	if pflag.Arg(0) == "strategy-report" {
+		if err := runStrategyReport(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to generate strategy report: %v\n", err)
+			return true, 1
+		}
+		return true, 0
+	}
+
+	return false, 0
+}
+
+// runStrategyReport summarises the decisions written by the strategy decision recorder.
+func runStrategyReport() error {
+	path := viper.GetString("strategies.recorder.path")
+	if path == "" {
+		return errors.New("strategies.recorder.path not configured")
+	}
+	from, err := reportTime(viper.GetString("report-from"))
+	if err != nil {
+		return errors.Wrap(err, "invalid report-from")
+	}
+	to, err := reportTime(viper.GetString("report-to"))
+	if err != nil {
+		return errors.Wrap(err, "invalid report-to")
+	}
+
+	report, err := standardrecorder.NewReport(resolvePath(path), viper.GetInt("strategies.recorder.max-files"), from, to)
+	if err != nil {
+		return err
+	}
+
+	return report.Write(os.Stdout)
+}
+
+// reportTime parses a time for the strategy report, which can be either an
+// RFC3339 time or a duration before now.  An empty string returns the zero time.
+func reportTime(input string) (time.Time, error) {
+	if input == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(input); err == nil {
+		return time.Now().Add(-duration), nil
 	}
 
-	return false
+	return time.Parse(time.RFC3339, input)
 }
@@ -23,6 +23,7 @@ var metricsNamespace = "vouch"
 
 var releaseMetric *prometheus.GaugeVec
 var readyMetric prometheus.Gauge
+var reloadsMetric *prometheus.CounterVec
 
 func registerMetrics(monitor metrics.Service) error {
 	if releaseMetric != nil {
@@ -71,6 +72,15 @@ func registerPrometheusMetrics() error {
 		return errors.Wrap(err, "failed to regsiter ready")
 	}
 
+	reloadsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reloads_total",
+		Help:      "The number of reloads of the beacon node configuration.",
+	}, []string{"result"})
+	if err := prometheus.Register(reloadsMetric); err != nil {
+		return errors.Wrap(err, "failed to register reloads_total")
+	}
+
 	return nil
 }
 
@@ -94,3 +104,16 @@ func setReady(ready bool) {
 		readyMetric.Set(0)
 	}
 }
+
+// monitorReload is called when the beacon node configuration is reloaded.
+func monitorReload(succeeded bool) {
+	if reloadsMetric == nil {
+		return
+	}
+
+	if succeeded {
+		reloadsMetric.WithLabelValues("succeeded").Inc()
+	} else {
+		reloadsMetric.WithLabelValues("failed").Inc()
+	}
+}
@@ -795,6 +795,28 @@ func (*AttestationDataProvider) AttestationData(_ context.Context, slot phase0.S
 	}, nil
 }
 
+// HeadAttestationDataProvider is a mock for eth2client.AttestationDataProvider with a specific head.
+type HeadAttestationDataProvider struct {
+	head phase0.Root
+}
+
+// NewHeadAttestationDataProvider returns a mock attestation data provider with a specific head.
+func NewHeadAttestationDataProvider(head phase0.Root) eth2client.AttestationDataProvider {
+	return &HeadAttestationDataProvider{
+		head: head,
+	}
+}
+
+// AttestationData is a mock.
+func (m *HeadAttestationDataProvider) AttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	attestationData, err := (&AttestationDataProvider{}).AttestationData(ctx, slot, committeeIndex)
+	if err != nil {
+		return nil, err
+	}
+	attestationData.BeaconBlockRoot = m.head
+	return attestationData, nil
+}
+
 // ErroringAttestationDataProvider is a mock for eth2client.AttestationDataProvider.
 type ErroringAttestationDataProvider struct{}
 
@@ -893,6 +915,23 @@ func (*AggregateAttestationProvider) AggregateAttestation(_ context.Context, slo
 	}, nil
 }
 
+// FixedAggregateAttestationProvider is a mock for eth2client.AggregateAttestationProvider.
+type FixedAggregateAttestationProvider struct {
+	aggregate *phase0.Attestation
+}
+
+// NewFixedAggregateAttestationProvider returns a mock attestation data provider that always returns the given aggregate.
+func NewFixedAggregateAttestationProvider(aggregate *phase0.Attestation) eth2client.AggregateAttestationProvider {
+	return &FixedAggregateAttestationProvider{
+		aggregate: aggregate,
+	}
+}
+
+// AggregateAttestation is a mock.
+func (m *FixedAggregateAttestationProvider) AggregateAttestation(_ context.Context, _ phase0.Slot, _ phase0.Root) (*phase0.Attestation, error) {
+	return m.aggregate, nil
+}
+
 // ErroringAggregateAttestationProvider is a mock for eth2client.AggregateAttestationProvider.
 type ErroringAggregateAttestationProvider struct{}
 
@@ -1387,3 +1426,39 @@ func (m *SleepySyncCommitteeContributionProvider) SyncCommitteeContribution(ctx
 	time.Sleep(m.wait)
 	return m.next.SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
 }
+
+// BeaconCommitteesProvider is a mock for eth2client.BeaconCommitteesProvider.
+type BeaconCommitteesProvider struct{}
+
+// NewBeaconCommitteesProvider returns a mock beacon committees provider.
+func NewBeaconCommitteesProvider() eth2client.BeaconCommitteesProvider {
+	return &BeaconCommitteesProvider{}
+}
+
+// BeaconCommittees is a mock.
+func (*BeaconCommitteesProvider) BeaconCommittees(_ context.Context, _ string) ([]*apiv1.BeaconCommittee, error) {
+	return []*apiv1.BeaconCommittee{}, nil
+}
+
+// BeaconCommitteesAtEpoch is a mock.
+func (*BeaconCommitteesProvider) BeaconCommitteesAtEpoch(_ context.Context, _ string, _ phase0.Epoch) ([]*apiv1.BeaconCommittee, error) {
+	return []*apiv1.BeaconCommittee{}, nil
+}
+
+// SyncCommitteesProvider is a mock for eth2client.SyncCommitteesProvider.
+type SyncCommitteesProvider struct{}
+
+// NewSyncCommitteesProvider returns a mock sync committees provider.
+func NewSyncCommitteesProvider() eth2client.SyncCommitteesProvider {
+	return &SyncCommitteesProvider{}
+}
+
+// SyncCommittee is a mock.
+func (*SyncCommitteesProvider) SyncCommittee(_ context.Context, _ string) (*apiv1.SyncCommittee, error) {
+	return &apiv1.SyncCommittee{}, nil
+}
+
+// SyncCommitteeAtEpoch is a mock.
+func (*SyncCommitteesProvider) SyncCommitteeAtEpoch(_ context.Context, _ string, _ phase0.Epoch) (*apiv1.SyncCommittee, error) {
+	return &apiv1.SyncCommittee{}, nil
+}
@@ -0,0 +1,264 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/cache"
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/clockdrift"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"github.com/attestantio/vouch/services/eth2client/switchable"
+	"github.com/attestantio/vouch/services/eventaggregator"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	majordomo "github.com/wealdtech/go-majordomo"
+)
+
+// reloader reloads the beacon node configuration while Vouch is running.
+type reloader struct {
+	mu sync.Mutex
+
+	majordomo        majordomo.Service
+	monitor          metrics.Service
+	chainTime        chaintime.Service
+	cacheSvc         cache.Service
+	strategyRecorder recorder.Service
+	strategyScorer   scorer.Service
+	errorClassifier  errorclassifier.Service
+	// clockDrift is nil if clock drift monitoring is disabled.
+	clockDrift       clockdrift.Service
+	consistency      consistency.Service
+	nodeHealth       nodehealth.Service
+	nodeCapabilities capabilities.Service
+	eventsProvider   eth2client.EventsProvider
+	// eventAggregator is nil if events are obtained from the main beacon node client.
+	eventAggregator *eventaggregator.Service
+	// retained are the keys of clients used by services that are not reloaded.
+	retained []string
+
+	eth2Client                   *switchable.Service
+	submitter                    *switchable.Service
+	beaconBlockProposalProvider  *switchable.Service
+	attestationDataProvider      *switchable.Service
+	aggregateAttestationProvider *switchable.Service
+	dutiesProvider               *switchable.Service
+	// syncCommitteeContributionProvider is nil if the beacon node is not Altair-capable.
+	syncCommitteeContributionProvider *switchable.Service
+}
+
+// switchableClient wraps a client, provider or submitter so that it can be replaced on reload.
+func switchableClient(ctx context.Context, name string, client interface{}) (*switchable.Service, error) {
+	return switchable.New(ctx,
+		switchable.WithLogLevel(util.LogLevel("eth2client")),
+		switchable.WithName(name),
+		switchable.WithClient(client),
+	)
+}
+
+// reload reloads the beacon node configuration, reporting the result.
+func (r *reloader) reload(ctx context.Context) error {
+	log.Info().Msg("Reloading beacon node configuration")
+	if err := r.switchBeaconNodes(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to reload beacon node configuration")
+		monitorReload(false)
+		return err
+	}
+	log.Info().Msg("Reloaded beacon node configuration")
+	monitorReload(true)
+
+	return nil
+}
+
+// switchBeaconNodes switches to the beacon nodes in the current configuration.
+// Everything is built before anything is switched, so a failure leaves the existing beacon nodes in use.
+// Clients for beacon nodes that are no longer used are closed once requests in progress have had time to complete.
+func (r *reloader) switchBeaconNodes(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := viper.ReadInConfig(); err != nil {
+		return errors.Wrap(err, "failed to read configuration")
+	}
+	if err := initClientConnections(ctx, r.majordomo); err != nil {
+		return errors.Wrap(err, "failed to initialise beacon node connections")
+	}
+
+	generation := nextClientGeneration()
+
+	eth2Client, err := startClient(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to start client")
+	}
+
+	submitterStrategy, err := selectSubmitterStrategy(ctx, r.monitor, r.eth2Client, r.chainTime, r.errorClassifier, r.nodeHealth, r.consistency, r.nodeCapabilities)
+	if err != nil {
+		return errors.Wrap(err, "failed to select submitter")
+	}
+
+	beaconBlockProposalProvider, err := selectBeaconBlockProposalProvider(ctx, r.monitor, r.eth2Client, r.eventsProvider, r.chainTime, r.cacheSvc, r.strategyRecorder, r.strategyScorer, r.errorClassifier, r.nodeHealth, r.consistency)
+	if err != nil {
+		return errors.Wrap(err, "failed to select beacon block proposal provider")
+	}
+
+	attestationDataProvider, err := selectAttestationDataProvider(ctx, r.monitor, r.eth2Client, r.chainTime, r.cacheSvc, r.strategyRecorder, r.strategyScorer, r.errorClassifier, r.nodeHealth, r.consistency)
+	if err != nil {
+		return errors.Wrap(err, "failed to select attestation data provider")
+	}
+
+	aggregateAttestationProvider, err := selectAggregateAttestationProvider(ctx, r.monitor, r.eth2Client, r.chainTime, r.strategyRecorder, r.strategyScorer, r.errorClassifier, r.nodeHealth, r.consistency)
+	if err != nil {
+		return errors.Wrap(err, "failed to select aggregate attestation provider")
+	}
+
+	dutiesProvider, err := selectDutiesProvider(ctx, r.monitor, r.eth2Client, r.nodeHealth, r.consistency)
+	if err != nil {
+		return errors.Wrap(err, "failed to select duties provider")
+	}
+
+	var syncCommitteeContributionProvider eth2client.SyncCommitteeContributionProvider
+	if r.syncCommitteeContributionProvider != nil {
+		syncCommitteeContributionProvider, err = selectSyncCommitteeContributionProvider(ctx, r.monitor, r.eth2Client, r.chainTime, r.strategyRecorder, r.strategyScorer, r.errorClassifier, r.nodeHealth, r.consistency, r.nodeCapabilities)
+		if err != nil {
+			return errors.Wrap(err, "failed to select sync committee contribution provider")
+		}
+	}
+
+	var eventsProviders map[string]eth2client.EventsProvider
+	if r.eventAggregator != nil {
+		eventsProviders, err = fetchEventsProviders(ctx, util.BeaconNodeAddresses("eventaggregator"))
+		if err != nil {
+			return err
+		}
+		if len(eventsProviders) == 0 {
+			return errors.New("no events providers for event aggregator")
+		}
+	}
+
+	// Refuse to switch to a main beacon node that is on a different network to the others.
+	if addressesSetter, isSetter := r.consistency.(consistency.AddressesSetter); isSetter {
+		if err := addressesSetter.SetAddresses(ctx, util.BeaconNodeAddresses("consistency"), consensusClientAddresses(), currentClientProxies()); err != nil {
+			return errors.Wrap(err, "failed to check beacon node consistency")
+		}
+	}
+
+	retainClients(r.retained)
+
+	// Everything has been built, so switch over.
+	if err := r.eth2Client.Switch(eth2Client); err != nil {
+		return errors.Wrap(err, "failed to switch client")
+	}
+	if err := r.submitter.Switch(submitterStrategy); err != nil {
+		return errors.Wrap(err, "failed to switch submitter")
+	}
+	if err := r.beaconBlockProposalProvider.Switch(beaconBlockProposalProvider); err != nil {
+		return errors.Wrap(err, "failed to switch beacon block proposal provider")
+	}
+	if err := r.attestationDataProvider.Switch(attestationDataProvider); err != nil {
+		return errors.Wrap(err, "failed to switch attestation data provider")
+	}
+	if err := r.aggregateAttestationProvider.Switch(aggregateAttestationProvider); err != nil {
+		return errors.Wrap(err, "failed to switch aggregate attestation provider")
+	}
+	if err := r.dutiesProvider.Switch(dutiesProvider); err != nil {
+		return errors.Wrap(err, "failed to switch duties provider")
+	}
+	if r.syncCommitteeContributionProvider != nil {
+		if err := r.syncCommitteeContributionProvider.Switch(syncCommitteeContributionProvider); err != nil {
+			return errors.Wrap(err, "failed to switch sync committee contribution provider")
+		}
+	}
+	if r.eventAggregator != nil {
+		if err := r.eventAggregator.SetEventsProviders(eventsProviders); err != nil {
+			return errors.Wrap(err, "failed to set events providers")
+		}
+	}
+	if addressesSetter, isSetter := r.nodeHealth.(nodehealth.AddressesSetter); isSetter {
+		addressesSetter.SetAddresses(ctx, util.BeaconNodeAddresses("nodehealth"), currentClientProxies())
+	}
+	if addressesSetter, isSetter := r.nodeCapabilities.(capabilities.AddressesSetter); isSetter {
+		addressesSetter.SetAddresses(ctx, util.BeaconNodeAddresses("capabilities"), currentClientProxies())
+	}
+	if endpointsSetter, isSetter := r.clockDrift.(clockdrift.EndpointsSetter); isSetter {
+		// Clock drift keeps its beacon nodes, but they may now be reached through different proxies.
+		endpointsSetter.SetEndpoints(currentClientProxies())
+	}
+
+	// Requests in progress may still be using the previous clients, so wait for them to time out before closing.
+	go func(ctx context.Context, drain time.Duration) {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(drain):
+		}
+		released := releaseClients(generation)
+		if len(released) > 0 {
+			log.Info().Strs("clients", released).Msg("Closed clients for beacon nodes no longer in use")
+		}
+	}(ctx, util.Timeout("eth2client"))
+
+	return nil
+}
+
+// startReloadServer starts a server that reloads the beacon node configuration on request.
+func startReloadServer(ctx context.Context, r *reloader, address string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reload", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := r.reload(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen for reload requests")
+	}
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Warn().Err(err).Msg("Reload server stopped")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			log.Debug().Err(err).Msg("Failed to close reload server")
+		}
+	}()
+	log.Info().Str("address", listener.Addr().String()).Msg("Listening for reload requests")
+
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -85,3 +86,17 @@ type Service interface {
 	// Propose carries out the proposal for a slot.
 	Propose(ctx context.Context, details interface{})
 }
+
+// RankedBeaconBlockProposalsProvider is the interface for providing multiple beacon block proposals,
+// allowing the proposer to fall back to a lesser proposal if the best one is unsuitable.
+type RankedBeaconBlockProposalsProvider interface {
+	// RankedBeaconBlockProposals provides beacon block proposals, ordered from best to worst.
+	RankedBeaconBlockProposals(ctx context.Context,
+		slot phase0.Slot,
+		randaoReveal phase0.BLSSignature,
+		graffiti []byte,
+	) (
+		[]*spec.VersionedBeaconBlock,
+		error,
+	)
+}
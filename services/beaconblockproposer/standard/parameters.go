@@ -17,7 +17,9 @@ import (
 	"errors"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
+	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
 	"github.com/attestantio/vouch/services/feerecipientprovider"
 	"github.com/attestantio/vouch/services/graffitiprovider"
@@ -38,6 +40,8 @@ type parameters struct {
 	beaconBlockSubmitter       submitter.BeaconBlockSubmitter
 	randaoRevealSigner         signer.RANDAORevealSigner
 	beaconBlockSigner          signer.BeaconBlockSigner
+	blockRootToSlotCache       cache.BlockRootToSlotProvider
+	maxParentDistance          phase0.Slot
 }
 
 // Parameter is the interface for service parameters.
@@ -121,10 +125,25 @@ func WithBeaconBlockSigner(signer signer.BeaconBlockSigner) Parameter {
 	})
 }
 
+// WithBlockRootToSlotCache sets the block root to slot cache, used to check proposals' parent roots.
+func WithBlockRootToSlotCache(cache cache.BlockRootToSlotProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blockRootToSlotCache = cache
+	})
+}
+
+// WithMaxParentDistance sets the maximum number of slots between a proposal and its parent.
+func WithMaxParentDistance(distance phase0.Slot) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxParentDistance = distance
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
-		logLevel: zerolog.GlobalLevel(),
+		logLevel:          zerolog.GlobalLevel(),
+		maxParentDistance: 64,
 	}
 	for _, p := range params {
 		if params != nil {
@@ -156,6 +175,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.beaconBlockSigner == nil {
 		return nil, errors.New("no beacon block signer specified")
 	}
+	if parameters.blockRootToSlotCache == nil {
+		return nil, errors.New("no block root to slot cache specified")
+	}
+	if parameters.maxParentDistance == 0 {
+		return nil, errors.New("no max parent distance specified")
+	}
 
 	return &parameters, nil
 }
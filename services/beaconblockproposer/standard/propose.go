@@ -87,24 +87,66 @@ func (s *Service) proposeBlock(ctx context.Context,
 	duty *beaconblockproposer.Duty,
 	graffiti []byte,
 ) error {
+	proposals, err := s.obtainProposals(ctx, duty, graffiti)
+	if err != nil {
+		return err
+	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("proposals", len(proposals)).Msg("Obtained proposals")
+
+	for i, proposal := range proposals {
+		if err := s.validateProposal(ctx, duty, graffiti, proposal); err != nil {
+			log.Warn().Int("rank", i).Err(err).Msg("Proposal failed validation")
+			continue
+		}
+		if i > 0 {
+			log.Info().Int("rank", i).Msg("Using lower-ranked proposal after validation failures")
+		}
+		return s.signAndSubmitProposal(ctx, started, duty, proposal)
+	}
+
+	return errors.New("no valid proposals")
+}
+
+// obtainProposals obtains one or more proposals, ordered from best to worst.
+func (s *Service) obtainProposals(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	graffiti []byte,
+) (
+	[]*spec.VersionedBeaconBlock,
+	error,
+) {
+	if rankedProvider, isProvider := s.proposalProvider.(beaconblockproposer.RankedBeaconBlockProposalsProvider); isProvider {
+		proposals, err := rankedProvider.RankedBeaconBlockProposals(ctx, duty.Slot(), duty.RANDAOReveal(), graffiti)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain proposal data")
+		}
+		if len(proposals) == 0 {
+			return nil, errors.New("obtained no beacon block proposals")
+		}
+		return proposals, nil
+	}
+
 	proposal, err := s.proposalProvider.BeaconBlockProposal(ctx, duty.Slot(), duty.RANDAOReveal(), graffiti)
 	if err != nil {
-		return errors.Wrap(err, "failed to obtain proposal data")
+		return nil, errors.Wrap(err, "failed to obtain proposal data")
 	}
 	if proposal == nil {
-		return errors.New("obtained nil beacon block proposal")
+		return nil, errors.New("obtained nil beacon block proposal")
 	}
-	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained proposal")
+	return []*spec.VersionedBeaconBlock{proposal}, nil
+}
 
+// signAndSubmitProposal signs and submits a proposal.
+func (s *Service) signAndSubmitProposal(ctx context.Context,
+	started time.Time,
+	duty *beaconblockproposer.Duty,
+	proposal *spec.VersionedBeaconBlock,
+) error {
 	proposalSlot, err := proposal.Slot()
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain proposal slot")
 	}
 
-	if proposalSlot != duty.Slot() {
-		return errors.New("proposal data for incorrect slot")
-	}
-
 	bodyRoot, err := proposal.BodyRoot()
 	if err != nil {
 		return errors.Wrap(err, "failed to calculate hash tree root of block body")
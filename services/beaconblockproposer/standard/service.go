@@ -22,7 +22,9 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/accountmanager"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/feerecipientprovider"
 	"github.com/attestantio/vouch/services/graffitiprovider"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/attestantio/vouch/services/signer"
@@ -38,10 +40,13 @@ type Service struct {
 	chainTimeService           chaintime.Service
 	proposalProvider           eth2client.BeaconBlockProposalProvider
 	validatingAccountsProvider accountmanager.ValidatingAccountsProvider
+	feeRecipientProvider       feerecipientprovider.Service
 	graffitiProvider           graffitiprovider.Service
 	beaconBlockSubmitter       submitter.BeaconBlockSubmitter
 	randaoRevealSigner         signer.RANDAORevealSigner
 	beaconBlockSigner          signer.BeaconBlockSigner
+	blockRootToSlotCache       cache.BlockRootToSlotProvider
+	maxParentDistance          phase0.Slot
 }
 
 // module-wide log.
@@ -65,10 +70,13 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		chainTimeService:           parameters.chainTimeService,
 		proposalProvider:           parameters.proposalProvider,
 		validatingAccountsProvider: parameters.validatingAccountsProvider,
+		feeRecipientProvider:       parameters.feeRecipientProvider,
 		graffitiProvider:           parameters.graffitiProvider,
 		beaconBlockSubmitter:       parameters.beaconBlockSubmitter,
 		randaoRevealSigner:         parameters.randaoRevealSigner,
 		beaconBlockSigner:          parameters.beaconBlockSigner,
+		blockRootToSlotCache:       parameters.blockRootToSlotCache,
+		maxParentDistance:          parameters.maxParentDistance,
 	}
 
 	return s, nil
@@ -18,10 +18,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
 	mockaccountsprovider "github.com/attestantio/vouch/services/accountmanager/mock"
 	"github.com/attestantio/vouch/services/beaconblockproposer"
 	"github.com/attestantio/vouch/services/beaconblockproposer/standard"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
 	mockfeerecipientprovider "github.com/attestantio/vouch/services/feerecipientprovider/mock"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
@@ -52,6 +55,7 @@ func TestProposeNoRANDAOReveal(t *testing.T) {
 		standard.WithBeaconBlockSubmitter(mock.NewBeaconBlockSubmitter()),
 		standard.WithRANDAORevealSigner(mocksigner.New()),
 		standard.WithBeaconBlockSigner(mocksigner.New()),
+		standard.WithBlockRootToSlotCache(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)),
 	)
 	require.NoError(t, err)
 
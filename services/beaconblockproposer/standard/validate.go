@@ -0,0 +1,189 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/pkg/errors"
+)
+
+// validateProposal checks that a proposal is suitable for signing.
+func (s *Service) validateProposal(ctx context.Context,
+	duty *beaconblockproposer.Duty,
+	graffiti []byte,
+	proposal *spec.VersionedBeaconBlock,
+) error {
+	if proposal == nil || proposal.IsEmpty() {
+		return errors.New("proposal is empty")
+	}
+
+	proposalSlot, err := proposal.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposal slot")
+	}
+	if proposalSlot != duty.Slot() {
+		return errors.New("proposal data for incorrect slot")
+	}
+
+	proposerIndex, err := proposalProposerIndex(proposal)
+	if err != nil {
+		return err
+	}
+	if proposerIndex != duty.ValidatorIndex() {
+		return fmt.Errorf("proposal has proposer index %d but duty is for %d", proposerIndex, duty.ValidatorIndex())
+	}
+
+	if err := s.validateParentRoot(ctx, proposal, proposalSlot); err != nil {
+		return err
+	}
+
+	if err := validateGraffiti(proposal, graffiti); err != nil {
+		return err
+	}
+
+	if proposal.Version == spec.DataVersionBellatrix {
+		if err := s.validateFeeRecipient(ctx, proposal, duty.ValidatorIndex()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateParentRoot ensures that the parent of the proposal is known and recent.
+func (s *Service) validateParentRoot(ctx context.Context,
+	proposal *spec.VersionedBeaconBlock,
+	proposalSlot phase0.Slot,
+) error {
+	parentRoot, err := proposal.ParentRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain parent root of block")
+	}
+	parentSlot, err := s.blockRootToSlotCache.BlockRootToSlot(ctx, parentRoot)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("parent root %#x unknown", parentRoot))
+	}
+	if parentSlot >= proposalSlot {
+		return fmt.Errorf("parent slot %d not before proposal slot %d", parentSlot, proposalSlot)
+	}
+	if proposalSlot-parentSlot > s.maxParentDistance {
+		return fmt.Errorf("parent slot %d more than %d slots before proposal slot %d", parentSlot, s.maxParentDistance, proposalSlot)
+	}
+
+	return nil
+}
+
+// validateGraffiti ensures that the graffiti of the proposal is that requested.
+func validateGraffiti(proposal *spec.VersionedBeaconBlock, graffiti []byte) error {
+	if len(graffiti) == 0 {
+		// No graffiti requested, so the beacon node is free to set its own.
+		return nil
+	}
+
+	proposalGraffiti, err := proposalGraffiti(proposal)
+	if err != nil {
+		return err
+	}
+
+	// The strategy may substitute the client name for each beacon node, in which case
+	// we can only check the graffiti up to the first substitution.
+	expected := graffiti
+	if index := bytes.Index(expected, []byte("{{CLIENT}}")); index != -1 {
+		expected = expected[:index]
+		if len(proposalGraffiti) < len(expected) {
+			return errors.New("proposal graffiti does not match requested graffiti")
+		}
+		proposalGraffiti = proposalGraffiti[:len(expected)]
+	} else {
+		proposalGraffiti = bytes.TrimRight(proposalGraffiti, "\x00")
+		expected = bytes.TrimRight(expected, "\x00")
+	}
+	if !bytes.Equal(proposalGraffiti, expected) {
+		return errors.New("proposal graffiti does not match requested graffiti")
+	}
+
+	return nil
+}
+
+// validateFeeRecipient ensures that the fee recipient of the proposal is that configured for the validator.
+func (s *Service) validateFeeRecipient(ctx context.Context,
+	proposal *spec.VersionedBeaconBlock,
+	validatorIndex phase0.ValidatorIndex,
+) error {
+	if proposal.Bellatrix.Body == nil || proposal.Bellatrix.Body.ExecutionPayload == nil {
+		return errors.New("proposal missing execution payload")
+	}
+	executionPayload := proposal.Bellatrix.Body.ExecutionPayload
+	if executionPayload.BlockHash == (phase0.Hash32{}) {
+		// Pre-merge block with empty execution payload; nothing to check.
+		return nil
+	}
+
+	feeRecipients, err := s.feeRecipientProvider.FeeRecipients(ctx, []phase0.ValidatorIndex{validatorIndex})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fee recipient")
+	}
+	feeRecipient, exists := feeRecipients[validatorIndex]
+	if !exists {
+		return errors.New("no fee recipient available for validator")
+	}
+	if !bytes.Equal(executionPayload.FeeRecipient[:], feeRecipient[:]) {
+		return fmt.Errorf("proposal fee recipient %#x does not match expected fee recipient %#x", executionPayload.FeeRecipient, feeRecipient)
+	}
+
+	return nil
+}
+
+// proposalProposerIndex returns the proposer index of a proposal.
+func proposalProposerIndex(proposal *spec.VersionedBeaconBlock) (phase0.ValidatorIndex, error) {
+	switch proposal.Version {
+	case spec.DataVersionPhase0:
+		return proposal.Phase0.ProposerIndex, nil
+	case spec.DataVersionAltair:
+		return proposal.Altair.ProposerIndex, nil
+	case spec.DataVersionBellatrix:
+		return proposal.Bellatrix.ProposerIndex, nil
+	default:
+		return 0, errors.New("unknown proposal version")
+	}
+}
+
+// proposalGraffiti returns the graffiti of a proposal.
+func proposalGraffiti(proposal *spec.VersionedBeaconBlock) ([]byte, error) {
+	switch proposal.Version {
+	case spec.DataVersionPhase0:
+		if proposal.Phase0.Body == nil {
+			return nil, errors.New("proposal missing body")
+		}
+		return proposal.Phase0.Body.Graffiti, nil
+	case spec.DataVersionAltair:
+		if proposal.Altair.Body == nil {
+			return nil, errors.New("proposal missing body")
+		}
+		return proposal.Altair.Body.Graffiti, nil
+	case spec.DataVersionBellatrix:
+		if proposal.Bellatrix.Body == nil {
+			return nil, errors.New("proposal missing body")
+		}
+		return proposal.Bellatrix.Body.Graffiti, nil
+	default:
+		return nil, errors.New("unknown proposal version")
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountsprovider "github.com/attestantio/vouch/services/accountmanager/mock"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockfeerecipientprovider "github.com/attestantio/vouch/services/feerecipientprovider/mock"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mocksigner "github.com/attestantio/vouch/services/signer/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// rankedProposalsProvider is a ranked proposals provider that returns fixed proposals.
+type rankedProposalsProvider struct {
+	proposals []*spec.VersionedBeaconBlock
+}
+
+func (*rankedProposalsProvider) BeaconBlockProposal(_ context.Context, _ phase0.Slot, _ phase0.BLSSignature, _ []byte) (*spec.VersionedBeaconBlock, error) {
+	return nil, nil
+}
+
+func (p *rankedProposalsProvider) RankedBeaconBlockProposals(_ context.Context, _ phase0.Slot, _ phase0.BLSSignature, _ []byte) ([]*spec.VersionedBeaconBlock, error) {
+	return p.proposals, nil
+}
+
+func mockProposal(t *testing.T, slot phase0.Slot, graffiti []byte) *spec.VersionedBeaconBlock {
+	proposal, err := mock.NewBeaconBlockProposalProvider().BeaconBlockProposal(context.Background(), slot, phase0.BLSSignature{}, graffiti)
+	require.NoError(t, err)
+	return proposal
+}
+
+func bellatrixProposal(slot phase0.Slot, parentRoot phase0.Root, blockHash phase0.Hash32, feeRecipient bellatrix.ExecutionAddress) *spec.VersionedBeaconBlock {
+	return &spec.VersionedBeaconBlock{
+		Version: spec.DataVersionBellatrix,
+		Bellatrix: &bellatrix.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: 1,
+			ParentRoot:    parentRoot,
+			Body: &bellatrix.BeaconBlockBody{
+				Graffiti: make([]byte, 32),
+				ExecutionPayload: &bellatrix.ExecutionPayload{
+					BlockHash:    blockHash,
+					FeeRecipient: feeRecipient,
+				},
+			},
+		},
+	}
+}
+
+func newTestService(t *testing.T, proposalProvider *rankedProposalsProvider, blockRootToSlot map[phase0.Root]phase0.Slot) *Service {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithMonitor(nullmetrics.New(ctx)),
+		WithProposalDataProvider(proposalProvider),
+		WithChainTimeService(chainTime),
+		WithValidatingAccountsProvider(mockaccountsprovider.NewValidatingAccountsProvider()),
+		WithFeeRecipientProvider(mockfeerecipientprovider.New()),
+		WithBeaconBlockSubmitter(mock.NewBeaconBlockSubmitter()),
+		WithRANDAORevealSigner(mocksigner.New()),
+		WithBeaconBlockSigner(mocksigner.New()),
+		WithBlockRootToSlotCache(mockcache.New(blockRootToSlot).(cache.BlockRootToSlotProvider)),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestValidateProposal(t *testing.T) {
+	ctx := context.Background()
+
+	// Parent root as used by the mock proposal provider.
+	parentRoot := phase0.Root{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+		0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+	}
+	s := newTestService(t, &rankedProposalsProvider{}, map[phase0.Root]phase0.Slot{
+		parentRoot: 99,
+	})
+
+	wrongProposer := mockProposal(t, 100, []byte("graffiti"))
+	wrongProposer.Phase0.ProposerIndex = 2
+
+	unknownParent := mockProposal(t, 100, []byte("graffiti"))
+	unknownParent.Phase0.ParentRoot = phase0.Root{0x01}
+
+	tests := []struct {
+		name     string
+		duty     *beaconblockproposer.Duty
+		graffiti []byte
+		proposal *spec.VersionedBeaconBlock
+		err      string
+	}{
+		{
+			name:     "Nil",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			proposal: nil,
+			err:      "proposal is empty",
+		},
+		{
+			name:     "WrongSlot",
+			duty:     beaconblockproposer.NewDuty(101, 1),
+			graffiti: []byte("graffiti"),
+			proposal: mockProposal(t, 100, []byte("graffiti")),
+			err:      "proposal data for incorrect slot",
+		},
+		{
+			name:     "WrongProposer",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			graffiti: []byte("graffiti"),
+			proposal: wrongProposer,
+			err:      "proposal has proposer index 2 but duty is for 1",
+		},
+		{
+			name:     "UnknownParent",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			graffiti: []byte("graffiti"),
+			proposal: unknownParent,
+			err:      "parent root 0x0100000000000000000000000000000000000000000000000000000000000000 unknown: not found",
+		},
+		{
+			name:     "ParentTooOld",
+			duty:     beaconblockproposer.NewDuty(200, 1),
+			graffiti: []byte("graffiti"),
+			proposal: mockProposal(t, 200, []byte("graffiti")),
+			err:      "parent slot 99 more than 64 slots before proposal slot 200",
+		},
+		{
+			name:     "ParentNotBefore",
+			duty:     beaconblockproposer.NewDuty(99, 1),
+			graffiti: []byte("graffiti"),
+			proposal: mockProposal(t, 99, []byte("graffiti")),
+			err:      "parent slot 99 not before proposal slot 99",
+		},
+		{
+			name:     "WrongGraffiti",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			graffiti: []byte("graffiti"),
+			proposal: mockProposal(t, 100, []byte("other")),
+			err:      "proposal graffiti does not match requested graffiti",
+		},
+		{
+			name:     "NoGraffitiRequested",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			proposal: mockProposal(t, 100, []byte("node graffiti")),
+		},
+		{
+			name:     "ClientGraffiti",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			graffiti: []byte("Vouch/{{CLIENT}}"),
+			proposal: mockProposal(t, 100, []byte("Vouch/lighthouse")),
+		},
+		{
+			name:     "ClientGraffitiMismatch",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			graffiti: []byte("Vouch/{{CLIENT}}"),
+			proposal: mockProposal(t, 100, []byte("Other/lighthouse")),
+			err:      "proposal graffiti does not match requested graffiti",
+		},
+		{
+			name:     "Good",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			graffiti: []byte("graffiti"),
+			proposal: mockProposal(t, 100, []byte("graffiti")),
+		},
+		{
+			name:     "BellatrixPreMerge",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			proposal: bellatrixProposal(100, parentRoot, phase0.Hash32{}, bellatrix.ExecutionAddress{}),
+		},
+		{
+			name:     "BellatrixWrongFeeRecipient",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			proposal: bellatrixProposal(100, parentRoot, phase0.Hash32{0x01}, bellatrix.ExecutionAddress{0x02}),
+			err:      "proposal fee recipient 0x0200000000000000000000000000000000000000 does not match expected fee recipient 0x0100000000000000000000000000000000000000",
+		},
+		{
+			name:     "BellatrixGood",
+			duty:     beaconblockproposer.NewDuty(100, 1),
+			proposal: bellatrixProposal(100, parentRoot, phase0.Hash32{0x01}, bellatrix.ExecutionAddress{0x01}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.validateProposal(ctx, test.duty, test.graffiti, test.proposal)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProposeBlockFallback(t *testing.T) {
+	ctx := context.Background()
+
+	parentRoot := phase0.Root{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+		0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+	}
+	blockRootToSlot := map[phase0.Root]phase0.Slot{
+		parentRoot: 99,
+	}
+
+	tests := []struct {
+		name      string
+		proposals []*spec.VersionedBeaconBlock
+		err       string
+	}{
+		{
+			name: "None",
+			err:  "obtained no beacon block proposals",
+		},
+		{
+			name: "AllInvalid",
+			proposals: []*spec.VersionedBeaconBlock{
+				mockProposal(t, 100, []byte("bad")),
+			},
+			err: "no valid proposals",
+		},
+		{
+			name: "FallbackToSecond",
+			proposals: []*spec.VersionedBeaconBlock{
+				mockProposal(t, 100, []byte("bad")),
+				mockProposal(t, 100, []byte("graffiti")),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestService(t, &rankedProposalsProvider{proposals: test.proposals}, blockRootToSlot)
+			duty := beaconblockproposer.NewDuty(100, 1)
+			duty.SetRandaoReveal(phase0.BLSSignature{0x01})
+			err := s.proposeBlock(ctx, time.Now(), duty, []byte("graffiti"))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
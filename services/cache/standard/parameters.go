@@ -30,6 +30,7 @@ type parameters struct {
 	monitor         metrics.Service
 	chainTime       chaintime.Service
 	consensusClient eth2client.Service
+	eventsProvider  eth2client.EventsProvider
 	scheduler       scheduler.Service
 }
 
@@ -72,6 +73,14 @@ func WithScheduler(service scheduler.Service) Parameter {
 	})
 }
 
+// WithEventsProvider sets the provider of block events.
+// If not supplied, events are obtained from the consensus client.
+func WithEventsProvider(provider eth2client.EventsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventsProvider = provider
+	})
+}
+
 // WithChainTime sets the chain time for the service.
 func WithChainTime(chainTime chaintime.Service) Parameter {
 	return parameterFunc(func(p *parameters) {
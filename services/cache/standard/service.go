@@ -61,7 +61,13 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		blockRootToSlot: make(map[phase0.Root]phase0.Slot),
 	}
 
-	if eventsProvider, isProvider := s.consensusClient.(eth2client.EventsProvider); isProvider {
+	eventsProvider := parameters.eventsProvider
+	if eventsProvider == nil {
+		if provider, isProvider := s.consensusClient.(eth2client.EventsProvider); isProvider {
+			eventsProvider = provider
+		}
+	}
+	if eventsProvider != nil {
 		if err := eventsProvider.Events(ctx, []string{"block"}, s.handleBlock); err != nil {
 			return nil, errors.Wrap(err, "failed to configure events")
 		}
@@ -0,0 +1,39 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package null is a capabilities service that considers all beacon nodes capable.
+package null
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/capabilities"
+)
+
+// Service is a capabilities service that considers all beacon nodes capable.
+type Service struct{}
+
+// New creates a new null capabilities service.
+func New(_ context.Context) *Service {
+	return &Service{}
+}
+
+// Supports returns true if the beacon node with the given address supports the capability.
+func (*Service) Supports(_ string, _ capabilities.Capability) bool {
+	return true
+}
+
+// Filter returns the addresses of the beacon nodes that support the capability, in the order supplied.
+func (*Service) Filter(_ capabilities.Capability, addresses []string) []string {
+	return addresses
+}
@@ -0,0 +1,29 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package null_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/vouch/services/capabilities"
+	nullcapabilities "github.com/attestantio/vouch/services/capabilities/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	s := nullcapabilities.New(context.Background())
+	require.True(t, s.Supports("a", capabilities.CapabilityAltair))
+	require.Equal(t, []string{"a", "b"}, s.Filter(capabilities.CapabilityAltair, []string{"a", "b"}))
+}
@@ -0,0 +1,49 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capabilities
+
+import "context"
+
+// Capability is an optional capability of a beacon node.
+type Capability string
+
+const (
+	// CapabilityAltair is support for the Altair fork.
+	CapabilityAltair Capability = "altair"
+	// CapabilityBellatrix is support for the Bellatrix fork.
+	CapabilityBellatrix Capability = "bellatrix"
+	// CapabilitySyncCommitteeMessages is support for submitting sync committee messages.
+	CapabilitySyncCommitteeMessages Capability = "sync_committee_messages"
+	// CapabilitySyncCommitteeContributions is support for sync committee contributions.
+	CapabilitySyncCommitteeContributions Capability = "sync_committee_contributions"
+	// CapabilitySyncCommitteeSubscriptions is support for subscribing to sync committees.
+	CapabilitySyncCommitteeSubscriptions Capability = "sync_committee_subscriptions"
+	// CapabilityProposalPreparations is support for submitting proposal preparations.
+	CapabilityProposalPreparations Capability = "proposal_preparations"
+)
+
+// Service is the capabilities service.
+type Service interface {
+	// Supports returns true if the beacon node with the given address supports the capability.
+	Supports(address string, capability Capability) bool
+
+	// Filter returns the addresses of the beacon nodes that support the capability, in the order supplied.
+	Filter(capability Capability, addresses []string) []string
+}
+
+// AddressesSetter is the interface for updating the beacon nodes whose capabilities are probed.
+type AddressesSetter interface {
+	// SetAddresses sets the addresses of the beacon nodes to probe, and the endpoints through which they are reached.
+	SetAddresses(ctx context.Context, addresses []string, endpoints map[string]string)
+}
@@ -0,0 +1,60 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var capabilitiesSupported *prometheus.GaugeVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if capabilitiesSupported != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	capabilitiesSupported = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "capabilities",
+		Name:      "supported",
+		Help:      "Whether each beacon node supports each capability; 1 if supported, otherwise 0.",
+	}, []string{"address", "capability"})
+	return prometheus.Register(capabilitiesSupported)
+}
+
+func monitorCapability(address string, capability capabilities.Capability, supported bool) {
+	if capabilitiesSupported == nil {
+		return
+	}
+	if supported {
+		capabilitiesSupported.WithLabelValues(address, string(capability)).Set(1)
+	} else {
+		capabilitiesSupported.WithLabelValues(address, string(capability)).Set(0)
+	}
+}
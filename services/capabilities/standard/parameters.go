@@ -0,0 +1,129 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standard probes the optional capabilities of beacon nodes,
+// such as support for forks and newer API endpoints, so that requests are
+// only sent to beacon nodes that can handle them.
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel  zerolog.Level
+	monitor   metrics.Service
+	scheduler scheduler.Service
+	timeout   time.Duration
+	interval  time.Duration
+	addresses []string
+	endpoints map[string]string
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithScheduler sets the scheduler for the module.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithTimeout sets the timeout for requests to beacon nodes.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithInterval sets the interval between probes.
+func WithInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.interval = interval
+	})
+}
+
+// WithAddresses sets the addresses of the beacon nodes to probe at startup.
+// Other beacon nodes are probed once they are seen by the service.
+func WithAddresses(addresses []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.addresses = addresses
+	})
+}
+
+// WithEndpoints sets the endpoints through which beacon nodes are reached, keyed by address.
+// Beacon nodes without an endpoint are reached directly at their address.
+func WithEndpoints(endpoints map[string]string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.endpoints = endpoints
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(context.Background()),
+		interval: time.Hour,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+
+	return &parameters, nil
+}
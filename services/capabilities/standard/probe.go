@@ -0,0 +1,175 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+)
+
+// forkCapabilities are the capabilities determined by the presence of the fork in the beacon node's spec.
+var forkCapabilities = map[capabilities.Capability]string{
+	capabilities.CapabilityAltair:    "ALTAIR_FORK_EPOCH",
+	capabilities.CapabilityBellatrix: "BELLATRIX_FORK_EPOCH",
+}
+
+// endpointCapabilities are the capabilities determined by the presence of an endpoint.
+// Each endpoint is probed by posting an empty list, which is harmless to beacon nodes that support it.
+var endpointCapabilities = map[capabilities.Capability]string{
+	capabilities.CapabilitySyncCommitteeMessages:      "/eth/v1/beacon/pool/sync_committees",
+	capabilities.CapabilitySyncCommitteeContributions: "/eth/v1/validator/contribution_and_proofs",
+	capabilities.CapabilitySyncCommitteeSubscriptions: "/eth/v1/validator/sync_committee_subscriptions",
+	capabilities.CapabilityProposalPreparations:       "/eth/v1/validator/prepare_beacon_proposer",
+}
+
+type specJSON struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// probe probes the capabilities of each beacon node.
+func (s *Service) probe(ctx context.Context, _ interface{}) {
+	s.nodesMu.RLock()
+	addresses := make([]string, 0, len(s.nodes))
+	for address := range s.nodes {
+		addresses = append(addresses, address)
+	}
+	s.nodesMu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, address := range addresses {
+		wg.Add(1)
+		go func(ctx context.Context, address string) {
+			defer wg.Done()
+			nodeCapabilities, err := s.probeNode(ctx, address)
+			if err != nil {
+				log.Debug().Str("address", address).Err(err).Msg("Failed to probe capabilities")
+				return
+			}
+			s.update(address, nodeCapabilities)
+		}(ctx, address)
+	}
+	wg.Wait()
+}
+
+// update updates the capabilities of a beacon node, reporting them if they have changed.
+func (s *Service) update(address string, nodeCapabilities map[capabilities.Capability]bool) {
+	s.nodesMu.Lock()
+	previous := s.nodes[address]
+	changed := false
+	for capability, supported := range nodeCapabilities {
+		if prior, determined := previous[capability]; !determined || prior != supported {
+			changed = true
+		}
+	}
+	merged := make(map[capabilities.Capability]bool, len(previous))
+	for capability, supported := range previous {
+		merged[capability] = supported
+	}
+	for capability, supported := range nodeCapabilities {
+		merged[capability] = supported
+	}
+	s.nodes[address] = merged
+	s.nodesMu.Unlock()
+
+	supported := make([]string, 0)
+	unsupported := make([]string, 0)
+	for capability, isSupported := range merged {
+		monitorCapability(address, capability, isSupported)
+		if isSupported {
+			supported = append(supported, string(capability))
+		} else {
+			unsupported = append(unsupported, string(capability))
+		}
+	}
+	if !changed {
+		return
+	}
+	sort.Strings(supported)
+	sort.Strings(unsupported)
+	e := log.Info()
+	if len(unsupported) > 0 {
+		e = log.Warn()
+	}
+	e.Str("address", address).Strs("supported", supported).Strs("unsupported", unsupported).Msg("Beacon node capabilities")
+}
+
+// probeNode probes the capabilities of a single beacon node.
+// Capabilities that cannot be determined are left out.
+func (s *Service) probeNode(ctx context.Context, address string) (map[capabilities.Capability]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	status, body, err := s.request(ctx, http.MethodGet, address, "/eth/v1/config/spec")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to obtain spec: status %d", status)
+	}
+	spec := &specJSON{}
+	if err := json.Unmarshal(body, spec); err != nil {
+		return nil, errors.Wrap(err, "failed to parse spec")
+	}
+
+	res := make(map[capabilities.Capability]bool)
+	for capability, key := range forkCapabilities {
+		_, res[capability] = spec.Data[key]
+	}
+
+	for capability, path := range endpointCapabilities {
+		status, _, err := s.request(ctx, http.MethodPost, address, path)
+		if err != nil {
+			log.Trace().Str("address", address).Str("capability", string(capability)).Err(err).Msg("Failed to probe endpoint")
+			continue
+		}
+		switch {
+		case status == http.StatusNotFound, status == http.StatusMethodNotAllowed, status == http.StatusNotImplemented:
+			res[capability] = false
+		case status >= 200 && status < 300, status == http.StatusBadRequest:
+			// The endpoint exists, even if it did not accept an empty request.
+			res[capability] = true
+		default:
+			// Anything else, such as a server error, tells us nothing about the endpoint.
+			log.Trace().Str("address", address).Str("capability", string(capability)).Int("status", status).Msg("Endpoint probe inconclusive")
+		}
+	}
+
+	return res, nil
+}
+
+// request sends a request to a beacon node, returning the status and body of the response.
+// POST requests send an empty list.
+func (s *Service) request(ctx context.Context, method string, address string, path string) (int, []byte, error) {
+	s.nodesMu.RLock()
+	endpoint, exists := s.endpoints[address]
+	s.nodesMu.RUnlock()
+	if exists {
+		address = endpoint
+	}
+
+	var body []byte
+	if method == http.MethodPost {
+		body = []byte("[]")
+	}
+
+	return util.BeaconNodeRequest(ctx, s.client, method, address, path, body)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/capabilities"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeNodeStatuses(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/config/spec":
+			_, _ = w.Write([]byte(`{"data":{"SLOTS_PER_EPOCH":"32"}}`))
+		case "/eth/v1/beacon/pool/sync_committees":
+			w.WriteHeader(http.StatusOK)
+		case "/eth/v1/validator/contribution_and_proofs":
+			w.WriteHeader(http.StatusBadRequest)
+		case "/eth/v1/validator/sync_committee_subscriptions":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s, err := New(ctx,
+		WithLogLevel(zerolog.Disabled),
+		WithScheduler(mockscheduler.New()),
+		WithTimeout(time.Second),
+		WithAddresses([]string{}),
+	)
+	require.NoError(t, err)
+
+	res, err := s.probeNode(ctx, server.URL)
+	require.NoError(t, err)
+
+	require.True(t, res[capabilities.CapabilitySyncCommitteeMessages])
+	require.True(t, res[capabilities.CapabilitySyncCommitteeContributions])
+	require.False(t, res[capabilities.CapabilityProposalPreparations])
+	// A server error does not determine the capability either way.
+	_, determined := res[capabilities.CapabilitySyncCommitteeSubscriptions]
+	require.False(t, determined)
+}
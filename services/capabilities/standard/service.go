@@ -0,0 +1,147 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service probes the capabilities of beacon nodes.
+type Service struct {
+	timeout   time.Duration
+	client    *http.Client
+	endpoints map[string]string
+
+	nodesMu sync.RWMutex
+	// nodes are the capabilities of each beacon node, keyed by address.
+	// Capabilities that have not been determined are absent.
+	nodes map[string]map[capabilities.Capability]bool
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new capabilities service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "capabilities").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		timeout:   parameters.timeout,
+		client:    util.NewBeaconNodeHTTPClient(parameters.timeout),
+		endpoints: parameters.endpoints,
+		nodes:     make(map[string]map[capabilities.Capability]bool),
+	}
+	for _, address := range parameters.addresses {
+		s.nodes[address] = make(map[capabilities.Capability]bool)
+	}
+
+	// Carry out an initial probe before returning, so that requests are routed correctly from the start.
+	s.probe(ctx, nil)
+
+	interval := parameters.interval
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(interval), nil
+	}
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"Capabilities",
+		"Probe beacon node capabilities",
+		runtimeFunc,
+		nil,
+		s.probe,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to schedule periodic capabilities probe")
+	}
+
+	return s, nil
+}
+
+// Supports returns true if the beacon node with the given address supports the capability.
+// Capabilities that have yet to be determined are considered supported.
+func (s *Service) Supports(address string, capability capabilities.Capability) bool {
+	s.nodesMu.RLock()
+	defer s.nodesMu.RUnlock()
+	supported, determined := s.nodes[address][capability]
+	return !determined || supported
+}
+
+// SetAddresses sets the addresses of the beacon nodes to probe, and the endpoints through which they are reached.
+// The capabilities of beacon nodes that remain are retained, and beacon nodes that are added are probed immediately.
+func (s *Service) SetAddresses(ctx context.Context, addresses []string, endpoints map[string]string) {
+	added := false
+	s.nodesMu.Lock()
+	nodes := make(map[string]map[capabilities.Capability]bool, len(addresses))
+	for _, address := range addresses {
+		nodeCapabilities, exists := s.nodes[address]
+		if !exists {
+			nodeCapabilities = make(map[capabilities.Capability]bool)
+			added = true
+		}
+		nodes[address] = nodeCapabilities
+	}
+	s.nodes = nodes
+	s.endpoints = endpoints
+	s.nodesMu.Unlock()
+
+	if added {
+		s.probe(ctx, nil)
+	}
+}
+
+// Filter returns the addresses of the beacon nodes that support the capability, in the order supplied.
+// If no beacon nodes support the capability then all are returned, in case the probe was mistaken.
+// Beacon nodes not previously seen by the service are added to those it probes.
+func (s *Service) Filter(capability capabilities.Capability, addresses []string) []string {
+	res := make([]string, 0, len(addresses))
+	s.nodesMu.Lock()
+	for _, address := range addresses {
+		nodeCapabilities, exists := s.nodes[address]
+		if !exists {
+			nodeCapabilities = make(map[capabilities.Capability]bool)
+			s.nodes[address] = nodeCapabilities
+		}
+		if supported, determined := nodeCapabilities[capability]; !determined || supported {
+			res = append(res, address)
+		}
+	}
+	s.nodesMu.Unlock()
+
+	if len(res) == 0 && len(addresses) > 0 {
+		log.Debug().Str("capability", string(capability)).Strs("addresses", addresses).Msg("No beacon nodes support capability; using all")
+		return addresses
+	}
+	return res
+}
@@ -0,0 +1,182 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/capabilities/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// nodeServer provides a beacon node API that supports altair but not bellatrix or proposal preparations.
+func nodeServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/config/spec":
+			_, _ = w.Write([]byte(`{"data":{"SLOTS_PER_EPOCH":"32","ALTAIR_FORK_EPOCH":"74240"}}`))
+		case "/eth/v1/beacon/pool/sync_committees",
+			"/eth/v1/validator/contribution_and_proofs",
+			"/eth/v1/validator/sync_committee_subscriptions":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "TimeoutMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "IntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+				standard.WithInterval(0),
+			},
+			err: "problem with parameters: interval must be positive",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSupports(t *testing.T) {
+	ctx := context.Background()
+
+	server := nodeServer()
+	defer server.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithTimeout(time.Second),
+		standard.WithAddresses([]string{server.URL, "http://127.0.0.1:1"}),
+	)
+	require.NoError(t, err)
+
+	require.True(t, s.Supports(server.URL, capabilities.CapabilityAltair))
+	require.False(t, s.Supports(server.URL, capabilities.CapabilityBellatrix))
+	require.True(t, s.Supports(server.URL, capabilities.CapabilitySyncCommitteeMessages))
+	require.True(t, s.Supports(server.URL, capabilities.CapabilitySyncCommitteeContributions))
+	require.True(t, s.Supports(server.URL, capabilities.CapabilitySyncCommitteeSubscriptions))
+	require.False(t, s.Supports(server.URL, capabilities.CapabilityProposalPreparations))
+
+	// Unreachable node is undetermined, so considered capable.
+	require.True(t, s.Supports("http://127.0.0.1:1", capabilities.CapabilityProposalPreparations))
+}
+
+func TestFilter(t *testing.T) {
+	ctx := context.Background()
+
+	server := nodeServer()
+	defer server.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithTimeout(time.Second),
+		standard.WithAddresses([]string{server.URL, "http://127.0.0.1:1"}),
+	)
+	require.NoError(t, err)
+
+	// Unsupported node is excluded.
+	require.Equal(t, []string{"http://127.0.0.1:1"}, s.Filter(capabilities.CapabilityProposalPreparations, []string{server.URL, "http://127.0.0.1:1"}))
+	// Supported nodes are retained.
+	require.Equal(t, []string{server.URL, "http://127.0.0.1:1"}, s.Filter(capabilities.CapabilitySyncCommitteeContributions, []string{server.URL, "http://127.0.0.1:1"}))
+	// No supported nodes falls back to all.
+	require.Equal(t, []string{server.URL}, s.Filter(capabilities.CapabilityBellatrix, []string{server.URL}))
+	// Unknown nodes are considered capable.
+	require.Equal(t, []string{"unknown"}, s.Filter(capabilities.CapabilityBellatrix, []string{"unknown"}))
+}
+
+func TestSetAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	server := nodeServer()
+	defer server.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithTimeout(time.Second),
+	)
+	require.NoError(t, err)
+	require.True(t, s.Supports(server.URL, capabilities.CapabilityBellatrix))
+
+	// Added node is probed immediately.
+	s.SetAddresses(ctx, []string{server.URL}, nil)
+	require.False(t, s.Supports(server.URL, capabilities.CapabilityBellatrix))
+
+	// Removed node is forgotten.
+	s.SetAddresses(ctx, []string{}, nil)
+	require.True(t, s.Supports(server.URL, capabilities.CapabilityBellatrix))
+}
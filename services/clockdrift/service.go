@@ -0,0 +1,36 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clockdrift
+
+import "time"
+
+// Service is the clock drift service.
+type Service interface {
+	// Drift provides the estimated offset of the local clock from the beacon nodes.
+	// A positive value means that the local clock is ahead of the beacon nodes.
+	// The second return value is false if no estimate is yet available.
+	Drift() (time.Duration, bool)
+}
+
+// Checker is the interface for checking that clock drift is within acceptable limits.
+type Checker interface {
+	// CheckDrift returns an error if the local clock has drifted beyond acceptable limits.
+	CheckDrift() error
+}
+
+// EndpointsSetter is the interface for updating the endpoints through which beacon nodes are reached.
+type EndpointsSetter interface {
+	// SetEndpoints sets the endpoints through which beacon nodes are reached, keyed by address.
+	SetEndpoints(endpoints map[string]string)
+}
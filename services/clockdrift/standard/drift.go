@@ -0,0 +1,179 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+)
+
+// maxSamples is the number of samples retained for each node.
+const maxSamples = 16
+
+// checkDrift samples the date of each node and updates the drift estimate.
+func (s *Service) checkDrift(ctx context.Context, _ interface{}) {
+	var wg sync.WaitGroup
+	for _, address := range s.addresses {
+		wg.Add(1)
+		go func(ctx context.Context, address string) {
+			defer wg.Done()
+			offset, err := s.sampleDateOffset(ctx, address)
+			if err != nil {
+				log.Debug().Str("address", address).Err(err).Msg("Failed to obtain date offset")
+				return
+			}
+			log.Trace().Str("address", address).Dur("offset", offset).Msg("Obtained date offset")
+			s.samplesMu.Lock()
+			s.dateOffsets[address] = appendSample(s.dateOffsets[address], offset)
+			s.samplesMu.Unlock()
+		}(ctx, address)
+	}
+	wg.Wait()
+
+	s.updateDrift()
+}
+
+// sampleDateOffset obtains an estimate of the offset of the local clock from the node
+// using the Date header of an HTTP response.
+func (s *Service) sampleDateOffset(ctx context.Context, address string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, util.BeaconNodeURL(s.endpoint(address), "/eth/v1/node/version"), nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create request")
+	}
+	sent := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to send request")
+	}
+	received := time.Now()
+	defer resp.Body.Close()
+
+	dateHeader := resp.Header.Get("Date")
+	if dateHeader == "" {
+		return 0, errors.New("no date header in response")
+	}
+	date, err := http.ParseTime(dateHeader)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid date header")
+	}
+
+	return dateOffset(sent, received, date), nil
+}
+
+// dateOffset calculates the offset of the local clock given the times a request was
+// sent and received and the date returned by the server.
+func dateOffset(sent time.Time, received time.Time, date time.Time) time.Duration {
+	// Assume that the server generated the date half-way through the round trip.
+	local := sent.Add(received.Sub(sent) / 2)
+	// Date headers are truncated to the second, so on average the server time is half a second later.
+	remote := date.Add(500 * time.Millisecond)
+	return local.Sub(remote)
+}
+
+// updateDrift recalculates the drift from the available samples.
+func (s *Service) updateDrift() {
+	s.samplesMu.Lock()
+	offsets := make([]time.Duration, 0, len(s.addresses))
+	for _, address := range s.addresses {
+		offset, available := nodeOffset(s.dateOffsets[address], s.headDelays[address])
+		if !available {
+			continue
+		}
+		monitorNodeOffset(address, offset)
+		offsets = append(offsets, offset)
+	}
+	if len(offsets) > 0 {
+		s.drift = median(offsets)
+		s.haveDrift = true
+	}
+	drift := s.drift
+	haveDrift := s.haveDrift
+	s.samplesMu.Unlock()
+
+	if !haveDrift {
+		log.Debug().Msg("No clock drift estimate available")
+		return
+	}
+
+	monitorDrift(drift)
+	if absDuration(drift) > s.warnThreshold {
+		log.Warn().Dur("drift", drift).Dur("threshold", s.warnThreshold).Msg("Local clock has drifted from beacon nodes; check time synchronization")
+	} else {
+		log.Trace().Dur("drift", drift).Msg("Clock drift within threshold")
+	}
+}
+
+// nodeOffset estimates the offset of the local clock from a single node.
+func nodeOffset(dateOffsets []time.Duration, headDelays []time.Duration) (time.Duration, bool) {
+	var offset time.Duration
+	available := false
+	if len(dateOffsets) > 0 {
+		offset = median(dateOffsets)
+		available = true
+	}
+
+	// A head event cannot arrive before the start of its slot, so its delay from the local
+	// start of slot is an upper bound on the offset of the local clock.
+	if len(headDelays) > 0 {
+		minDelay := headDelays[0]
+		for _, delay := range headDelays[1:] {
+			if delay < minDelay {
+				minDelay = delay
+			}
+		}
+		if minDelay < 0 && (!available || minDelay < offset) {
+			offset = minDelay
+			available = true
+		}
+	}
+
+	return offset, available
+}
+
+// appendSample appends a sample, discarding the oldest if required.
+func appendSample(samples []time.Duration, sample time.Duration) []time.Duration {
+	samples = append(samples, sample)
+	if len(samples) > maxSamples {
+		samples = samples[len(samples)-maxSamples:]
+	}
+	return samples
+}
+
+// median returns the median of the supplied durations.
+func median(durations []time.Duration) time.Duration {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func absDuration(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return -duration
+	}
+	return duration
+}
@@ -0,0 +1,123 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateOffset(t *testing.T) {
+	base := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		sent     time.Time
+		received time.Time
+		date     time.Time
+		expected time.Duration
+	}{
+		{
+			name:     "InSync",
+			sent:     base.Add(400 * time.Millisecond),
+			received: base.Add(600 * time.Millisecond),
+			date:     base,
+			expected: 0,
+		},
+		{
+			name:     "LocalAhead",
+			sent:     base.Add(2400 * time.Millisecond),
+			received: base.Add(2600 * time.Millisecond),
+			date:     base,
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "LocalBehind",
+			sent:     base.Add(-2600 * time.Millisecond),
+			received: base.Add(-2400 * time.Millisecond),
+			date:     base,
+			expected: -3 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, dateOffset(test.sent, test.received, test.date))
+		})
+	}
+}
+
+func TestNodeOffset(t *testing.T) {
+	tests := []struct {
+		name        string
+		dateOffsets []time.Duration
+		headDelays  []time.Duration
+		expected    time.Duration
+		available   bool
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:        "DateOnly",
+			dateOffsets: []time.Duration{time.Second, 3 * time.Second, 2 * time.Second},
+			expected:    2 * time.Second,
+			available:   true,
+		},
+		{
+			name:       "HeadOnlyPositive",
+			headDelays: []time.Duration{time.Second, 4 * time.Second},
+		},
+		{
+			name:       "HeadOnlyNegative",
+			headDelays: []time.Duration{-time.Second, 4 * time.Second},
+			expected:   -time.Second,
+			available:  true,
+		},
+		{
+			name:        "HeadBounds",
+			dateOffsets: []time.Duration{0, 0},
+			headDelays:  []time.Duration{-2 * time.Second, time.Second},
+			expected:    -2 * time.Second,
+			available:   true,
+		},
+		{
+			name:        "HeadDoesNotBound",
+			dateOffsets: []time.Duration{-3 * time.Second},
+			headDelays:  []time.Duration{-2 * time.Second},
+			expected:    -3 * time.Second,
+			available:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			offset, available := nodeOffset(test.dateOffsets, test.headDelays)
+			require.Equal(t, test.available, available)
+			require.Equal(t, test.expected, offset)
+		})
+	}
+}
+
+func TestAppendSample(t *testing.T) {
+	var samples []time.Duration
+	for i := 0; i < maxSamples+5; i++ {
+		samples = appendSample(samples, time.Duration(i))
+	}
+	require.Len(t, samples, maxSamples)
+	require.Equal(t, time.Duration(5), samples[0])
+	require.Equal(t, time.Duration(maxSamples+4), samples[maxSamples-1])
+}
@@ -0,0 +1,45 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+)
+
+// headEventHandler returns a handler that records the delay of head events for the given node.
+func (s *Service) headEventHandler(address string) eth2client.EventHandlerFunc {
+	return func(event *apiv1.Event) {
+		if event.Data == nil {
+			return
+		}
+		data, isHeadEvent := event.Data.(*apiv1.HeadEvent)
+		if !isHeadEvent {
+			return
+		}
+		// Only consider events for the current slot, as events for earlier slots
+		// (for example after a reorg) say nothing about the clock.
+		if data.Slot != s.chainTime.CurrentSlot() && data.Slot != s.chainTime.CurrentSlot()+1 {
+			return
+		}
+		delay := time.Since(s.chainTime.StartOfSlot(data.Slot))
+		log.Trace().Str("address", address).Uint64("slot", uint64(data.Slot)).Dur("delay", delay).Msg("Received head event")
+
+		s.samplesMu.Lock()
+		s.headDelays[address] = appendSample(s.headDelays[address], delay)
+		s.samplesMu.Unlock()
+	}
+}
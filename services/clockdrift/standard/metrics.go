@@ -0,0 +1,92 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var driftOffset prometheus.Gauge
+var nodeOffsets *prometheus.GaugeVec
+var signingRefusals prometheus.Counter
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if driftOffset != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	driftOffset = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "clockdrift",
+		Name:      "offset_seconds",
+		Help:      "The estimated offset of the local clock from the beacon nodes.",
+	})
+	if err := prometheus.Register(driftOffset); err != nil {
+		return err
+	}
+
+	nodeOffsets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "clockdrift",
+		Name:      "node_offset_seconds",
+		Help:      "The estimated offset of the local clock from each beacon node.",
+	}, []string{"server"})
+	if err := prometheus.Register(nodeOffsets); err != nil {
+		return err
+	}
+
+	signingRefusals = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "clockdrift",
+		Name:      "signing_refusals_total",
+		Help:      "The number of times signing was refused due to clock drift.",
+	})
+	return prometheus.Register(signingRefusals)
+}
+
+func monitorDrift(offset time.Duration) {
+	if driftOffset == nil {
+		return
+	}
+	driftOffset.Set(offset.Seconds())
+}
+
+func monitorNodeOffset(server string, offset time.Duration) {
+	if nodeOffsets == nil {
+		return
+	}
+	nodeOffsets.WithLabelValues(server).Set(offset.Seconds())
+}
+
+func monitorSigningRefused() {
+	if signingRefusals == nil {
+		return
+	}
+	signingRefusals.Inc()
+}
@@ -0,0 +1,173 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel        zerolog.Level
+	monitor         metrics.Service
+	scheduler       scheduler.Service
+	chainTime       chaintime.Service
+	timeout         time.Duration
+	interval        time.Duration
+	addresses       []string
+	endpoints       map[string]string
+	eventsProviders map[string]eth2client.EventsProvider
+	warnThreshold   time.Duration
+	maxDrift        time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithScheduler sets the scheduler for the module.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithChainTime sets the chaintime service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithTimeout sets the timeout for requests made by the module.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithInterval sets the interval between clock drift checks.
+func WithInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.interval = interval
+	})
+}
+
+// WithAddresses sets the addresses of the beacon nodes against which to check drift.
+func WithAddresses(addresses []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.addresses = addresses
+	})
+}
+
+// WithEndpoints sets the endpoints through which beacon nodes are reached, keyed by address.
+// Beacon nodes without an endpoint are reached directly at their address.
+func WithEndpoints(endpoints map[string]string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.endpoints = endpoints
+	})
+}
+
+// WithEventsProviders sets the events providers, keyed by address, used to time head events.
+func WithEventsProviders(providers map[string]eth2client.EventsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.eventsProviders = providers
+	})
+}
+
+// WithWarnThreshold sets the drift above which a warning is logged.
+func WithWarnThreshold(threshold time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.warnThreshold = threshold
+	})
+}
+
+// WithMaxDrift sets the drift above which signing is refused.
+// A value of 0 disables the check.
+func WithMaxDrift(maxDrift time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxDrift = maxDrift
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		monitor:       nullmetrics.New(context.Background()),
+		interval:      time.Minute,
+		warnThreshold: time.Second,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chaintime specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	if len(parameters.addresses) == 0 {
+		return nil, errors.New("no addresses specified")
+	}
+	if parameters.warnThreshold <= 0 {
+		return nil, errors.New("warn threshold must be positive")
+	}
+	if parameters.maxDrift < 0 {
+		return nil, errors.New("max drift cannot be negative")
+	}
+
+	return &parameters, nil
+}
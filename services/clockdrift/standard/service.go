@@ -0,0 +1,155 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a clock drift service.
+type Service struct {
+	chainTime     chaintime.Service
+	timeout       time.Duration
+	client        *http.Client
+	addresses     []string
+	warnThreshold time.Duration
+
+	endpointsMu sync.RWMutex
+	endpoints   map[string]string
+
+	maxDrift time.Duration
+
+	samplesMu   sync.RWMutex
+	dateOffsets map[string][]time.Duration
+	headDelays  map[string][]time.Duration
+	drift       time.Duration
+	haveDrift   bool
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new clock drift service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "clockdrift").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		chainTime:     parameters.chainTime,
+		timeout:       parameters.timeout,
+		client:        util.NewBeaconNodeHTTPClient(parameters.timeout),
+		addresses:     parameters.addresses,
+		endpoints:     parameters.endpoints,
+		warnThreshold: parameters.warnThreshold,
+		maxDrift:      parameters.maxDrift,
+		dateOffsets:   make(map[string][]time.Duration),
+		headDelays:    make(map[string][]time.Duration),
+	}
+
+	for address, eventsProvider := range parameters.eventsProviders {
+		if err := eventsProvider.Events(ctx, []string{"head"}, s.headEventHandler(address)); err != nil {
+			// Not fatal, as we can still use the date headers.
+			log.Warn().Str("address", address).Err(err).Msg("Failed to configure head events for clock drift")
+		}
+	}
+
+	// Carry out an initial check in the background, so that we have an estimate as soon as possible
+	// without holding up startup if the beacon nodes are slow to respond.
+	go s.checkDrift(ctx, nil)
+
+	interval := parameters.interval
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(interval), nil
+	}
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"Clock drift",
+		"Check clock drift",
+		runtimeFunc,
+		nil,
+		s.checkDrift,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to schedule periodic clock drift check")
+	}
+
+	return s, nil
+}
+
+// Drift provides the estimated offset of the local clock from the beacon nodes.
+// A positive value means that the local clock is ahead of the beacon nodes.
+// The second return value is false if no estimate is yet available.
+func (s *Service) Drift() (time.Duration, bool) {
+	s.samplesMu.RLock()
+	defer s.samplesMu.RUnlock()
+
+	return s.drift, s.haveDrift
+}
+
+// CheckDrift returns an error if the local clock has drifted beyond acceptable limits.
+func (s *Service) CheckDrift() error {
+	if s.maxDrift == 0 {
+		return nil
+	}
+
+	drift, haveDrift := s.Drift()
+	if !haveDrift {
+		return nil
+	}
+	if absDuration(drift) > s.maxDrift {
+		monitorSigningRefused()
+		return fmt.Errorf("local clock drift of %v exceeds maximum of %v", drift, s.maxDrift)
+	}
+
+	return nil
+}
+
+// SetEndpoints sets the endpoints through which beacon nodes are reached, keyed by address.
+func (s *Service) SetEndpoints(endpoints map[string]string) {
+	s.endpointsMu.Lock()
+	s.endpoints = endpoints
+	s.endpointsMu.Unlock()
+}
+
+// endpoint provides the endpoint through which the beacon node at the given address is reached.
+func (s *Service) endpoint(address string) string {
+	s.endpointsMu.RLock()
+	defer s.endpointsMu.RUnlock()
+
+	if endpoint, exists := s.endpoints[address]; exists {
+		return endpoint
+	}
+	return address
+}
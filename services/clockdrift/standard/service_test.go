@@ -0,0 +1,266 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/clockdrift/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses([]string{server.URL}),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses([]string{server.URL}),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses([]string{server.URL}),
+			},
+			err: "problem with parameters: no chaintime specified",
+		},
+		{
+			name: "TimeoutMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithAddresses([]string{server.URL}),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "IntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithInterval(0),
+				standard.WithAddresses([]string{server.URL}),
+			},
+			err: "problem with parameters: interval must be positive",
+		},
+		{
+			name: "AddressesMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no addresses specified",
+		},
+		{
+			name: "WarnThresholdZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses([]string{server.URL}),
+				standard.WithWarnThreshold(0),
+			},
+			err: "problem with parameters: warn threshold must be positive",
+		},
+		{
+			name: "MaxDriftNegative",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses([]string{server.URL}),
+				standard.WithMaxDrift(-1),
+			},
+			err: "problem with parameters: max drift cannot be negative",
+		},
+		{
+			name: "EventsErroring",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses([]string{server.URL}),
+				standard.WithEventsProviders(map[string]eth2client.EventsProvider{
+					server.URL: mock.NewErroringEventsProvider(),
+				}),
+			},
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses([]string{server.URL}),
+				standard.WithEventsProviders(map[string]eth2client.EventsProvider{
+					server.URL: mock.NewEventsProvider(),
+				}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckDrift(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	// Server whose clock is 10 seconds behind ours.
+	behindServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-10*time.Second).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer behindServer.Close()
+
+	tests := []struct {
+		name      string
+		maxDrift  time.Duration
+		addresses []string
+		endpoints map[string]string
+		estimate  bool
+		err       string
+	}{
+		{
+			name:      "Disabled",
+			addresses: []string{behindServer.URL},
+		},
+		{
+			name:      "NoEstimate",
+			maxDrift:  time.Second,
+			addresses: []string{"http://localhost:1"},
+		},
+		{
+			name:      "WithinLimit",
+			maxDrift:  20 * time.Second,
+			addresses: []string{behindServer.URL},
+			estimate:  true,
+		},
+		{
+			name:      "ExceedsLimit",
+			maxDrift:  5 * time.Second,
+			addresses: []string{behindServer.URL},
+			estimate:  true,
+			err:       "local clock drift of",
+		},
+		{
+			name:      "Endpoint",
+			maxDrift:  5 * time.Second,
+			addresses: []string{"http://localhost:1"},
+			endpoints: map[string]string{"http://localhost:1": behindServer.URL},
+			estimate:  true,
+			err:       "local clock drift of",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := standard.New(ctx,
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithAddresses(test.addresses),
+				standard.WithEndpoints(test.endpoints),
+				standard.WithMaxDrift(test.maxDrift),
+			)
+			require.NoError(t, err)
+			if test.estimate {
+				// Wait for the initial check to provide an estimate.
+				require.Eventually(t, func() bool {
+					_, haveDrift := s.Drift()
+					return haveDrift
+				}, 5*time.Second, 10*time.Millisecond)
+			}
+			err = s.CheckDrift()
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
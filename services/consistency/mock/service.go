@@ -0,0 +1,48 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import "github.com/attestantio/vouch/services/consistency"
+
+// Service is a mock consistency service.
+type Service struct {
+	inconsistent map[string]bool
+}
+
+// New creates a new mock consistency service, which considers the given beacon nodes inconsistent.
+func New(inconsistent ...string) consistency.Service {
+	s := &Service{
+		inconsistent: make(map[string]bool, len(inconsistent)),
+	}
+	for _, address := range inconsistent {
+		s.inconsistent[address] = true
+	}
+	return s
+}
+
+// Consistent returns true if the beacon node with the given address agrees with the majority of beacon nodes.
+func (s *Service) Consistent(address string) bool {
+	return !s.inconsistent[address]
+}
+
+// Filter returns the addresses of the beacon nodes that agree with the majority of beacon nodes, in the order supplied.
+func (s *Service) Filter(addresses []string) []string {
+	res := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if !s.inconsistent[address] {
+			res = append(res, address)
+		}
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package null is a consistency service that considers all beacon nodes consistent.
+package null
+
+import "context"
+
+// Service is a consistency service that considers all beacon nodes consistent.
+type Service struct{}
+
+// New creates a new null consistency service.
+func New(_ context.Context) *Service {
+	return &Service{}
+}
+
+// Consistent returns true if the beacon node with the given address agrees with the majority of beacon nodes.
+func (*Service) Consistent(_ string) bool {
+	return true
+}
+
+// Filter returns the addresses of the beacon nodes that agree with the majority of beacon nodes, in the order supplied.
+func (*Service) Filter(addresses []string) []string {
+	return addresses
+}
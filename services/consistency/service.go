@@ -0,0 +1,33 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistency
+
+import "context"
+
+// Service is the consistency service.
+type Service interface {
+	// Consistent returns true if the beacon node with the given address agrees with the majority of beacon nodes.
+	Consistent(address string) bool
+
+	// Filter returns the addresses of the beacon nodes that agree with the majority of beacon nodes, in the order supplied.
+	Filter(addresses []string) []string
+}
+
+// AddressesSetter is the interface for updating the beacon nodes whose consistency is checked.
+type AddressesSetter interface {
+	// SetAddresses sets the addresses of the beacon nodes to check, those used for Vouch's main
+	// connection, and the endpoints through which they are reached.
+	// An error is returned if any of the main beacon nodes disagrees with the majority of beacon nodes.
+	SetAddresses(ctx context.Context, addresses []string, mainAddresses []string, endpoints map[string]string) error
+}
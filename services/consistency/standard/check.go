@@ -0,0 +1,255 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+)
+
+// specKeys are the spec values compared across beacon nodes.
+// Values that a beacon node does not report, for example forks of which it is unaware, are not compared.
+var specKeys = []string{
+	"SECONDS_PER_SLOT",
+	"SLOTS_PER_EPOCH",
+	"EPOCHS_PER_SYNC_COMMITTEE_PERIOD",
+	"GENESIS_FORK_VERSION",
+	"ALTAIR_FORK_VERSION",
+	"ALTAIR_FORK_EPOCH",
+	"BELLATRIX_FORK_VERSION",
+	"BELLATRIX_FORK_EPOCH",
+	"DEPOSIT_CHAIN_ID",
+	"DEPOSIT_NETWORK_ID",
+	"DEPOSIT_CONTRACT_ADDRESS",
+}
+
+type genesisJSON struct {
+	Data *genesisDataJSON `json:"data"`
+}
+
+type genesisDataJSON struct {
+	GenesisTime           string `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+}
+
+type forkScheduleJSON struct {
+	Data []*forkJSON `json:"data"`
+}
+
+type forkJSON struct {
+	CurrentVersion string `json:"current_version"`
+	Epoch          string `json:"epoch"`
+}
+
+type specJSON struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// check checks the consistency of the beacon nodes.
+func (s *Service) check(ctx context.Context, _ interface{}) {
+	s.nodesMu.RLock()
+	addresses := make([]string, 0, len(s.nodes))
+	for address := range s.nodes {
+		addresses = append(addresses, address)
+	}
+	endpoints := s.endpoints
+	s.nodesMu.RUnlock()
+
+	fetched := s.fetch(ctx, addresses, endpoints)
+
+	s.nodesMu.Lock()
+	previous := snapshot(s.nodes)
+	for address, values := range fetched {
+		if n, exists := s.nodes[address]; exists {
+			n.values = values
+		}
+	}
+	assess(s.nodes, s.mainAddresses)
+	current := snapshot(s.nodes)
+	mainErr := mainConsistent(s.nodes, s.mainAddresses)
+	s.nodesMu.Unlock()
+
+	report(previous, current)
+	if mainErr != nil {
+		// The main beacon node cannot be excluded, so all that can be done is to make a noise.
+		log.Error().Err(mainErr).Msg("Main beacon node is inconsistent")
+	}
+}
+
+// fetch fetches the network values of the given beacon nodes.
+// Beacon nodes whose values cannot be obtained are left out.
+func (s *Service) fetch(ctx context.Context, addresses []string, endpoints map[string]string) map[string]map[string]string {
+	res := make(map[string]map[string]string, len(addresses))
+	var resMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, address := range addresses {
+		wg.Add(1)
+		go func(ctx context.Context, address string) {
+			defer wg.Done()
+			endpoint, exists := endpoints[address]
+			if !exists {
+				endpoint = address
+			}
+			values, err := s.nodeValues(ctx, endpoint)
+			if err != nil {
+				log.Debug().Str("address", address).Err(err).Msg("Failed to obtain network values")
+				return
+			}
+			resMu.Lock()
+			res[address] = values
+			resMu.Unlock()
+		}(ctx, address)
+	}
+	wg.Wait()
+
+	return res
+}
+
+// nodeValues obtains the network values of a single beacon node, reached at the given endpoint.
+func (s *Service) nodeValues(ctx context.Context, endpoint string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	genesis := &genesisJSON{}
+	if err := s.get(ctx, endpoint, "/eth/v1/beacon/genesis", genesis); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain genesis")
+	}
+	if genesis.Data == nil {
+		return nil, errors.New("genesis missing")
+	}
+
+	forkSchedule := &forkScheduleJSON{}
+	if err := s.get(ctx, endpoint, "/eth/v1/config/fork_schedule", forkSchedule); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain fork schedule")
+	}
+
+	spec := &specJSON{}
+	if err := s.get(ctx, endpoint, "/eth/v1/config/spec", spec); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	res := map[string]string{
+		"genesis_time":            genesis.Data.GenesisTime,
+		"genesis_validators_root": strings.ToLower(genesis.Data.GenesisValidatorsRoot),
+		"genesis_fork_version":    strings.ToLower(genesis.Data.GenesisForkVersion),
+	}
+	for _, fork := range forkSchedule.Data {
+		if fork == nil {
+			continue
+		}
+		res[fmt.Sprintf("fork %s epoch", strings.ToLower(fork.CurrentVersion))] = fork.Epoch
+	}
+	for _, key := range specKeys {
+		if value, exists := spec.Data[key]; exists {
+			res[key] = strings.ToLower(fmt.Sprintf("%v", value))
+		}
+	}
+
+	return res, nil
+}
+
+// assess compares the values of the beacon nodes, updating their mismatches.
+// Each value is compared against that reported by the most beacon nodes; ties are broken in
+// favour of the value reported by a main beacon node, and then by the lowest value, so that
+// the result does not depend on the order in which beacon nodes are seen.
+func assess(nodes map[string]*node, mainAddresses []string) {
+	counts := make(map[string]map[string]int)
+	for _, n := range nodes {
+		for key, value := range n.values {
+			if _, exists := counts[key]; !exists {
+				counts[key] = make(map[string]int)
+			}
+			counts[key][value]++
+		}
+	}
+
+	mainValues := make(map[string]map[string]bool)
+	for _, address := range mainAddresses {
+		n, exists := nodes[address]
+		if !exists {
+			continue
+		}
+		for key, value := range n.values {
+			if _, exists := mainValues[key]; !exists {
+				mainValues[key] = make(map[string]bool)
+			}
+			mainValues[key][value] = true
+		}
+	}
+
+	reference := make(map[string]string, len(counts))
+	for key, valueCounts := range counts {
+		best := ""
+		bestCount := 0
+		bestMain := false
+		for value, count := range valueCounts {
+			isMain := mainValues[key][value]
+			switch {
+			case count > bestCount,
+				count == bestCount && isMain && !bestMain,
+				count == bestCount && isMain == bestMain && value < best:
+				best = value
+				bestCount = count
+				bestMain = isMain
+			}
+		}
+		reference[key] = best
+	}
+
+	for _, n := range nodes {
+		mismatches := make([]string, 0)
+		for key, value := range n.values {
+			if value != reference[key] {
+				mismatches = append(mismatches, fmt.Sprintf("%s is %s rather than %s", key, value, reference[key]))
+			}
+		}
+		sort.Strings(mismatches)
+		n.mismatches = mismatches
+	}
+}
+
+// snapshot provides the mismatches of each beacon node.
+func snapshot(nodes map[string]*node) map[string][]string {
+	res := make(map[string][]string, len(nodes))
+	for address, n := range nodes {
+		res[address] = n.mismatches
+	}
+	return res
+}
+
+// report reports beacon nodes whose consistency has changed.
+func report(previous map[string][]string, current map[string][]string) {
+	for address, mismatches := range current {
+		monitorConsistent(address, len(mismatches) == 0)
+		prior := previous[address]
+		switch {
+		case len(mismatches) > 0 && strings.Join(mismatches, "\n") != strings.Join(prior, "\n"):
+			log.Error().Str("address", address).Strs("mismatches", mismatches).Msg("Beacon node disagrees with the majority of beacon nodes about the network; excluding it")
+		case len(mismatches) == 0 && len(prior) > 0:
+			log.Info().Str("address", address).Msg("Beacon node agrees with the majority of beacon nodes about the network; no longer excluding it")
+		}
+	}
+}
+
+// get fetches the given path from a beacon node and decodes the JSON response.
+func (s *Service) get(ctx context.Context, endpoint string, path string, res interface{}) error {
+	return util.BeaconNodeGet(ctx, s.client, endpoint, path, res)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var consistentNodes *prometheus.GaugeVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if consistentNodes != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	consistentNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "consistency",
+		Name:      "consistent",
+		Help:      "Whether each beacon node agrees with the majority of beacon nodes about the network; 1 if it agrees, otherwise 0.",
+	}, []string{"address"})
+	return prometheus.Register(consistentNodes)
+}
+
+func monitorConsistent(address string, consistent bool) {
+	if consistentNodes == nil {
+		return
+	}
+	if consistent {
+		consistentNodes.WithLabelValues(address).Set(1)
+	} else {
+		consistentNodes.WithLabelValues(address).Set(0)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+type node struct {
+	// values are the network values reported by the beacon node, or nil if they have yet to be obtained.
+	values map[string]string
+	// mismatches describe the values on which the beacon node disagrees with the majority.
+	mismatches []string
+}
+
+// Service checks that beacon nodes agree on the network they are following.
+type Service struct {
+	timeout time.Duration
+	client  *http.Client
+
+	nodesMu       sync.RWMutex
+	endpoints     map[string]string
+	mainAddresses []string
+	nodes         map[string]*node
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new consistency service.
+// It returns an error if any of the main beacon nodes disagrees with the majority of beacon nodes.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "consistency").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		timeout:       parameters.timeout,
+		client:        util.NewBeaconNodeHTTPClient(parameters.timeout),
+		endpoints:     parameters.endpoints,
+		mainAddresses: parameters.mainAddresses,
+		nodes:         make(map[string]*node),
+	}
+	for _, address := range parameters.addresses {
+		s.nodes[address] = &node{}
+	}
+	for _, address := range parameters.mainAddresses {
+		if _, exists := s.nodes[address]; !exists {
+			s.nodes[address] = &node{}
+		}
+	}
+
+	// Carry out an initial check before returning, so that Vouch does not start against the wrong network.
+	s.check(ctx, nil)
+	s.nodesMu.RLock()
+	err = mainConsistent(s.nodes, s.mainAddresses)
+	s.nodesMu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	interval := parameters.interval
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(interval), nil
+	}
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"Consistency",
+		"Check beacon node consistency",
+		runtimeFunc,
+		nil,
+		s.check,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to schedule periodic consistency check")
+	}
+
+	return s, nil
+}
+
+// Consistent returns true if the beacon node with the given address agrees with the majority of beacon nodes.
+// Beacon nodes that have yet to be checked are considered consistent.
+// Beacon nodes not previously seen by the service are added to those it checks.
+func (s *Service) Consistent(address string) bool {
+	s.nodesMu.Lock()
+	defer s.nodesMu.Unlock()
+	return s.consistent(address)
+}
+
+// Filter returns the addresses of the beacon nodes that agree with the majority of beacon nodes, in the order supplied.
+// Beacon nodes that disagree are never returned, even if that leaves none.
+// Beacon nodes not previously seen by the service are added to those it checks.
+func (s *Service) Filter(addresses []string) []string {
+	res := make([]string, 0, len(addresses))
+	s.nodesMu.Lock()
+	for _, address := range addresses {
+		if s.consistent(address) {
+			res = append(res, address)
+		}
+	}
+	s.nodesMu.Unlock()
+
+	return res
+}
+
+// consistent returns true if the beacon node with the given address agrees with the majority of beacon nodes.
+// The caller must hold the nodes lock for writing.
+func (s *Service) consistent(address string) bool {
+	n, exists := s.nodes[address]
+	if !exists {
+		s.nodes[address] = &node{}
+		return true
+	}
+	return len(n.mismatches) == 0
+}
+
+// SetAddresses sets the addresses of the beacon nodes to check, those used for Vouch's main
+// connection, and the endpoints through which they are reached.
+// Beacon nodes that are added, or have yet to be checked, are checked immediately.  If any of the main beacon nodes disagrees
+// with the majority of beacon nodes an error is returned and the existing addresses are retained.
+func (s *Service) SetAddresses(ctx context.Context, addresses []string, mainAddresses []string, endpoints map[string]string) error {
+	s.nodesMu.RLock()
+	previous := snapshot(s.nodes)
+	nodes := make(map[string]*node, len(addresses))
+	// unchecked are beacon nodes that are new, or whose values have yet to be obtained.
+	unchecked := make([]string, 0)
+	for _, address := range append(append([]string{}, addresses...), mainAddresses...) {
+		if _, exists := nodes[address]; exists {
+			continue
+		}
+		n := &node{}
+		if existing, exists := s.nodes[address]; exists {
+			n.values = existing.values
+		}
+		if n.values == nil {
+			unchecked = append(unchecked, address)
+		}
+		nodes[address] = n
+	}
+	s.nodesMu.RUnlock()
+
+	for address, values := range s.fetch(ctx, unchecked, endpoints) {
+		nodes[address].values = values
+	}
+	assess(nodes, mainAddresses)
+	if err := mainConsistent(nodes, mainAddresses); err != nil {
+		return err
+	}
+
+	current := snapshot(nodes)
+	s.nodesMu.Lock()
+	s.nodes = nodes
+	s.mainAddresses = mainAddresses
+	s.endpoints = endpoints
+	s.nodesMu.Unlock()
+
+	report(previous, current)
+
+	return nil
+}
+
+// mainConsistent returns an error if any of the main beacon nodes disagrees with the majority.
+func mainConsistent(nodes map[string]*node, mainAddresses []string) error {
+	for _, address := range mainAddresses {
+		n, exists := nodes[address]
+		if !exists || len(n.mismatches) == 0 {
+			continue
+		}
+		return fmt.Errorf("main beacon node %s disagrees with the majority of beacon nodes: %s", address, strings.Join(n.mismatches, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/consistency/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mainnetRoot = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	praterRoot  = "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"
+)
+
+// nodeServer provides a beacon node API for a network with the given genesis validators root and bellatrix fork epoch.
+// An empty bellatrix fork epoch is a beacon node that is unaware of bellatrix.
+func nodeServer(genesisValidatorsRoot string, bellatrixForkEpoch string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"genesis_time":"1606824023","genesis_validators_root":"%s","genesis_fork_version":"0x00000000"}}`, genesisValidatorsRoot)))
+		case "/eth/v1/config/fork_schedule":
+			if bellatrixForkEpoch == "" {
+				_, _ = w.Write([]byte(`{"data":[{"previous_version":"0x00000000","current_version":"0x00000000","epoch":"0"},{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"74240"}]}`))
+			} else {
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"data":[{"previous_version":"0x00000000","current_version":"0x00000000","epoch":"0"},{"previous_version":"0x00000000","current_version":"0x01000000","epoch":"74240"},{"previous_version":"0x01000000","current_version":"0x02000000","epoch":"%s"}]}`, bellatrixForkEpoch)))
+			}
+		case "/eth/v1/config/spec":
+			if bellatrixForkEpoch == "" {
+				_, _ = w.Write([]byte(`{"data":{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32","ALTAIR_FORK_EPOCH":"74240"}}`))
+			} else {
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32","ALTAIR_FORK_EPOCH":"74240","BELLATRIX_FORK_EPOCH":"%s"}}`, bellatrixForkEpoch)))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "TimeoutMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "IntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+				standard.WithInterval(0),
+			},
+			err: "problem with parameters: interval must be positive",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConsistent(t *testing.T) {
+	ctx := context.Background()
+
+	main := nodeServer(mainnetRoot, "144896")
+	defer main.Close()
+	agrees := nodeServer(mainnetRoot, "144896")
+	defer agrees.Close()
+	wrongNetwork := nodeServer(praterRoot, "144896")
+	defer wrongNetwork.Close()
+	staleFork := nodeServer(mainnetRoot, "150000")
+	defer staleFork.Close()
+	preBellatrix := nodeServer(mainnetRoot, "")
+	defer preBellatrix.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithTimeout(time.Second),
+		standard.WithMainAddresses([]string{main.URL}),
+		standard.WithAddresses([]string{agrees.URL, wrongNetwork.URL, staleFork.URL, preBellatrix.URL, "http://127.0.0.1:1"}),
+	)
+	require.NoError(t, err)
+
+	require.True(t, s.Consistent(main.URL))
+	require.True(t, s.Consistent(agrees.URL))
+	require.False(t, s.Consistent(wrongNetwork.URL))
+	require.False(t, s.Consistent(staleFork.URL))
+	// Forks of which a beacon node is unaware are not compared.
+	require.True(t, s.Consistent(preBellatrix.URL))
+	// Unreachable node is undetermined, so considered consistent.
+	require.True(t, s.Consistent("http://127.0.0.1:1"))
+	// Unknown node is considered consistent.
+	require.True(t, s.Consistent("unknown"))
+
+	require.Equal(t, []string{main.URL, agrees.URL}, s.Filter([]string{main.URL, wrongNetwork.URL, agrees.URL}))
+	// Inconsistent nodes are never returned, even if that leaves none.
+	require.Empty(t, s.Filter([]string{wrongNetwork.URL, staleFork.URL}))
+}
+
+func TestMainInconsistent(t *testing.T) {
+	ctx := context.Background()
+
+	main := nodeServer(praterRoot, "112260")
+	defer main.Close()
+	node1 := nodeServer(mainnetRoot, "144896")
+	defer node1.Close()
+	node2 := nodeServer(mainnetRoot, "144896")
+	defer node2.Close()
+
+	_, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithTimeout(time.Second),
+		standard.WithMainAddresses([]string{main.URL}),
+		standard.WithAddresses([]string{node1.URL, node2.URL}),
+	)
+	require.EqualError(t, err, fmt.Sprintf("main beacon node %s disagrees with the majority of beacon nodes: BELLATRIX_FORK_EPOCH is 112260 rather than 144896; fork 0x02000000 epoch is 112260 rather than 144896; genesis_validators_root is %s rather than %s", main.URL, praterRoot, mainnetRoot))
+}
+
+func TestTieFavoursMain(t *testing.T) {
+	ctx := context.Background()
+
+	main := nodeServer(praterRoot, "112260")
+	defer main.Close()
+	other := nodeServer(mainnetRoot, "144896")
+	defer other.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithTimeout(time.Second),
+		standard.WithMainAddresses([]string{main.URL}),
+		standard.WithAddresses([]string{other.URL}),
+	)
+	require.NoError(t, err)
+	require.True(t, s.Consistent(main.URL))
+	require.False(t, s.Consistent(other.URL))
+}
+
+func TestSetAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	main := nodeServer(mainnetRoot, "144896")
+	defer main.Close()
+	wrongNetwork := nodeServer(praterRoot, "112260")
+	defer wrongNetwork.Close()
+	node1 := nodeServer(praterRoot, "112260")
+	defer node1.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithTimeout(time.Second),
+		standard.WithMainAddresses([]string{main.URL}),
+		standard.WithAddresses([]string{main.URL, main.URL + "/"}),
+	)
+	require.NoError(t, err)
+	require.True(t, s.Consistent(wrongNetwork.URL))
+
+	// Added node is checked immediately.
+	require.NoError(t, s.SetAddresses(ctx, []string{main.URL, main.URL + "/", wrongNetwork.URL}, []string{main.URL}, nil))
+	require.False(t, s.Consistent(wrongNetwork.URL))
+
+	// A change that leaves the main node in the minority is rejected.
+	require.Error(t, s.SetAddresses(ctx, []string{main.URL, wrongNetwork.URL, node1.URL}, []string{main.URL}, nil))
+	require.False(t, s.Consistent(wrongNetwork.URL))
+
+	// Removed node is forgotten.
+	require.NoError(t, s.SetAddresses(ctx, []string{main.URL}, []string{main.URL}, nil))
+	require.True(t, s.Consistent(wrongNetwork.URL))
+}
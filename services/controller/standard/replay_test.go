@@ -0,0 +1,305 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	mockaccountmanager "github.com/attestantio/vouch/services/accountmanager/mock"
+	mockattestationaggregator "github.com/attestantio/vouch/services/attestationaggregator/mock"
+	standardattester "github.com/attestantio/vouch/services/attester/standard"
+	mockbeaconblockproposer "github.com/attestantio/vouch/services/beaconblockproposer/mock"
+	mockbeaconcommitteesubscriber "github.com/attestantio/vouch/services/beaconcommitteesubscriber/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/controller/standard"
+	"github.com/attestantio/vouch/services/eth2client/recording"
+	"github.com/attestantio/vouch/services/eth2client/replay"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	mockproposalpreparer "github.com/attestantio/vouch/services/proposalpreparer/mock"
+	advancedscheduler "github.com/attestantio/vouch/services/scheduler/advanced"
+	standardsigner "github.com/attestantio/vouch/services/signer/standard"
+	mocksynccommitteeaggregator "github.com/attestantio/vouch/services/synccommitteeaggregator/mock"
+	mocksynccommitteemessenger "github.com/attestantio/vouch/services/synccommitteemessenger/mock"
+	mocksynccommitteesubscriber "github.com/attestantio/vouch/services/synccommitteesubscriber/mock"
+	firstattestationdatastrategy "github.com/attestantio/vouch/strategies/attestationdata/first"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// replaySlot is the slot at which the validator attests.
+// It is in the second epoch, so that the attestation data has a valid source.
+const replaySlot = phase0.Slot(37)
+
+// beaconNode is a beacon node providing the interactions needed for an attestation.
+type beaconNode struct {
+	eth2client.SpecProvider
+	eth2client.AttestationDataProvider
+	eth2client.DomainProvider
+
+	pubKey  phase0.BLSPubKey
+	handler eth2client.EventHandlerFunc
+}
+
+func (*beaconNode) Name() string {
+	return "mock"
+}
+
+func (*beaconNode) Address() string {
+	return "localhost:5052"
+}
+
+func (n *beaconNode) AttesterDuties(_ context.Context, epoch phase0.Epoch, _ []phase0.ValidatorIndex) ([]*apiv1.AttesterDuty, error) {
+	if epoch != phase0.Epoch(uint64(replaySlot)/32) {
+		return []*apiv1.AttesterDuty{}, nil
+	}
+	return []*apiv1.AttesterDuty{
+		{
+			PubKey:                  n.pubKey,
+			Slot:                    replaySlot,
+			ValidatorIndex:          1,
+			CommitteeIndex:          2,
+			CommitteeLength:         128,
+			CommitteesAtSlot:        4,
+			ValidatorCommitteeIndex: 3,
+		},
+	}, nil
+}
+
+func (n *beaconNode) Events(_ context.Context, _ []string, handler eth2client.EventHandlerFunc) error {
+	n.handler = handler
+	return nil
+}
+
+func (*beaconNode) SubmitAttestations(_ context.Context, _ []*phase0.Attestation) error {
+	return nil
+}
+
+// validatingAccountsProvider provides a single validating account.
+type validatingAccountsProvider struct {
+	account e2wtypes.Account
+}
+
+func (p *validatingAccountsProvider) ValidatingAccountsForEpoch(_ context.Context, _ phase0.Epoch) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	return map[phase0.ValidatorIndex]e2wtypes.Account{1: p.account}, nil
+}
+
+func (p *validatingAccountsProvider) ValidatingAccountsForEpochByIndex(_ context.Context, _ phase0.Epoch, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]e2wtypes.Account, error) {
+	res := make(map[phase0.ValidatorIndex]e2wtypes.Account)
+	for _, index := range indices {
+		if index == 1 {
+			res[index] = p.account
+		}
+	}
+	return res, nil
+}
+
+// attestationsSubmitter passes attestations to the beacon node, noting what was submitted.
+type attestationsSubmitter struct {
+	client       eth2client.AttestationsSubmitter
+	attestations chan []*phase0.Attestation
+	errs         chan error
+}
+
+func (s *attestationsSubmitter) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	err := s.client.SubmitAttestations(ctx, attestations)
+	s.attestations <- attestations
+	s.errs <- err
+	return err
+}
+
+// replayClient is the set of providers used by the services under test.
+type replayClient interface {
+	eth2client.SpecProvider
+	eth2client.AttesterDutiesProvider
+	eth2client.AttestationDataProvider
+	eth2client.DomainProvider
+	eth2client.EventsProvider
+	eth2client.AttestationsSubmitter
+}
+
+// attestSlot runs the controller, attester, strategy and signer against the client until the
+// validator has attested, with the head event for the attestation slot sent by sendHead.
+func attestSlot(t *testing.T,
+	client replayClient,
+	account e2wtypes.Account,
+	sendHead func(),
+) (
+	[]*phase0.Attestation,
+	error,
+) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Start shortly before the attestation slot, so that the attestation is for the same slot each run.
+	slotDuration := 12 * time.Second
+	genesisTime := time.Now().Add(500*time.Millisecond - time.Duration(replaySlot)*slotDuration)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(slotDuration)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	scheduler, err := advancedscheduler.New(ctx,
+		advancedscheduler.WithLogLevel(zerolog.Disabled),
+	)
+	require.NoError(t, err)
+
+	attestationDataProvider, err := firstattestationdatastrategy.New(ctx,
+		firstattestationdatastrategy.WithLogLevel(zerolog.Disabled),
+		firstattestationdatastrategy.WithTimeout(2*time.Second),
+		firstattestationdatastrategy.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+			"replay": client,
+		}),
+	)
+	require.NoError(t, err)
+
+	signer, err := standardsigner.New(ctx,
+		standardsigner.WithLogLevel(zerolog.Disabled),
+		standardsigner.WithMonitor(nullmetrics.New(ctx)),
+		standardsigner.WithClientMonitor(nullmetrics.New(ctx)),
+		standardsigner.WithSpecProvider(client),
+		standardsigner.WithDomainProvider(client),
+	)
+	require.NoError(t, err)
+
+	accountsProvider := &validatingAccountsProvider{account: account}
+	submitter := &attestationsSubmitter{
+		client:       client,
+		attestations: make(chan []*phase0.Attestation, 1),
+		errs:         make(chan error, 1),
+	}
+	attester, err := standardattester.New(ctx,
+		standardattester.WithLogLevel(zerolog.Disabled),
+		standardattester.WithMonitor(nullmetrics.New(ctx)),
+		standardattester.WithProcessConcurrency(1),
+		standardattester.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+		standardattester.WithAttestationDataProvider(attestationDataProvider),
+		standardattester.WithAttestationsSubmitter(submitter),
+		standardattester.WithValidatingAccountsProvider(accountsProvider),
+		standardattester.WithBeaconAttestationsSigner(signer),
+	)
+	require.NoError(t, err)
+
+	_, err = standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithMonitor(nullmetrics.New(ctx)),
+		standard.WithSpecProvider(client),
+		standard.WithForkScheduleProvider(mock.NewForkScheduleProvider()),
+		standard.WithChainTimeService(chainTime),
+		standard.WithProposerDutiesProvider(mock.NewProposerDutiesProvider()),
+		standard.WithAttesterDutiesProvider(client),
+		standard.WithSyncCommitteeDutiesProvider(mock.NewSyncCommitteeDutiesProvider()),
+		standard.WithEventsProvider(client),
+		standard.WithValidatingAccountsProvider(accountsProvider),
+		standard.WithProposalsPreparer(mockproposalpreparer.New()),
+		standard.WithScheduler(scheduler),
+		standard.WithAttester(attester),
+		standard.WithSyncCommitteeMessenger(mocksynccommitteemessenger.New()),
+		standard.WithSyncCommitteeAggregator(mocksynccommitteeaggregator.New()),
+		standard.WithSyncCommitteeSubscriber(mocksynccommitteesubscriber.New()),
+		standard.WithBeaconBlockProposer(mockbeaconblockproposer.New()),
+		standard.WithBeaconCommitteeSubscriber(mockbeaconcommitteesubscriber.New()),
+		standard.WithAttestationAggregator(mockattestationaggregator.New()),
+		standard.WithAccountsRefresher(mockaccountmanager.NewRefresher()),
+		standard.WithBeaconBlockHeadersProvider(mock.NewBeaconBlockHeadersProvider()),
+		standard.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		standard.WithMaxAttestationDelay(4*time.Second),
+		standard.WithMaxProposalDelay(4*time.Second),
+		standard.WithMaxSyncCommitteeMessageDelay(4*time.Second),
+		standard.WithAttestationAggregationDelay(8*time.Second),
+		standard.WithSyncCommitteeAggregationDelay(8*time.Second),
+	)
+	require.NoError(t, err)
+
+	// Send the head event once the attestation slot has started, to kick off the attestation.
+	time.Sleep(time.Until(chainTime.StartOfSlot(replaySlot)))
+	sendHead()
+
+	select {
+	case attestations := <-submitter.attestations:
+		return attestations, <-submitter.errs
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for attestations")
+	}
+
+	return nil, nil
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	require.NoError(t, e2types.InitBLS())
+
+	wallet, err := hd.CreateWallet(ctx, "test wallet", []byte("pass"), scratch.New(), keystorev4.New(), make([]byte, 64))
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("pass")))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "test account", []byte("pass"))
+	require.NoError(t, err)
+	require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("pass")))
+
+	node := &beaconNode{
+		SpecProvider:            mock.NewSpecProvider(),
+		AttestationDataProvider: mock.NewAttestationDataProvider(),
+		DomainProvider:          mock.NewDomainProvider(),
+	}
+	copy(node.pubKey[:], account.(e2wtypes.AccountPublicKeyProvider).PublicKey().Marshal())
+	head := &apiv1.Event{
+		Topic: "head",
+		Data: &apiv1.HeadEvent{
+			Slot:  replaySlot,
+			Block: phase0.Root{0x01},
+			State: phase0.Root{0x02},
+		},
+	}
+
+	// Record the beacon node's interactions for the attestation.
+	path := filepath.Join(t.TempDir(), "recording.json")
+	recordingCtx, recordingCancel := context.WithCancel(ctx)
+	defer recordingCancel()
+	recorder, err := recording.New(recordingCtx,
+		recording.WithLogLevel(zerolog.Disabled),
+		recording.WithClient(node),
+		recording.WithPath(path),
+	)
+	require.NoError(t, err)
+	recorded, err := attestSlot(t, recorder, account, func() { node.handler(head) })
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	recordingCancel()
+
+	// Replay the recording; the attestation should be the same as that recorded, and be accepted
+	// by the replay client as the attestation it recorded being submitted.
+	replayer, err := replay.New(ctx,
+		replay.WithLogLevel(zerolog.Disabled),
+		replay.WithPath(path),
+	)
+	require.NoError(t, err)
+	replayed, err := attestSlot(t, replayer, account, func() { require.NoError(t, replayer.ReplayEvents()) })
+	require.NoError(t, err)
+	require.Equal(t, recorded, replayed)
+}
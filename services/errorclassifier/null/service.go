@@ -0,0 +1,47 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package null is an error classifier that classifies errors without reference to the client or operation.
+package null
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/errorclassifier"
+)
+
+// Service is an error classifier that classifies errors without reference to the client or operation.
+type Service struct{}
+
+// New creates a new null error classifier.
+func New(_ context.Context) *Service {
+	return &Service{}
+}
+
+// Classify classifies an error returned by the provider for the given operation.
+func (*Service) Classify(_ context.Context, _ interface{}, _ string, err error) errorclassifier.Class {
+	return errorclassifier.ClassOf(err)
+}
+
+// Wrap classifies an error returned by the provider for the given operation, returning
+// an error that carries its class.
+func (s *Service) Wrap(ctx context.Context, provider interface{}, operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &errorclassifier.Error{
+		Class: s.Classify(ctx, provider, operation, err),
+		Err:   err,
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package null_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/vouch/services/errorclassifier"
+	nullerrorclassifier "github.com/attestantio/vouch/services/errorclassifier/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	s := nullerrorclassifier.New(ctx)
+	require.Equal(t, errorclassifier.Class(""), s.Classify(ctx, nil, "attestation", nil))
+	require.Equal(t, errorclassifier.ClassFatal, s.Classify(ctx, nil, "attestation", errors.New("POST failed with status 400: PriorAttestationKnown")))
+	require.Equal(t, errorclassifier.ClassRetryable, s.Classify(ctx, nil, "attestation", errors.New("connection reset")))
+
+	require.NoError(t, s.Wrap(ctx, nil, "attestation", nil))
+	require.Equal(t, errorclassifier.ClassRetryable, errorclassifier.ClassOf(s.Wrap(ctx, nil, "attestation", errors.New("connection reset"))))
+}
@@ -0,0 +1,110 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorclassifier
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// Class is the class of an error.
+type Class string
+
+const (
+	// ClassBenignDuplicate is an error returned because the beacon node already has the message.
+	ClassBenignDuplicate Class = "benign-duplicate"
+	// ClassNodeBehind is an error returned because the beacon node is not up to date with the chain.
+	ClassNodeBehind Class = "node-behind"
+	// ClassRetryable is a transient error that may succeed if retried.
+	ClassRetryable Class = "retryable"
+	// ClassFatal is an error that will not succeed if retried.
+	ClassFatal Class = "fatal"
+)
+
+// Benign returns true if the error means that the beacon node already has the message.
+func (c Class) Benign() bool {
+	return c == ClassBenignDuplicate
+}
+
+// Retryable returns true if the request may succeed if retried.
+func (c Class) Retryable() bool {
+	return c == ClassRetryable || c == ClassNodeBehind
+}
+
+// Rule is a rule for classifying errors.
+type Rule struct {
+	// Client is the type of client to which the rule applies, for example "lighthouse".
+	// If this is empty the rule applies to all clients.
+	Client string `mapstructure:"client"`
+	// Operation is the operation to which the rule applies, for example "attestation".
+	// If this is empty the rule applies to all operations.
+	Operation string `mapstructure:"operation"`
+	// Pattern is the regular expression that the error message must match.
+	Pattern string `mapstructure:"pattern"`
+	// Class is the class of matching errors.
+	Class Class `mapstructure:"class"`
+}
+
+// Error is an error that has been classified.
+type Error struct {
+	Class Class
+	Err   error
+}
+
+// Error returns the message of the underlying error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Service is the error classification service.
+type Service interface {
+	// Classify classifies an error returned by the provider for the given operation.
+	Classify(ctx context.Context, provider interface{}, operation string, err error) Class
+
+	// Wrap classifies an error returned by the provider for the given operation, returning
+	// an error that carries its class.
+	Wrap(ctx context.Context, provider interface{}, operation string, err error) error
+}
+
+// clientErrorRe matches errors returned by beacon nodes that relate to the request itself.
+var clientErrorRe = regexp.MustCompile(`failed with status 4\d\d`)
+
+// ClassOf returns the class of an error.  If the error has not been classified it
+// is classified without reference to the client or operation.
+func ClassOf(err error) Class {
+	if err == nil {
+		return ""
+	}
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Class
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ClassFatal
+	}
+	if clientErrorRe.MatchString(err.Error()) {
+		// The beacon node rejected the request itself.
+		return ClassFatal
+	}
+
+	// Anything else, such as a server error or connection reset, may succeed if retried.
+	return ClassRetryable
+}
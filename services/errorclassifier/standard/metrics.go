@@ -0,0 +1,59 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var classifiedErrors *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if classifiedErrors != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	classifiedErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "errorclassifier",
+		Name:      "errors_total",
+		Help:      "The number of errors returned by beacon nodes, by class.",
+	}, []string{"client", "operation", "class"})
+	return prometheus.Register(classifiedErrors)
+}
+
+func monitorError(client string, operation string, class errorclassifier.Class) {
+	if classifiedErrors == nil {
+		return
+	}
+	if client == "" {
+		client = "unknown"
+	}
+	classifiedErrors.WithLabelValues(client, operation, string(class)).Inc()
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standard classifies errors returned by beacon nodes, using
+// declarative rules that match on the client type, the operation and the
+// error message, so that callers can decide how to handle them.
+package standard
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel zerolog.Level
+	monitor  metrics.Service
+	rules    []*errorclassifier.Rule
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithRules sets additional rules for classification.
+// These rules are checked before the built-in rules, so can override them.
+func WithRules(rules []*errorclassifier.Rule) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.rules = rules
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(context.Background()),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	for i, rule := range parameters.rules {
+		if rule == nil {
+			return nil, fmt.Errorf("rule %d not specified", i)
+		}
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("no pattern specified for rule %d", i)
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern for rule %d", i)
+		}
+		if !validClass(rule.Class) {
+			return nil, fmt.Errorf("invalid class %q for rule %d", rule.Class, i)
+		}
+	}
+
+	return &parameters, nil
+}
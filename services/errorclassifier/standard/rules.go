@@ -0,0 +1,119 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"regexp"
+)
+
+// clientTypes are the known types of client, as found in their node versions.
+var clientTypes = []string{
+	"lighthouse",
+	"lodestar",
+	"nimbus",
+	"prysm",
+	"teku",
+}
+
+// builtinRules are the rules used to classify errors in the absence of overrides.
+var builtinRules = []*rule{
+	// Lighthouse rejects duplicate attestations.  It is possible that an attestation we sent
+	// to another node already propagated to this node.
+	{
+		client:    "lighthouse",
+		operation: "attestation",
+		pattern:   regexp.MustCompile(`PriorAttestationKnown`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	// Lighthouse rejects an attestation for a block that is not its current head.
+	{
+		client:    "lighthouse",
+		operation: "attestation",
+		pattern:   regexp.MustCompile(`UnknownHeadBlock`),
+		class:     errorclassifier.ClassNodeBehind,
+	},
+	{
+		client:    "lighthouse",
+		operation: "synccommitteemessage",
+		pattern:   regexp.MustCompile(`^Verification: PriorSyncCommitteeMessageKnown`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "lighthouse",
+		operation: "synccommitteecontribution",
+		pattern:   regexp.MustCompile(`^Verification: AggregatorAlreadyKnown`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "teku",
+		operation: "synccommitteemessage",
+		pattern:   regexp.MustCompile(`^Ignoring sync committee message as a duplicate was processed during validation$`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	// Lodestar returns error codes ending in ALREADY_KNOWN for duplicate messages.
+	{
+		client:  "lodestar",
+		pattern: regexp.MustCompile(`ALREADY_KNOWN`),
+		class:   errorclassifier.ClassBenignDuplicate,
+	},
+	// All clients return a message containing "syncing" when they are not synced.
+	{
+		pattern: regexp.MustCompile(`(?i)(currently syncing|not synced|is syncing)`),
+		class:   errorclassifier.ClassNodeBehind,
+	},
+	// Other clients report duplicate attestations and aggregates with their own messages.
+	{
+		client:    "lighthouse",
+		operation: "aggregateattestation",
+		pattern:   regexp.MustCompile(`(AttestationSupersetKnown|AggregatorAlreadyKnown)`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "prysm",
+		operation: "attestation",
+		pattern:   regexp.MustCompile(`(?i)already seen`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "prysm",
+		operation: "aggregateattestation",
+		pattern:   regexp.MustCompile(`(?i)already seen`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "teku",
+		operation: "attestation",
+		pattern:   regexp.MustCompile(`(?i)duplicate`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "teku",
+		operation: "aggregateattestation",
+		pattern:   regexp.MustCompile(`(?i)duplicate`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "nimbus",
+		operation: "attestation",
+		pattern:   regexp.MustCompile(`(?i)already (seen|known)`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+	{
+		client:    "nimbus",
+		operation: "aggregateattestation",
+		pattern:   regexp.MustCompile(`(?i)already (seen|known|covered)`),
+		class:     errorclassifier.ClassBenignDuplicate,
+	},
+}
@@ -0,0 +1,198 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// severities orders the classes, used when combining multiple classes.
+var severities = map[errorclassifier.Class]int{
+	errorclassifier.ClassBenignDuplicate: 1,
+	errorclassifier.ClassNodeBehind:      2,
+	errorclassifier.ClassRetryable:       3,
+	errorclassifier.ClassFatal:           4,
+}
+
+// validClass returns true if the class is known.
+func validClass(class errorclassifier.Class) bool {
+	_, exists := severities[class]
+	return exists
+}
+
+type rule struct {
+	client    string
+	operation string
+	pattern   *regexp.Regexp
+	class     errorclassifier.Class
+}
+
+// Service classifies errors.
+type Service struct {
+	rules []*rule
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new error classification service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "errorclassifier").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	rules := make([]*rule, 0, len(parameters.rules)+len(builtinRules))
+	for _, r := range parameters.rules {
+		rules = append(rules, &rule{
+			client:    strings.ToLower(r.Client),
+			operation: r.Operation,
+			// Pattern has already been checked in parameters.
+			pattern: regexp.MustCompile(r.Pattern),
+			class:   r.Class,
+		})
+	}
+	rules = append(rules, builtinRules...)
+
+	return &Service{
+		rules: rules,
+	}, nil
+}
+
+// Classify classifies an error returned by the provider for the given operation.
+func (s *Service) Classify(ctx context.Context, provider interface{}, operation string, err error) errorclassifier.Class {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return errorclassifier.ClassFatal
+	}
+
+	client := ClientType(ctx, provider)
+
+	// If the beacon node reported individual failures then each is classified, and
+	// the error takes the most severe class.
+	var class errorclassifier.Class
+	for _, msg := range failureMessages(err) {
+		msgClass := classify(s.rules, client, operation, msg)
+		if msgClass == "" {
+			msgClass = errorclassifier.ClassOf(err)
+		}
+		if severities[msgClass] > severities[class] {
+			class = msgClass
+		}
+	}
+
+	monitorError(client, operation, class)
+	log.Trace().Str("client", client).Str("operation", operation).Str("class", string(class)).Err(err).Msg("Classified error")
+
+	return class
+}
+
+// Wrap classifies an error returned by the provider for the given operation, returning
+// an error that carries its class.
+func (s *Service) Wrap(ctx context.Context, provider interface{}, operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &errorclassifier.Error{
+		Class: s.Classify(ctx, provider, operation, err),
+		Err:   err,
+	}
+}
+
+// ClientType returns the type of client of the provider, or an empty string if unknown.
+func ClientType(ctx context.Context, provider interface{}) string {
+	nodeVersionProvider, isProvider := provider.(eth2client.NodeVersionProvider)
+	if !isProvider {
+		return ""
+	}
+	nodeVersion, err := nodeVersionProvider.NodeVersion(ctx)
+	if err != nil {
+		return ""
+	}
+	nodeVersion = strings.ToLower(nodeVersion)
+	for _, clientType := range clientTypes {
+		if strings.Contains(nodeVersion, clientType) {
+			return clientType
+		}
+	}
+
+	return ""
+}
+
+// classify returns the class of the first rule to match the message, or an empty string if no rules match.
+func classify(rules []*rule, client string, operation string, msg string) errorclassifier.Class {
+	for _, r := range rules {
+		if r.client != "" && r.client != client {
+			continue
+		}
+		if r.operation != "" && r.operation != operation {
+			continue
+		}
+		if r.pattern.MatchString(msg) {
+			return r.class
+		}
+	}
+
+	return ""
+}
+
+type failuresResponse struct {
+	Failures []*failure `json:"failures"`
+}
+
+type failure struct {
+	Message string `json:"message"`
+}
+
+// failureMessages returns the individual failure messages in the error, if present,
+// or else the error message itself.
+func failureMessages(err error) []string {
+	errorStr := err.Error()
+	jsonIndex := strings.Index(errorStr, "{")
+	if jsonIndex == -1 {
+		return []string{errorStr}
+	}
+	resp := failuresResponse{}
+	if jsonErr := json.Unmarshal([]byte(errorStr[jsonIndex:]), &resp); jsonErr != nil || len(resp.Failures) == 0 {
+		return []string{errorStr}
+	}
+
+	msgs := make([]string, len(resp.Failures))
+	for i := range resp.Failures {
+		msgs[i] = resp.Failures[i].Message
+	}
+	return msgs
+}
@@ -0,0 +1,306 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"github.com/attestantio/vouch/services/errorclassifier/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type nodeVersionProvider struct {
+	nodeVersion string
+}
+
+func (p *nodeVersionProvider) NodeVersion(_ context.Context) (string, error) {
+	return p.nodeVersion, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "RuleNil",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithRules([]*errorclassifier.Rule{nil}),
+			},
+			err: "problem with parameters: rule 0 not specified",
+		},
+		{
+			name: "RulePatternMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithRules([]*errorclassifier.Rule{
+					{
+						Class: errorclassifier.ClassFatal,
+					},
+				}),
+			},
+			err: "problem with parameters: no pattern specified for rule 0",
+		},
+		{
+			name: "RulePatternInvalid",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithRules([]*errorclassifier.Rule{
+					{
+						Pattern: "(",
+						Class:   errorclassifier.ClassFatal,
+					},
+				}),
+			},
+			err: "problem with parameters: invalid pattern for rule 0",
+		},
+		{
+			name: "RuleClassInvalid",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithRules([]*errorclassifier.Rule{
+					{
+						Pattern: "error",
+						Class:   "bad",
+					},
+				}),
+			},
+			err: `problem with parameters: invalid class "bad" for rule 0`,
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithRules([]*errorclassifier.Rule{
+					{
+						Client:  "prysm",
+						Pattern: "error",
+						Class:   errorclassifier.ClassFatal,
+					},
+				}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithRules([]*errorclassifier.Rule{
+			{
+				Client:    "prysm",
+				Operation: "attestation",
+				Pattern:   "Could not broadcast",
+				Class:     errorclassifier.ClassNodeBehind,
+			},
+			{
+				Client:  "teku",
+				Pattern: "PriorAttestationKnown",
+				Class:   errorclassifier.ClassFatal,
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	lighthouse := &nodeVersionProvider{nodeVersion: "Lighthouse/v2.3.1-564d7da/x86_64-linux"}
+	prysm := &nodeVersionProvider{nodeVersion: "Prysm/v2.1.2 (linux amd64)"}
+	teku := &nodeVersionProvider{nodeVersion: "teku/v22.6.0/linux-x86_64/-eclipseadoptium-openjdk64bitservervm-java-17"}
+
+	tests := []struct {
+		name      string
+		provider  interface{}
+		operation string
+		err       error
+		class     errorclassifier.Class
+	}{
+		{
+			name:      "Nil",
+			provider:  lighthouse,
+			operation: "attestation",
+		},
+		{
+			name:      "Cancelled",
+			provider:  lighthouse,
+			operation: "attestation",
+			err:       context.Canceled,
+			class:     errorclassifier.ClassFatal,
+		},
+		{
+			name:      "LighthousePriorAttestationKnown",
+			provider:  lighthouse,
+			operation: "attestation",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"BAD_REQUEST: Invalid attestation: PriorAttestationKnown"}`),
+			class:     errorclassifier.ClassBenignDuplicate,
+		},
+		{
+			name:      "LighthouseUnknownHeadBlock",
+			provider:  lighthouse,
+			operation: "attestation",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"BAD_REQUEST: Invalid attestation: UnknownHeadBlock"}`),
+			class:     errorclassifier.ClassNodeBehind,
+		},
+		{
+			name:      "LighthouseOtherOperation",
+			provider:  lighthouse,
+			operation: "aggregateattestation",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"BAD_REQUEST: Invalid attestation: UnknownHeadBlock"}`),
+			class:     errorclassifier.ClassFatal,
+		},
+		{
+			name:      "LighthouseSyncCommitteeMessagesAllKnown",
+			provider:  lighthouse,
+			operation: "synccommitteemessage",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"BAD_REQUEST: error","failures":[{"index":0,"message":"Verification: PriorSyncCommitteeMessageKnown"},{"index":1,"message":"Verification: PriorSyncCommitteeMessageKnown"}]}`),
+			class:     errorclassifier.ClassBenignDuplicate,
+		},
+		{
+			name:      "LighthouseSyncCommitteeMessagesSomeKnown",
+			provider:  lighthouse,
+			operation: "synccommitteemessage",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"BAD_REQUEST: error","failures":[{"index":0,"message":"Verification: PriorSyncCommitteeMessageKnown"},{"index":1,"message":"Verification: InvalidSignature"}]}`),
+			class:     errorclassifier.ClassFatal,
+		},
+		{
+			name:      "TekuSyncCommitteeMessagesKnown",
+			provider:  teku,
+			operation: "synccommitteemessage",
+			err:       errors.New(`POST failed with status 400: {"code":"400","message":"error","failures":[{"index":"0","message":"Ignoring sync committee message as a duplicate was processed during validation"}]}`),
+			class:     errorclassifier.ClassBenignDuplicate,
+		},
+		{
+			name:      "TekuAttestationDuplicate",
+			provider:  teku,
+			operation: "attestation",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"Ignoring attestation as a duplicate"}`),
+			class:     errorclassifier.ClassBenignDuplicate,
+		},
+		{
+			name:      "PrysmAggregateAlreadySeen",
+			provider:  prysm,
+			operation: "aggregateattestation",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"aggregate already seen"}`),
+			class:     errorclassifier.ClassBenignDuplicate,
+		},
+		{
+			name:      "DuplicateOtherOperation",
+			provider:  teku,
+			operation: "beaconblock",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"duplicate block"}`),
+			class:     errorclassifier.ClassFatal,
+		},
+		{
+			name:      "DuplicateUnknownClient",
+			provider:  nil,
+			operation: "attestation",
+			err:       errors.New(`POST failed with status 400: {"code":400,"message":"already known"}`),
+			class:     errorclassifier.ClassFatal,
+		},
+		{
+			name:      "Syncing",
+			provider:  prysm,
+			operation: "beaconblock",
+			err:       errors.New(`POST failed with status 503: {"code":503,"message":"Beacon node is currently syncing and not serving request on that endpoint"}`),
+			class:     errorclassifier.ClassNodeBehind,
+		},
+		{
+			name:      "ServerError",
+			provider:  prysm,
+			operation: "beaconblock",
+			err:       errors.New(`POST failed with status 500: {"code":500,"message":"internal error"}`),
+			class:     errorclassifier.ClassRetryable,
+		},
+		{
+			name:      "ConnectionError",
+			provider:  nil,
+			operation: "beaconblock",
+			err:       errors.New(`connection refused`),
+			class:     errorclassifier.ClassRetryable,
+		},
+		{
+			name:      "Override",
+			provider:  prysm,
+			operation: "attestation",
+			err:       errors.New(`POST failed with status 400: Could not broadcast attestation`),
+			class:     errorclassifier.ClassNodeBehind,
+		},
+		{
+			name:      "OverrideBuiltin",
+			provider:  teku,
+			operation: "attestation",
+			err:       errors.New(`POST failed with status 400: PriorAttestationKnown`),
+			class:     errorclassifier.ClassFatal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.class, s.Classify(ctx, test.provider, test.operation, test.err))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+	)
+	require.NoError(t, err)
+
+	lighthouse := &nodeVersionProvider{nodeVersion: "Lighthouse/v2.3.1-564d7da/x86_64-linux"}
+	require.NoError(t, s.Wrap(ctx, lighthouse, "attestation", nil))
+
+	baseErr := errors.New(`POST failed with status 400: PriorAttestationKnown`)
+	wrappedErr := s.Wrap(ctx, lighthouse, "attestation", baseErr)
+	require.EqualError(t, wrappedErr, baseErr.Error())
+	require.True(t, errors.Is(wrappedErr, baseErr))
+	require.Equal(t, errorclassifier.ClassBenignDuplicate, errorclassifier.ClassOf(wrappedErr))
+	require.Equal(t, errorclassifier.ClassBenignDuplicate, errorclassifier.ClassOf(fmt.Errorf("outer: %w", wrappedErr)))
+
+	// Unclassified errors.
+	require.Equal(t, errorclassifier.Class(""), errorclassifier.ClassOf(nil))
+	require.Equal(t, errorclassifier.ClassFatal, errorclassifier.ClassOf(baseErr))
+	require.Equal(t, errorclassifier.ClassRetryable, errorclassifier.ClassOf(errors.New("connection reset")))
+	require.Equal(t, errorclassifier.ClassFatal, errorclassifier.ClassOf(context.DeadlineExceeded))
+}
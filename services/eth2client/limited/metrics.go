@@ -0,0 +1,78 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limited
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var queueingDelays *prometheus.HistogramVec
+var rejections *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if queueingDelays != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	queueingDelays = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "eth2client_limited",
+		Name:      "queueing_delay_seconds",
+		Help:      "The time requests waited before being sent to the beacon node.",
+		Buckets: []float64{
+			0.0, 0.01, 0.02, 0.05, 0.1, 0.2, 0.5,
+			1.0, 2.0, 5.0,
+		},
+	}, []string{"address"})
+	if err := prometheus.Register(queueingDelays); err != nil {
+		return err
+	}
+
+	rejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "eth2client_limited",
+		Name:      "rejections_total",
+		Help:      "The number of requests rejected before being sent to the beacon node.",
+	}, []string{"address", "reason"})
+	return prometheus.Register(rejections)
+}
+
+func monitorQueueingDelay(address string, delay time.Duration) {
+	if queueingDelays == nil {
+		return
+	}
+	queueingDelays.WithLabelValues(address).Observe(delay.Seconds())
+}
+
+func monitorRejection(address string, reason string) {
+	if rejections == nil {
+		return
+	}
+	rejections.WithLabelValues(address, reason).Inc()
+}
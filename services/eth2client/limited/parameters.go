@@ -0,0 +1,137 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package limited wraps a beacon node client, limiting the number of
+// requests in flight and the rate at which requests are made to the
+// node, so that a single busy node is not overwhelmed by Vouch.
+package limited
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel    zerolog.Level
+	monitor     metrics.Service
+	client      eth2client.Service
+	maxInFlight int
+	rate        float64
+	burst       int
+	maxWait     time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithClient sets the client to which requests are passed.
+func WithClient(client eth2client.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.client = client
+	})
+}
+
+// WithMaxInFlight sets the maximum number of requests in flight to the client.
+// 0 means no limit.
+func WithMaxInFlight(maxInFlight int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxInFlight = maxInFlight
+	})
+}
+
+// WithRate sets the maximum sustained rate of requests to the client, in requests per second.
+// 0 means no limit.
+func WithRate(rate float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.rate = rate
+	})
+}
+
+// WithBurst sets the number of requests that can be made in a burst above the sustained rate.
+// If not supplied this defaults to 1.
+func WithBurst(burst int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.burst = burst
+	})
+}
+
+// WithMaxWait sets the maximum time that a request will wait for its turn before being rejected.
+// 0 means that requests wait until their context is done.
+func WithMaxWait(maxWait time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxWait = maxWait
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(context.Background()),
+		burst:    1,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.client == nil {
+		return nil, errors.New("no client specified")
+	}
+	if parameters.maxInFlight < 0 {
+		return nil, errors.New("max in flight cannot be negative")
+	}
+	if parameters.rate < 0 {
+		return nil, errors.New("rate cannot be negative")
+	}
+	if parameters.burst < 1 {
+		return nil, errors.New("burst must be at least 1")
+	}
+	if parameters.maxWait < 0 {
+		return nil, errors.New("max wait cannot be negative")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,488 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limited
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Name provides the name of the underlying client.
+func (s *Service) Name() string {
+	return s.client.Name()
+}
+
+// Address provides the address of the underlying client.
+func (s *Service) Address() string {
+	return s.client.Address()
+}
+
+// Events feeds requested events with the given topics to the supplied handler.
+// Event streams are long-lived, so are not subject to limits.
+func (s *Service) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	provider, isProvider := s.client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("client is not an events provider")
+	}
+
+	return provider.Events(ctx, topics, handler)
+}
+
+// AggregateAttestation fetches the aggregate attestation given an attestation.
+func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root) (*phase0.Attestation, error) {
+	provider, isProvider := s.client.(eth2client.AggregateAttestationProvider)
+	if !isProvider {
+		return nil, errors.New("client is not an aggregate attestation provider")
+	}
+	release, err := s.acquire(ctx, "aggregate attestation")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.AggregateAttestation(ctx, slot, attestationDataRoot)
+}
+
+// AttestationData fetches the attestation data for the given slot and committee index.
+func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	provider, isProvider := s.client.(eth2client.AttestationDataProvider)
+	if !isProvider {
+		return nil, errors.New("client is not an attestation data provider")
+	}
+	release, err := s.acquire(ctx, "attestation data")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.AttestationData(ctx, slot, committeeIndex)
+}
+
+// AttesterDuties obtains attester duties.
+func (s *Service) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.AttesterDuty, error) {
+	provider, isProvider := s.client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client is not an attester duties provider")
+	}
+	release, err := s.acquire(ctx, "attester duties")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.AttesterDuties(ctx, epoch, validatorIndices)
+}
+
+// BeaconBlockHeader provides the block header of a given block ID.
+func (s *Service) BeaconBlockHeader(ctx context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	provider, isProvider := s.client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a beacon block headers provider")
+	}
+	release, err := s.acquire(ctx, "beacon block header")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.BeaconBlockHeader(ctx, blockID)
+}
+
+// BeaconBlockProposal fetches a proposed beacon block for signing.
+func (s *Service) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) (*spec.VersionedBeaconBlock, error) {
+	provider, isProvider := s.client.(eth2client.BeaconBlockProposalProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a beacon block proposal provider")
+	}
+	release, err := s.acquire(ctx, "beacon block proposal")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.BeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
+}
+
+// BeaconBlockRoot fetches a block's root given a block ID.
+func (s *Service) BeaconBlockRoot(ctx context.Context, blockID string) (*phase0.Root, error) {
+	provider, isProvider := s.client.(eth2client.BeaconBlockRootProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a beacon block root provider")
+	}
+	release, err := s.acquire(ctx, "beacon block root")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.BeaconBlockRoot(ctx, blockID)
+}
+
+// Domain provides a domain for a given domain type at a given epoch.
+func (s *Service) Domain(ctx context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	provider, isProvider := s.client.(eth2client.DomainProvider)
+	if !isProvider {
+		return phase0.Domain{}, errors.New("client is not a domain provider")
+	}
+	return provider.Domain(ctx, domainType, epoch)
+}
+
+// FarFutureEpoch provides the far future epoch of the chain.
+func (s *Service) FarFutureEpoch(ctx context.Context) (phase0.Epoch, error) {
+	provider, isProvider := s.client.(eth2client.FarFutureEpochProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a far future epoch provider")
+	}
+	return provider.FarFutureEpoch(ctx)
+}
+
+// Fork fetches fork information for the given state.
+func (s *Service) Fork(ctx context.Context, stateID string) (*phase0.Fork, error) {
+	provider, isProvider := s.client.(eth2client.ForkProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a fork provider")
+	}
+	release, err := s.acquire(ctx, "fork")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.Fork(ctx, stateID)
+}
+
+// ForkSchedule provides details of past and future changes in the chain's fork version.
+func (s *Service) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
+	provider, isProvider := s.client.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a fork schedule provider")
+	}
+	return provider.ForkSchedule(ctx)
+}
+
+// Genesis fetches genesis information for the chain.
+func (s *Service) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
+	provider, isProvider := s.client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a genesis provider")
+	}
+	return provider.Genesis(ctx)
+}
+
+// GenesisTime provides the genesis time of the chain.
+func (s *Service) GenesisTime(ctx context.Context) (time.Time, error) {
+	provider, isProvider := s.client.(eth2client.GenesisTimeProvider)
+	if !isProvider {
+		return time.Time{}, errors.New("client is not a genesis time provider")
+	}
+	return provider.GenesisTime(ctx)
+}
+
+// NodeClient provides the client for the node.
+func (s *Service) NodeClient(ctx context.Context) (string, error) {
+	provider, isProvider := s.client.(eth2client.NodeClientProvider)
+	if !isProvider {
+		return "", errors.New("client is not a node client provider")
+	}
+	release, err := s.acquire(ctx, "node client")
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	return provider.NodeClient(ctx)
+}
+
+// NodeSyncing provides the syncing information for the node.
+func (s *Service) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
+	provider, isProvider := s.client.(eth2client.NodeSyncingProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a node syncing provider")
+	}
+	release, err := s.acquire(ctx, "node syncing")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.NodeSyncing(ctx)
+}
+
+// NodeVersion returns a free-text string with the node version.
+func (s *Service) NodeVersion(ctx context.Context) (string, error) {
+	provider, isProvider := s.client.(eth2client.NodeVersionProvider)
+	if !isProvider {
+		return "", errors.New("client is not a node version provider")
+	}
+	release, err := s.acquire(ctx, "node version")
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	return provider.NodeVersion(ctx)
+}
+
+// ProposerDuties obtains proposer duties for the given epoch.
+func (s *Service) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.ProposerDuty, error) {
+	provider, isProvider := s.client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a proposer duties provider")
+	}
+	release, err := s.acquire(ctx, "proposer duties")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.ProposerDuties(ctx, epoch, validatorIndices)
+}
+
+// SignedBeaconBlock fetches a signed beacon block given a block ID.
+func (s *Service) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	provider, isProvider := s.client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a signed beacon block provider")
+	}
+	release, err := s.acquire(ctx, "signed beacon block")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.SignedBeaconBlock(ctx, blockID)
+}
+
+// SlotDuration provides the duration of a slot of the chain.
+func (s *Service) SlotDuration(ctx context.Context) (time.Duration, error) {
+	provider, isProvider := s.client.(eth2client.SlotDurationProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a slot duration provider")
+	}
+	return provider.SlotDuration(ctx)
+}
+
+// SlotsPerEpoch provides the slots per epoch of the chain.
+func (s *Service) SlotsPerEpoch(ctx context.Context) (uint64, error) {
+	provider, isProvider := s.client.(eth2client.SlotsPerEpochProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a slots per epoch provider")
+	}
+	return provider.SlotsPerEpoch(ctx)
+}
+
+// Spec provides the spec information of the chain.
+func (s *Service) Spec(ctx context.Context) (map[string]interface{}, error) {
+	provider, isProvider := s.client.(eth2client.SpecProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a spec provider")
+	}
+	return provider.Spec(ctx)
+}
+
+// SyncCommitteeContribution provides a sync committee contribution.
+func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slot, subcommitteeIndex uint64, beaconBlockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeContributionProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a sync committee contribution provider")
+	}
+	release, err := s.acquire(ctx, "sync committee contribution")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
+}
+
+// SyncCommitteeDuties obtains sync committee duties.
+func (s *Service) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.SyncCommitteeDuty, error) {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a sync committee duties provider")
+	}
+	release, err := s.acquire(ctx, "sync committee duties")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.SyncCommitteeDuties(ctx, epoch, validatorIndices)
+}
+
+// TargetAggregatorsPerCommittee provides the target number of aggregators for each attestation committee.
+func (s *Service) TargetAggregatorsPerCommittee(ctx context.Context) (uint64, error) {
+	provider, isProvider := s.client.(eth2client.TargetAggregatorsPerCommitteeProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a target aggregators per committee provider")
+	}
+	return provider.TargetAggregatorsPerCommittee(ctx)
+}
+
+// Validators provides the validators, with their balance and status, for a given state.
+func (s *Service) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	provider, isProvider := s.client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a validators provider")
+	}
+	release, err := s.acquire(ctx, "validators")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.Validators(ctx, stateID, validatorIndices)
+}
+
+// ValidatorsByPubKey provides the validators, with their balance and status, for a given state.
+func (s *Service) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	provider, isProvider := s.client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a validators provider")
+	}
+	release, err := s.acquire(ctx, "validators")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return provider.ValidatorsByPubKey(ctx, stateID, validatorPubKeys)
+}
+
+// SubmitAggregateAttestations submits aggregate attestations.
+func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregateAndProofs []*phase0.SignedAggregateAndProof) error {
+	provider, isProvider := s.client.(eth2client.AggregateAttestationsSubmitter)
+	if !isProvider {
+		return errors.New("client is not an aggregate attestations submitter")
+	}
+	release, err := s.acquire(ctx, "submit aggregate attestations")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitAggregateAttestations(ctx, aggregateAndProofs)
+}
+
+// SubmitAttestations submits attestations.
+func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	provider, isProvider := s.client.(eth2client.AttestationsSubmitter)
+	if !isProvider {
+		return errors.New("client is not an attestations submitter")
+	}
+	release, err := s.acquire(ctx, "submit attestations")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitAttestations(ctx, attestations)
+}
+
+// SubmitBeaconBlock submits a beacon block.
+func (s *Service) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
+	provider, isProvider := s.client.(eth2client.BeaconBlockSubmitter)
+	if !isProvider {
+		return errors.New("client is not a beacon block submitter")
+	}
+	release, err := s.acquire(ctx, "submit beacon block")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitBeaconBlock(ctx, block)
+}
+
+// SubmitBeaconCommitteeSubscriptions subscribes to beacon committees.
+func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.BeaconCommitteeSubscription) error {
+	provider, isProvider := s.client.(eth2client.BeaconCommitteeSubscriptionsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a beacon committee subscriptions submitter")
+	}
+	release, err := s.acquire(ctx, "submit beacon committee subscriptions")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitProposalPreparations submits proposal preparations.
+func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
+	provider, isProvider := s.client.(eth2client.ProposalPreparationsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a proposal preparations submitter")
+	}
+	release, err := s.acquire(ctx, "submit proposal preparations")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitProposalPreparations(ctx, preparations)
+}
+
+// SubmitSyncCommitteeContributions submits sync committee contributions.
+func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeContributionsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a sync committee contributions submitter")
+	}
+	release, err := s.acquire(ctx, "submit sync committee contributions")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeMessagesSubmitter)
+	if !isProvider {
+		return errors.New("client is not a sync committee messages submitter")
+	}
+	release, err := s.acquire(ctx, "submit sync committee messages")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitSyncCommitteeMessages(ctx, messages)
+}
+
+// SubmitSyncCommitteeSubscriptions subscribes to sync committees.
+func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.SyncCommitteeSubscription) error {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeSubscriptionsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a sync committee subscriptions submitter")
+	}
+	release, err := s.acquire(ctx, "submit sync committee subscriptions")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return provider.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+}
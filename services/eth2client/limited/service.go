@@ -0,0 +1,177 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limited
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	"golang.org/x/sync/semaphore"
+)
+
+// Service is a beacon node client that limits the requests made to the underlying client.
+type Service struct {
+	client  eth2client.Service
+	address string
+	maxWait time.Duration
+
+	// inFlight limits the number of concurrent requests; nil if unlimited.
+	inFlight *semaphore.Weighted
+
+	// Token bucket for rate limiting; rate is 0 if unlimited.
+	bucketMu  sync.Mutex
+	rate      float64
+	burst     float64
+	tokens    float64
+	refreshed time.Time
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new limited client.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "eth2client").Str("impl", "limited").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		client:    parameters.client,
+		address:   parameters.client.Address(),
+		maxWait:   parameters.maxWait,
+		rate:      parameters.rate,
+		burst:     float64(parameters.burst),
+		tokens:    float64(parameters.burst),
+		refreshed: time.Now(),
+	}
+	if parameters.maxInFlight > 0 {
+		s.inFlight = semaphore.NewWeighted(int64(parameters.maxInFlight))
+	}
+	log.Trace().
+		Str("address", s.address).
+		Int("max_in_flight", parameters.maxInFlight).
+		Float64("rate", parameters.rate).
+		Int("burst", parameters.burst).
+		Dur("max_wait", parameters.maxWait).
+		Msg("Limits configured")
+
+	return s, nil
+}
+
+// acquire waits until a request can be made to the client, returning a function
+// that must be called when the request has completed.
+func (s *Service) acquire(ctx context.Context, operation string) (func(), error) {
+	started := time.Now()
+
+	if err := s.waitForToken(ctx); err != nil {
+		log.Debug().Str("address", s.address).Str("operation", operation).Err(err).Msg("Request rejected")
+		return nil, errors.Wrapf(err, "request to %s rejected", s.address)
+	}
+
+	if s.inFlight != nil {
+		acquireCtx := ctx
+		if s.maxWait > 0 {
+			var cancel context.CancelFunc
+			acquireCtx, cancel = context.WithTimeout(ctx, s.maxWait-time.Since(started))
+			defer cancel()
+		}
+		if err := s.inFlight.Acquire(acquireCtx, 1); err != nil {
+			if ctx.Err() != nil {
+				monitorRejection(s.address, "cancelled")
+				return nil, errors.Wrapf(ctx.Err(), "request to %s rejected", s.address)
+			}
+			monitorRejection(s.address, "in_flight")
+			log.Debug().Str("address", s.address).Str("operation", operation).Msg("Request rejected; too many requests in flight")
+			return nil, errors.Errorf("request to %s rejected: too many requests in flight", s.address)
+		}
+	}
+
+	monitorQueueingDelay(s.address, time.Since(started))
+
+	return func() {
+		if s.inFlight != nil {
+			s.inFlight.Release(1)
+		}
+	}, nil
+}
+
+// waitForToken waits until the rate limit allows a request to be made.
+func (s *Service) waitForToken(ctx context.Context) error {
+	if s.rate == 0 {
+		return nil
+	}
+
+	delay := s.reserveToken()
+	if s.maxWait > 0 && delay > s.maxWait {
+		s.returnToken()
+		monitorRejection(s.address, "rate")
+		return errors.New("rate limit exceeded")
+	}
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		s.returnToken()
+		monitorRejection(s.address, "cancelled")
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserveToken takes a token from the bucket, returning the time until the token is available.
+func (s *Service) reserveToken() time.Duration {
+	s.bucketMu.Lock()
+	defer s.bucketMu.Unlock()
+
+	now := time.Now()
+	s.tokens += now.Sub(s.refreshed).Seconds() * s.rate
+	if s.tokens > s.burst {
+		s.tokens = s.burst
+	}
+	s.refreshed = now
+
+	s.tokens--
+	if s.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-s.tokens / s.rate * float64(time.Second))
+}
+
+// returnToken returns an unused token to the bucket.
+func (s *Service) returnToken() {
+	s.bucketMu.Lock()
+	s.tokens++
+	s.bucketMu.Unlock()
+}
@@ -0,0 +1,230 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limited_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/eth2client/limited"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// client is a minimal beacon node client.
+type client struct {
+	// delay is the time taken to respond to each request.
+	delay time.Duration
+}
+
+func (*client) Name() string {
+	return "mock"
+}
+
+func (*client) Address() string {
+	return "localhost:5052"
+}
+
+func (c *client) AttestationData(_ context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	time.Sleep(c.delay)
+	return &phase0.AttestationData{
+		Slot:   slot,
+		Index:  committeeIndex,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []limited.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []limited.Parameter{
+				limited.WithLogLevel(zerolog.Disabled),
+				limited.WithMonitor(nil),
+				limited.WithClient(&client{}),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "ClientMissing",
+			params: []limited.Parameter{
+				limited.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no client specified",
+		},
+		{
+			name: "MaxInFlightNegative",
+			params: []limited.Parameter{
+				limited.WithLogLevel(zerolog.Disabled),
+				limited.WithClient(&client{}),
+				limited.WithMaxInFlight(-1),
+			},
+			err: "problem with parameters: max in flight cannot be negative",
+		},
+		{
+			name: "RateNegative",
+			params: []limited.Parameter{
+				limited.WithLogLevel(zerolog.Disabled),
+				limited.WithClient(&client{}),
+				limited.WithRate(-1),
+			},
+			err: "problem with parameters: rate cannot be negative",
+		},
+		{
+			name: "BurstZero",
+			params: []limited.Parameter{
+				limited.WithLogLevel(zerolog.Disabled),
+				limited.WithClient(&client{}),
+				limited.WithBurst(0),
+			},
+			err: "problem with parameters: burst must be at least 1",
+		},
+		{
+			name: "MaxWaitNegative",
+			params: []limited.Parameter{
+				limited.WithLogLevel(zerolog.Disabled),
+				limited.WithClient(&client{}),
+				limited.WithMaxWait(-time.Second),
+			},
+			err: "problem with parameters: max wait cannot be negative",
+		},
+		{
+			name: "Good",
+			params: []limited.Parameter{
+				limited.WithLogLevel(zerolog.Disabled),
+				limited.WithClient(&client{}),
+				limited.WithMaxInFlight(2),
+				limited.WithRate(10),
+				limited.WithBurst(5),
+				limited.WithMaxWait(time.Second),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := limited.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "mock", s.Name())
+				require.Equal(t, "localhost:5052", s.Address())
+			}
+		})
+	}
+}
+
+func TestNotProvider(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := limited.New(ctx,
+		limited.WithLogLevel(zerolog.Disabled),
+		limited.WithClient(&client{}),
+	)
+	require.NoError(t, err)
+
+	_, err = s.NodeVersion(ctx)
+	require.EqualError(t, err, "client is not a node version provider")
+}
+
+func TestRate(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := limited.New(ctx,
+		limited.WithLogLevel(zerolog.Disabled),
+		limited.WithClient(&client{}),
+		limited.WithRate(20),
+		limited.WithBurst(2),
+	)
+	require.NoError(t, err)
+
+	// The burst is immediate, subsequent requests are spaced by the rate.
+	started := time.Now()
+	for i := 0; i < 4; i++ {
+		_, err := s.AttestationData(ctx, phase0.Slot(i), 0)
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(started), 90*time.Millisecond)
+}
+
+func TestRateRejected(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := limited.New(ctx,
+		limited.WithLogLevel(zerolog.Disabled),
+		limited.WithClient(&client{}),
+		limited.WithRate(1),
+		limited.WithMaxWait(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	_, err = s.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	_, err = s.AttestationData(ctx, 2, 0)
+	require.EqualError(t, err, "request to localhost:5052 rejected: rate limit exceeded")
+}
+
+func TestRateCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	s, err := limited.New(ctx,
+		limited.WithLogLevel(zerolog.Disabled),
+		limited.WithClient(&client{}),
+		limited.WithRate(1),
+	)
+	require.NoError(t, err)
+
+	_, err = s.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	_, err = s.AttestationData(ctx, 2, 0)
+	require.EqualError(t, err, "request to localhost:5052 rejected: context deadline exceeded")
+}
+
+func TestInFlight(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := limited.New(ctx,
+		limited.WithLogLevel(zerolog.Disabled),
+		limited.WithClient(&client{delay: 200 * time.Millisecond}),
+		limited.WithMaxInFlight(1),
+		limited.WithMaxWait(20*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	errs := make(chan error)
+	go func() {
+		_, err := s.AttestationData(ctx, 1, 0)
+		errs <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	_, err = s.AttestationData(ctx, 2, 0)
+	require.EqualError(t, err, "request to localhost:5052 rejected: too many requests in flight")
+	require.NoError(t, <-errs)
+
+	// Slot is free again.
+	_, err = s.AttestationData(ctx, 3, 0)
+	require.NoError(t, err)
+}
@@ -0,0 +1,175 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a local proxy to a beacon node.
+type Service struct {
+	address  string
+	listener net.Listener
+	server   *http.Server
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new proxy, listening on a local address until the context is done.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "eth2client").Str("impl", "proxy").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	address := parameters.address
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+	target, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.New("invalid address")
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(parameters.clientCert) > 0 {
+		log.Trace().Msg("Adding client certificate")
+		cert, err := tls.X509KeyPair(parameters.clientCert, parameters.clientKey)
+		if err != nil {
+			return nil, errors.New("invalid client certificate or key")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if len(parameters.caCert) > 0 {
+		log.Trace().Msg("Adding CA certificate")
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(parameters.caCert) {
+			return nil, errors.New("invalid CA certificate")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	headers := make(http.Header)
+	for k, v := range parameters.headers {
+		headers.Set(k, v)
+	}
+	if target.User != nil {
+		// Credentials in the address are sent as basic authentication.
+		if headers.Get("Authorization") == "" {
+			password, _ := target.User.Password()
+			req := &http.Request{Header: headers}
+			req.SetBasicAuth(target.User.Username(), password)
+		}
+		target.User = nil
+	}
+	headerNames := make([]string, 0, len(headers))
+	for k := range headers {
+		headerNames = append(headerNames, k)
+	}
+	sort.Strings(headerNames)
+
+	reverseProxy := httputil.NewSingleHostReverseProxy(target)
+	director := reverseProxy.Director
+	reverseProxy.Director = func(req *http.Request) {
+		director(req)
+		req.Host = target.Host
+		for k, v := range headers {
+			req.Header[k] = v
+		}
+	}
+	reverseProxy.Transport = &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        64,
+		MaxIdleConnsPerHost: 64,
+	}
+	// Flush immediately, so that event streams are passed on as they arrive.
+	reverseProxy.FlushInterval = -1
+	reverseProxy.ErrorHandler = func(w http.ResponseWriter, req *http.Request, err error) {
+		log.Debug().Str("path", req.URL.Path).Err(err).Msg("Failed to proxy request")
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen")
+	}
+
+	s := &Service{
+		address:  listener.Addr().String(),
+		listener: listener,
+		server: &http.Server{
+			Handler:           reverseProxy,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("Proxy failed")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		log.Trace().Msg("Context done; closing proxy")
+		if err := s.server.Close(); err != nil {
+			log.Debug().Err(err).Msg("Failed to close proxy")
+		}
+	}()
+
+	// Only log the names of headers, as their values may contain secrets.
+	log.Debug().
+		Str("target", redact(target)).
+		Str("address", s.address).
+		Strs("headers", headerNames).
+		Bool("client_cert", len(parameters.clientCert) > 0).
+		Msg("Proxy started")
+
+	return s, nil
+}
+
+// Address provides the local address of the proxy.
+func (s *Service) Address() string {
+	return s.address
+}
+
+// redact provides the URL without any query parameters, which may contain secrets.
+func redact(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = ""
+	return redacted.String()
+}
@@ -0,0 +1,162 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/vouch/services/eth2client/proxy"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		params []proxy.Parameter
+		err    string
+	}{
+		{
+			name: "AddressMissing",
+			params: []proxy.Parameter{
+				proxy.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no address specified",
+		},
+		{
+			name: "ClientKeyMissing",
+			params: []proxy.Parameter{
+				proxy.WithLogLevel(zerolog.Disabled),
+				proxy.WithAddress("localhost:5052"),
+				proxy.WithClientCert([]byte("cert")),
+			},
+			err: "problem with parameters: client certificate specified without client key",
+		},
+		{
+			name: "ClientCertMissing",
+			params: []proxy.Parameter{
+				proxy.WithLogLevel(zerolog.Disabled),
+				proxy.WithAddress("localhost:5052"),
+				proxy.WithClientKey([]byte("key")),
+			},
+			err: "problem with parameters: client key specified without client certificate",
+		},
+		{
+			name: "ClientCertInvalid",
+			params: []proxy.Parameter{
+				proxy.WithLogLevel(zerolog.Disabled),
+				proxy.WithAddress("localhost:5052"),
+				proxy.WithClientCert([]byte("cert")),
+				proxy.WithClientKey([]byte("key")),
+			},
+			err: "invalid client certificate or key",
+		},
+		{
+			name: "CACertInvalid",
+			params: []proxy.Parameter{
+				proxy.WithLogLevel(zerolog.Disabled),
+				proxy.WithAddress("localhost:5052"),
+				proxy.WithCACert([]byte("cert")),
+			},
+			err: "invalid CA certificate",
+		},
+		{
+			name: "Good",
+			params: []proxy.Parameter{
+				proxy.WithLogLevel(zerolog.Disabled),
+				proxy.WithAddress("localhost:5052"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := proxy.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProxy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s %s", r.Method, r.URL.Path, r.Header.Get("Authorization"), r.Header.Get("X-Api-Key"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		address  string
+		headers  map[string]string
+		expected string
+	}{
+		{
+			name:     "NoHeaders",
+			address:  server.URL,
+			expected: "GET /eth/v1/node/version  ",
+		},
+		{
+			name:    "Headers",
+			address: server.URL,
+			headers: map[string]string{
+				"authorization": "Bearer secret",
+				"X-API-Key":     "key",
+			},
+			expected: "GET /eth/v1/node/version Bearer secret key",
+		},
+		{
+			name:     "BasicAuth",
+			address:  strings.Replace(server.URL, "http://", "http://user:pass@", 1),
+			expected: "GET /eth/v1/node/version Basic dXNlcjpwYXNz ",
+		},
+		{
+			name:     "PathPrefix",
+			address:  fmt.Sprintf("%s/prefix/", server.URL),
+			expected: "GET /prefix/eth/v1/node/version  ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := proxy.New(ctx,
+				proxy.WithLogLevel(zerolog.Disabled),
+				proxy.WithAddress(test.address),
+				proxy.WithHeaders(test.headers),
+			)
+			require.NoError(t, err)
+
+			resp, err := http.Get(fmt.Sprintf("http://%s/eth/v1/node/version", s.Address()))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, string(body))
+		})
+	}
+}
@@ -0,0 +1,454 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Name provides the name of the underlying client.
+func (s *Service) Name() string {
+	return s.client.Name()
+}
+
+// Address provides the address of the underlying client.
+func (s *Service) Address() string {
+	return s.client.Address()
+}
+
+// Events feeds requested events with the given topics to the supplied handler.
+// Each event is recorded as it is received.
+func (s *Service) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	provider, isProvider := s.client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("client is not an events provider")
+	}
+	return provider.Events(ctx, topics, func(event *apiv1.Event) {
+		s.recordEvent(event)
+		handler(event)
+	})
+}
+
+// Spec provides the spec information of the chain.
+func (s *Service) Spec(ctx context.Context) (map[string]interface{}, error) {
+	provider, isProvider := s.client.(eth2client.SpecProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a spec provider")
+	}
+	started := time.Now()
+	res, err := provider.Spec(ctx)
+	if err != nil {
+		s.record(started, "Spec", nil, nil, err)
+		return nil, err
+	}
+	values, encodeErr := EncodeSpec(res)
+	if encodeErr != nil {
+		log.Warn().Err(encodeErr).Msg("Failed to encode spec")
+		return res, nil
+	}
+	s.record(started, "Spec", nil, values, nil)
+	return res, nil
+}
+
+// AggregateAttestation fetches the aggregate attestation given an attestation.
+func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root) (*phase0.Attestation, error) {
+	provider, isProvider := s.client.(eth2client.AggregateAttestationProvider)
+	if !isProvider {
+		return nil, errors.New("client is not an aggregate attestation provider")
+	}
+	started := time.Now()
+	res, err := provider.AggregateAttestation(ctx, slot, attestationDataRoot)
+	s.record(started, "AggregateAttestation", []interface{}{slot, attestationDataRoot}, res, err)
+	return res, err
+}
+
+// AttestationData fetches the attestation data for the given slot and committee index.
+func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	provider, isProvider := s.client.(eth2client.AttestationDataProvider)
+	if !isProvider {
+		return nil, errors.New("client is not an attestation data provider")
+	}
+	started := time.Now()
+	res, err := provider.AttestationData(ctx, slot, committeeIndex)
+	s.record(started, "AttestationData", []interface{}{slot, committeeIndex}, res, err)
+	return res, err
+}
+
+// AttesterDuties obtains attester duties.
+func (s *Service) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.AttesterDuty, error) {
+	provider, isProvider := s.client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client is not an attester duties provider")
+	}
+	started := time.Now()
+	res, err := provider.AttesterDuties(ctx, epoch, validatorIndices)
+	s.record(started, "AttesterDuties", []interface{}{epoch, validatorIndices}, res, err)
+	return res, err
+}
+
+// BeaconBlockHeader provides the block header of a given block ID.
+func (s *Service) BeaconBlockHeader(ctx context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	provider, isProvider := s.client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a beacon block headers provider")
+	}
+	started := time.Now()
+	res, err := provider.BeaconBlockHeader(ctx, blockID)
+	s.record(started, "BeaconBlockHeader", []interface{}{blockID}, res, err)
+	return res, err
+}
+
+// BeaconBlockProposal fetches a proposed beacon block for signing.
+func (s *Service) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) (*spec.VersionedBeaconBlock, error) {
+	provider, isProvider := s.client.(eth2client.BeaconBlockProposalProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a beacon block proposal provider")
+	}
+	started := time.Now()
+	res, err := provider.BeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
+	s.record(started, "BeaconBlockProposal", []interface{}{slot, randaoReveal, graffiti}, res, err)
+	return res, err
+}
+
+// BeaconBlockRoot fetches a block's root given a block ID.
+func (s *Service) BeaconBlockRoot(ctx context.Context, blockID string) (*phase0.Root, error) {
+	provider, isProvider := s.client.(eth2client.BeaconBlockRootProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a beacon block root provider")
+	}
+	started := time.Now()
+	res, err := provider.BeaconBlockRoot(ctx, blockID)
+	s.record(started, "BeaconBlockRoot", []interface{}{blockID}, res, err)
+	return res, err
+}
+
+// Domain provides a domain for a given domain type at a given epoch.
+func (s *Service) Domain(ctx context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	provider, isProvider := s.client.(eth2client.DomainProvider)
+	if !isProvider {
+		return phase0.Domain{}, errors.New("client is not a domain provider")
+	}
+	started := time.Now()
+	res, err := provider.Domain(ctx, domainType, epoch)
+	s.record(started, "Domain", []interface{}{domainType, epoch}, res, err)
+	return res, err
+}
+
+// FarFutureEpoch provides the far future epoch of the chain.
+func (s *Service) FarFutureEpoch(ctx context.Context) (phase0.Epoch, error) {
+	provider, isProvider := s.client.(eth2client.FarFutureEpochProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a far future epoch provider")
+	}
+	started := time.Now()
+	res, err := provider.FarFutureEpoch(ctx)
+	s.record(started, "FarFutureEpoch", nil, res, err)
+	return res, err
+}
+
+// Fork fetches fork information for the given state.
+func (s *Service) Fork(ctx context.Context, stateID string) (*phase0.Fork, error) {
+	provider, isProvider := s.client.(eth2client.ForkProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a fork provider")
+	}
+	started := time.Now()
+	res, err := provider.Fork(ctx, stateID)
+	s.record(started, "Fork", []interface{}{stateID}, res, err)
+	return res, err
+}
+
+// ForkSchedule provides details of past and future changes in the chain's fork version.
+func (s *Service) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
+	provider, isProvider := s.client.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a fork schedule provider")
+	}
+	started := time.Now()
+	res, err := provider.ForkSchedule(ctx)
+	s.record(started, "ForkSchedule", nil, res, err)
+	return res, err
+}
+
+// Genesis fetches genesis information for the chain.
+func (s *Service) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
+	provider, isProvider := s.client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a genesis provider")
+	}
+	started := time.Now()
+	res, err := provider.Genesis(ctx)
+	s.record(started, "Genesis", nil, res, err)
+	return res, err
+}
+
+// GenesisTime provides the genesis time of the chain.
+func (s *Service) GenesisTime(ctx context.Context) (time.Time, error) {
+	provider, isProvider := s.client.(eth2client.GenesisTimeProvider)
+	if !isProvider {
+		return time.Time{}, errors.New("client is not a genesis time provider")
+	}
+	started := time.Now()
+	res, err := provider.GenesisTime(ctx)
+	s.record(started, "GenesisTime", nil, res, err)
+	return res, err
+}
+
+// NodeClient provides the client for the node.
+func (s *Service) NodeClient(ctx context.Context) (string, error) {
+	provider, isProvider := s.client.(eth2client.NodeClientProvider)
+	if !isProvider {
+		return "", errors.New("client is not a node client provider")
+	}
+	started := time.Now()
+	res, err := provider.NodeClient(ctx)
+	s.record(started, "NodeClient", nil, res, err)
+	return res, err
+}
+
+// NodeSyncing provides the syncing information for the node.
+func (s *Service) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
+	provider, isProvider := s.client.(eth2client.NodeSyncingProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a node syncing provider")
+	}
+	started := time.Now()
+	res, err := provider.NodeSyncing(ctx)
+	s.record(started, "NodeSyncing", nil, res, err)
+	return res, err
+}
+
+// NodeVersion returns a free-text string with the node version.
+func (s *Service) NodeVersion(ctx context.Context) (string, error) {
+	provider, isProvider := s.client.(eth2client.NodeVersionProvider)
+	if !isProvider {
+		return "", errors.New("client is not a node version provider")
+	}
+	started := time.Now()
+	res, err := provider.NodeVersion(ctx)
+	s.record(started, "NodeVersion", nil, res, err)
+	return res, err
+}
+
+// ProposerDuties obtains proposer duties for the given epoch.
+func (s *Service) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.ProposerDuty, error) {
+	provider, isProvider := s.client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a proposer duties provider")
+	}
+	started := time.Now()
+	res, err := provider.ProposerDuties(ctx, epoch, validatorIndices)
+	s.record(started, "ProposerDuties", []interface{}{epoch, validatorIndices}, res, err)
+	return res, err
+}
+
+// SignedBeaconBlock fetches a signed beacon block given a block ID.
+func (s *Service) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	provider, isProvider := s.client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a signed beacon block provider")
+	}
+	started := time.Now()
+	res, err := provider.SignedBeaconBlock(ctx, blockID)
+	s.record(started, "SignedBeaconBlock", []interface{}{blockID}, res, err)
+	return res, err
+}
+
+// SlotDuration provides the duration of a slot of the chain.
+func (s *Service) SlotDuration(ctx context.Context) (time.Duration, error) {
+	provider, isProvider := s.client.(eth2client.SlotDurationProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a slot duration provider")
+	}
+	started := time.Now()
+	res, err := provider.SlotDuration(ctx)
+	s.record(started, "SlotDuration", nil, res, err)
+	return res, err
+}
+
+// SlotsPerEpoch provides the slots per epoch of the chain.
+func (s *Service) SlotsPerEpoch(ctx context.Context) (uint64, error) {
+	provider, isProvider := s.client.(eth2client.SlotsPerEpochProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a slots per epoch provider")
+	}
+	started := time.Now()
+	res, err := provider.SlotsPerEpoch(ctx)
+	s.record(started, "SlotsPerEpoch", nil, res, err)
+	return res, err
+}
+
+// SyncCommitteeContribution provides a sync committee contribution.
+func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slot, subcommitteeIndex uint64, beaconBlockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeContributionProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a sync committee contribution provider")
+	}
+	started := time.Now()
+	res, err := provider.SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
+	s.record(started, "SyncCommitteeContribution", []interface{}{slot, subcommitteeIndex, beaconBlockRoot}, res, err)
+	return res, err
+}
+
+// SyncCommitteeDuties obtains sync committee duties.
+func (s *Service) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.SyncCommitteeDuty, error) {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeDutiesProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a sync committee duties provider")
+	}
+	started := time.Now()
+	res, err := provider.SyncCommitteeDuties(ctx, epoch, validatorIndices)
+	s.record(started, "SyncCommitteeDuties", []interface{}{epoch, validatorIndices}, res, err)
+	return res, err
+}
+
+// TargetAggregatorsPerCommittee provides the target number of aggregators for each attestation committee.
+func (s *Service) TargetAggregatorsPerCommittee(ctx context.Context) (uint64, error) {
+	provider, isProvider := s.client.(eth2client.TargetAggregatorsPerCommitteeProvider)
+	if !isProvider {
+		return 0, errors.New("client is not a target aggregators per committee provider")
+	}
+	started := time.Now()
+	res, err := provider.TargetAggregatorsPerCommittee(ctx)
+	s.record(started, "TargetAggregatorsPerCommittee", nil, res, err)
+	return res, err
+}
+
+// Validators provides the validators, with their balance and status, for a given state.
+func (s *Service) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	provider, isProvider := s.client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a validators provider")
+	}
+	started := time.Now()
+	res, err := provider.Validators(ctx, stateID, validatorIndices)
+	s.record(started, "Validators", []interface{}{stateID, validatorIndices}, res, err)
+	return res, err
+}
+
+// ValidatorsByPubKey provides the validators, with their balance and status, for a given state.
+func (s *Service) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	provider, isProvider := s.client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("client is not a validators provider")
+	}
+	started := time.Now()
+	res, err := provider.ValidatorsByPubKey(ctx, stateID, validatorPubKeys)
+	s.record(started, "ValidatorsByPubKey", []interface{}{stateID, validatorPubKeys}, res, err)
+	return res, err
+}
+
+// SubmitAggregateAttestations submits aggregate attestations.
+func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregateAndProofs []*phase0.SignedAggregateAndProof) error {
+	provider, isProvider := s.client.(eth2client.AggregateAttestationsSubmitter)
+	if !isProvider {
+		return errors.New("client is not an aggregate attestations submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitAggregateAttestations(ctx, aggregateAndProofs)
+	s.record(started, "SubmitAggregateAttestations", []interface{}{aggregateAndProofs}, nil, err)
+	return err
+}
+
+// SubmitAttestations submits attestations.
+func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	provider, isProvider := s.client.(eth2client.AttestationsSubmitter)
+	if !isProvider {
+		return errors.New("client is not an attestations submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitAttestations(ctx, attestations)
+	s.record(started, "SubmitAttestations", []interface{}{attestations}, nil, err)
+	return err
+}
+
+// SubmitBeaconBlock submits a beacon block.
+func (s *Service) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
+	provider, isProvider := s.client.(eth2client.BeaconBlockSubmitter)
+	if !isProvider {
+		return errors.New("client is not a beacon block submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitBeaconBlock(ctx, block)
+	s.record(started, "SubmitBeaconBlock", []interface{}{block}, nil, err)
+	return err
+}
+
+// SubmitBeaconCommitteeSubscriptions subscribes to beacon committees.
+func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.BeaconCommitteeSubscription) error {
+	provider, isProvider := s.client.(eth2client.BeaconCommitteeSubscriptionsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a beacon committee subscriptions submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+	s.record(started, "SubmitBeaconCommitteeSubscriptions", []interface{}{subscriptions}, nil, err)
+	return err
+}
+
+// SubmitProposalPreparations submits proposal preparations.
+func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
+	provider, isProvider := s.client.(eth2client.ProposalPreparationsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a proposal preparations submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitProposalPreparations(ctx, preparations)
+	s.record(started, "SubmitProposalPreparations", []interface{}{preparations}, nil, err)
+	return err
+}
+
+// SubmitSyncCommitteeContributions submits sync committee contributions.
+func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeContributionsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a sync committee contributions submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+	s.record(started, "SubmitSyncCommitteeContributions", []interface{}{contributionAndProofs}, nil, err)
+	return err
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeMessagesSubmitter)
+	if !isProvider {
+		return errors.New("client is not a sync committee messages submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitSyncCommitteeMessages(ctx, messages)
+	s.record(started, "SubmitSyncCommitteeMessages", []interface{}{messages}, nil, err)
+	return err
+}
+
+// SubmitSyncCommitteeSubscriptions subscribes to sync committees.
+func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.SyncCommitteeSubscription) error {
+	provider, isProvider := s.client.(eth2client.SyncCommitteeSubscriptionsSubmitter)
+	if !isProvider {
+		return errors.New("client is not a sync committee subscriptions submitter")
+	}
+	started := time.Now()
+	err := provider.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+	s.record(started, "SubmitSyncCommitteeSubscriptions", []interface{}{subscriptions}, nil, err)
+	return err
+}
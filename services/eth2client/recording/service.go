@@ -0,0 +1,166 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// EventMethod is the method of records for events received from the beacon node.
+const EventMethod = "Event"
+
+// Record is a single interaction with the beacon node, as written to the recording.
+type Record struct {
+	// Started is the time at which the request was made, or the event received.
+	Started time.Time `json:"started"`
+	// Duration is the time taken for the beacon node to respond.
+	Duration time.Duration `json:"duration"`
+	// Method is the name of the client method called.
+	Method string `json:"method"`
+	// Request is the list of arguments to the method, excluding the context.
+	// For events it is the topic.
+	Request json.RawMessage `json:"request,omitempty"`
+	// Response is the value returned by the method.
+	// For events it is the event data.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Error is the error returned by the method, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Service is a beacon node client that records the requests made to the underlying client.
+type Service struct {
+	client eth2client.Service
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new recording client.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "eth2client").Str("impl", "recording").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	file, err := os.OpenFile(parameters.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open recording file")
+	}
+
+	s := &Service{
+		client: parameters.client,
+		file:   file,
+	}
+	log.Info().Str("address", parameters.client.Address()).Str("path", parameters.path).Msg("Recording beacon node interactions")
+
+	go func(ctx context.Context, s *Service) {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.file.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close recording file")
+		}
+	}(ctx, s)
+
+	return s, nil
+}
+
+// record writes an interaction with the beacon node to the recording.
+// Failures are logged rather than returned, as they should not affect the request.
+func (s *Service) record(started time.Time, method string, request []interface{}, response interface{}, responseErr error) {
+	record := &Record{
+		Started:  started,
+		Duration: time.Since(started),
+		Method:   method,
+	}
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			log.Warn().Str("method", method).Err(err).Msg("Failed to marshal request")
+			return
+		}
+		record.Request = data
+	}
+	if responseErr != nil {
+		record.Error = responseErr.Error()
+	} else if response != nil {
+		data, err := json.Marshal(response)
+		if err != nil {
+			log.Warn().Str("method", method).Err(err).Msg("Failed to marshal response")
+			return
+		}
+		record.Response = data
+	}
+	s.write(record)
+}
+
+// recordEvent writes an event received from the beacon node to the recording.
+func (s *Service) recordEvent(event *apiv1.Event) {
+	if event == nil {
+		return
+	}
+	topic, err := json.Marshal(event.Topic)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to marshal event topic")
+		return
+	}
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		log.Warn().Str("topic", event.Topic).Err(err).Msg("Failed to marshal event data")
+		return
+	}
+	s.write(&Record{
+		Started:  time.Now(),
+		Method:   EventMethod,
+		Request:  topic,
+		Response: data,
+	})
+}
+
+// write writes a record to the recording file.
+func (s *Service) write(record *Record) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Warn().Str("method", record.Method).Err(err).Msg("Failed to marshal record")
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(line); err != nil {
+		log.Warn().Str("method", record.Method).Err(err).Msg("Failed to write record")
+		return
+	}
+	log.Trace().Str("method", record.Method).Msg("Wrote record")
+}
@@ -0,0 +1,101 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/eth2client/recording"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// client is a minimal beacon node client.
+type client struct{}
+
+func (*client) Name() string {
+	return "mock"
+}
+
+func (*client) Address() string {
+	return "localhost:5052"
+}
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+
+	tests := []struct {
+		name   string
+		params []recording.Parameter
+		err    string
+	}{
+		{
+			name: "ClientMissing",
+			params: []recording.Parameter{
+				recording.WithLogLevel(zerolog.Disabled),
+				recording.WithPath(path),
+			},
+			err: "problem with parameters: no client specified",
+		},
+		{
+			name: "PathMissing",
+			params: []recording.Parameter{
+				recording.WithLogLevel(zerolog.Disabled),
+				recording.WithClient(&client{}),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "Good",
+			params: []recording.Parameter{
+				recording.WithLogLevel(zerolog.Disabled),
+				recording.WithClient(&client{}),
+				recording.WithPath(path),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := recording.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSpec(t *testing.T) {
+	ctx := context.Background()
+
+	spec, err := mock.NewSpecProvider().Spec(ctx)
+	require.NoError(t, err)
+
+	values, err := recording.EncodeSpec(spec)
+	require.NoError(t, err)
+	decoded, err := recording.DecodeSpec(values)
+	require.NoError(t, err)
+	require.Equal(t, spec, decoded)
+
+	_, err = recording.EncodeSpec(map[string]interface{}{"BAD": 1.5})
+	require.EqualError(t, err, "unhandled type float64 for BAD")
+}
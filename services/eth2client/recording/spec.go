@@ -0,0 +1,114 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SpecValue is a value from the beacon node's spec.
+// The spec holds values of many types, so each is written with its type to allow it to be read back.
+type SpecValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// EncodeSpec encodes a spec for writing to the recording.
+func EncodeSpec(spec map[string]interface{}) (map[string]*SpecValue, error) {
+	res := make(map[string]*SpecValue, len(spec))
+	for k, v := range spec {
+		var valueType string
+		switch v.(type) {
+		case uint64:
+			valueType = "uint64"
+		case time.Duration:
+			valueType = "duration"
+		case time.Time:
+			valueType = "time"
+		case []byte:
+			valueType = "bytes"
+		case phase0.Version:
+			valueType = "version"
+		case phase0.DomainType:
+			valueType = "domain_type"
+		case string:
+			valueType = "string"
+		default:
+			return nil, fmt.Errorf("unhandled type %T for %s", v, k)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to marshal %s", k))
+		}
+		res[k] = &SpecValue{
+			Type:  valueType,
+			Value: data,
+		}
+	}
+
+	return res, nil
+}
+
+// DecodeSpec decodes a spec read from the recording.
+func DecodeSpec(values map[string]*SpecValue) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		if v == nil {
+			return nil, fmt.Errorf("missing value for %s", k)
+		}
+		var err error
+		switch v.Type {
+		case "uint64":
+			var val uint64
+			err = json.Unmarshal(v.Value, &val)
+			res[k] = val
+		case "duration":
+			var val time.Duration
+			err = json.Unmarshal(v.Value, &val)
+			res[k] = val
+		case "time":
+			var val time.Time
+			err = json.Unmarshal(v.Value, &val)
+			res[k] = val
+		case "bytes":
+			var val []byte
+			err = json.Unmarshal(v.Value, &val)
+			res[k] = val
+		case "version":
+			var val phase0.Version
+			err = json.Unmarshal(v.Value, &val)
+			res[k] = val
+		case "domain_type":
+			var val phase0.DomainType
+			err = json.Unmarshal(v.Value, &val)
+			res[k] = val
+		case "string":
+			var val string
+			err = json.Unmarshal(v.Value, &val)
+			res[k] = val
+		default:
+			return nil, fmt.Errorf("unhandled type %s for %s", v.Type, k)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to unmarshal %s", k))
+		}
+	}
+
+	return res, nil
+}
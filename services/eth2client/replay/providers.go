@@ -0,0 +1,312 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/eth2client/recording"
+)
+
+// Name provides the name of the client.
+func (*Service) Name() string {
+	return "replay"
+}
+
+// Address provides the path of the recording.
+func (s *Service) Address() string {
+	return s.path
+}
+
+// Events subscribes the supplied handler to events with the given topics.
+// Recorded events are sent by ReplayEvents.
+func (s *Service) Events(_ context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		s.handlers[topic] = append(s.handlers[topic], handler)
+	}
+	return nil
+}
+
+// Spec provides the spec information of the chain.
+func (s *Service) Spec(ctx context.Context) (map[string]interface{}, error) {
+	var values map[string]*recording.SpecValue
+	if err := s.replay(ctx, "Spec", nil, &values); err != nil {
+		return nil, err
+	}
+	return recording.DecodeSpec(values)
+}
+
+// AggregateAttestation fetches the aggregate attestation given an attestation.
+func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root) (*phase0.Attestation, error) {
+	var res *phase0.Attestation
+	if err := s.replay(ctx, "AggregateAttestation", []interface{}{slot, attestationDataRoot}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AttestationData fetches the attestation data for the given slot and committee index.
+func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	var res *phase0.AttestationData
+	if err := s.replay(ctx, "AttestationData", []interface{}{slot, committeeIndex}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AttesterDuties obtains attester duties.
+func (s *Service) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.AttesterDuty, error) {
+	var res []*apiv1.AttesterDuty
+	if err := s.replay(ctx, "AttesterDuties", []interface{}{epoch, validatorIndices}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BeaconBlockHeader provides the block header of a given block ID.
+func (s *Service) BeaconBlockHeader(ctx context.Context, blockID string) (*apiv1.BeaconBlockHeader, error) {
+	var res *apiv1.BeaconBlockHeader
+	if err := s.replay(ctx, "BeaconBlockHeader", []interface{}{blockID}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BeaconBlockProposal fetches a proposed beacon block for signing.
+func (s *Service) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) (*spec.VersionedBeaconBlock, error) {
+	var res *spec.VersionedBeaconBlock
+	if err := s.replay(ctx, "BeaconBlockProposal", []interface{}{slot, randaoReveal, graffiti}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BeaconBlockRoot fetches a block's root given a block ID.
+func (s *Service) BeaconBlockRoot(ctx context.Context, blockID string) (*phase0.Root, error) {
+	var res *phase0.Root
+	if err := s.replay(ctx, "BeaconBlockRoot", []interface{}{blockID}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Domain provides a domain for a given domain type at a given epoch.
+func (s *Service) Domain(ctx context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	var res phase0.Domain
+	if err := s.replay(ctx, "Domain", []interface{}{domainType, epoch}, &res); err != nil {
+		return phase0.Domain{}, err
+	}
+	return res, nil
+}
+
+// FarFutureEpoch provides the far future epoch of the chain.
+func (s *Service) FarFutureEpoch(ctx context.Context) (phase0.Epoch, error) {
+	var res phase0.Epoch
+	if err := s.replay(ctx, "FarFutureEpoch", nil, &res); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// Fork fetches fork information for the given state.
+func (s *Service) Fork(ctx context.Context, stateID string) (*phase0.Fork, error) {
+	var res *phase0.Fork
+	if err := s.replay(ctx, "Fork", []interface{}{stateID}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ForkSchedule provides details of past and future changes in the chain's fork version.
+func (s *Service) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
+	var res []*phase0.Fork
+	if err := s.replay(ctx, "ForkSchedule", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Genesis fetches genesis information for the chain.
+func (s *Service) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
+	var res *apiv1.Genesis
+	if err := s.replay(ctx, "Genesis", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GenesisTime provides the genesis time of the chain.
+func (s *Service) GenesisTime(ctx context.Context) (time.Time, error) {
+	var res time.Time
+	if err := s.replay(ctx, "GenesisTime", nil, &res); err != nil {
+		return time.Time{}, err
+	}
+	return res, nil
+}
+
+// NodeClient provides the client for the node.
+func (s *Service) NodeClient(ctx context.Context) (string, error) {
+	var res string
+	if err := s.replay(ctx, "NodeClient", nil, &res); err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// NodeSyncing provides the syncing information for the node.
+func (s *Service) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
+	var res *apiv1.SyncState
+	if err := s.replay(ctx, "NodeSyncing", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NodeVersion returns a free-text string with the node version.
+func (s *Service) NodeVersion(ctx context.Context) (string, error) {
+	var res string
+	if err := s.replay(ctx, "NodeVersion", nil, &res); err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// ProposerDuties obtains proposer duties for the given epoch.
+func (s *Service) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.ProposerDuty, error) {
+	var res []*apiv1.ProposerDuty
+	if err := s.replay(ctx, "ProposerDuties", []interface{}{epoch, validatorIndices}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SignedBeaconBlock fetches a signed beacon block given a block ID.
+func (s *Service) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.VersionedSignedBeaconBlock, error) {
+	var res *spec.VersionedSignedBeaconBlock
+	if err := s.replay(ctx, "SignedBeaconBlock", []interface{}{blockID}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SlotDuration provides the duration of a slot of the chain.
+func (s *Service) SlotDuration(ctx context.Context) (time.Duration, error) {
+	var res time.Duration
+	if err := s.replay(ctx, "SlotDuration", nil, &res); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// SlotsPerEpoch provides the slots per epoch of the chain.
+func (s *Service) SlotsPerEpoch(ctx context.Context) (uint64, error) {
+	var res uint64
+	if err := s.replay(ctx, "SlotsPerEpoch", nil, &res); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// SyncCommitteeContribution provides a sync committee contribution.
+func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slot, subcommitteeIndex uint64, beaconBlockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	var res *altair.SyncCommitteeContribution
+	if err := s.replay(ctx, "SyncCommitteeContribution", []interface{}{slot, subcommitteeIndex, beaconBlockRoot}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SyncCommitteeDuties obtains sync committee duties.
+func (s *Service) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*apiv1.SyncCommitteeDuty, error) {
+	var res []*apiv1.SyncCommitteeDuty
+	if err := s.replay(ctx, "SyncCommitteeDuties", []interface{}{epoch, validatorIndices}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TargetAggregatorsPerCommittee provides the target number of aggregators for each attestation committee.
+func (s *Service) TargetAggregatorsPerCommittee(ctx context.Context) (uint64, error) {
+	var res uint64
+	if err := s.replay(ctx, "TargetAggregatorsPerCommittee", nil, &res); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// Validators provides the validators, with their balance and status, for a given state.
+func (s *Service) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	var res map[phase0.ValidatorIndex]*apiv1.Validator
+	if err := s.replay(ctx, "Validators", []interface{}{stateID, validatorIndices}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ValidatorsByPubKey provides the validators, with their balance and status, for a given state.
+func (s *Service) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
+	var res map[phase0.ValidatorIndex]*apiv1.Validator
+	if err := s.replay(ctx, "ValidatorsByPubKey", []interface{}{stateID, validatorPubKeys}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubmitAggregateAttestations submits aggregate attestations.
+func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregateAndProofs []*phase0.SignedAggregateAndProof) error {
+	return s.replay(ctx, "SubmitAggregateAttestations", []interface{}{aggregateAndProofs}, nil)
+}
+
+// SubmitAttestations submits attestations.
+func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	return s.replay(ctx, "SubmitAttestations", []interface{}{attestations}, nil)
+}
+
+// SubmitBeaconBlock submits a beacon block.
+func (s *Service) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
+	return s.replay(ctx, "SubmitBeaconBlock", []interface{}{block}, nil)
+}
+
+// SubmitBeaconCommitteeSubscriptions subscribes to beacon committees.
+func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.BeaconCommitteeSubscription) error {
+	return s.replay(ctx, "SubmitBeaconCommitteeSubscriptions", []interface{}{subscriptions}, nil)
+}
+
+// SubmitProposalPreparations submits proposal preparations.
+func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
+	return s.replay(ctx, "SubmitProposalPreparations", []interface{}{preparations}, nil)
+}
+
+// SubmitSyncCommitteeContributions submits sync committee contributions.
+func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	return s.replay(ctx, "SubmitSyncCommitteeContributions", []interface{}{contributionAndProofs}, nil)
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	return s.replay(ctx, "SubmitSyncCommitteeMessages", []interface{}{messages}, nil)
+}
+
+// SubmitSyncCommitteeSubscriptions subscribes to sync committees.
+func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.SyncCommitteeSubscription) error {
+	return s.replay(ctx, "SubmitSyncCommitteeSubscriptions", []interface{}{subscriptions}, nil)
+}
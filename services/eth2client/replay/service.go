@@ -0,0 +1,225 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/eth2client/recording"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// maxRecordSize is the maximum size of a single record in the recording.
+const maxRecordSize = 64 * 1024 * 1024
+
+// Service is a beacon node client that serves responses from a recording.
+type Service struct {
+	path   string
+	delays bool
+
+	mu sync.Mutex
+	// records are the recorded responses, keyed by method and request, in the order recorded.
+	records map[string][]*recording.Record
+	// events are the recorded events, in the order received.
+	events   []*recording.Record
+	handlers map[string][]eth2client.EventHandlerFunc
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new replay client.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "eth2client").Str("impl", "replay").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	s := &Service{
+		path:     parameters.path,
+		delays:   parameters.delays,
+		records:  make(map[string][]*recording.Record),
+		events:   make([]*recording.Record, 0),
+		handlers: make(map[string][]eth2client.EventHandlerFunc),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// load loads the recording.
+func (s *Service) load() error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return errors.Wrap(err, "failed to open recording")
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), maxRecordSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &recording.Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to parse record on line %d", line))
+		}
+		if record.Method == recording.EventMethod {
+			s.events = append(s.events, record)
+			continue
+		}
+		key := recordKey(record.Method, record.Request)
+		s.records[key] = append(s.records[key], record)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "failed to read recording")
+	}
+	log.Trace().Int("lines", line).Int("events", len(s.events)).Msg("Loaded recording")
+
+	return nil
+}
+
+// recordKey provides the key for a record.
+func recordKey(method string, request json.RawMessage) string {
+	return fmt.Sprintf("%s:%s", method, string(request))
+}
+
+// replay serves the recorded response for the given method and request.
+// Responses for the same method and request are served in the order recorded, with the last repeated
+// once all have been served, so a replay is deterministic however many times a request is made.
+func (s *Service) replay(ctx context.Context, method string, request []interface{}, res interface{}) error {
+	var data json.RawMessage
+	if request != nil {
+		var err error
+		data, err = json.Marshal(request)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request")
+		}
+	}
+
+	key := recordKey(method, data)
+	s.mu.Lock()
+	records := s.records[key]
+	if len(records) == 0 {
+		s.mu.Unlock()
+		return fmt.Errorf("no recorded response for %s", method)
+	}
+	record := records[0]
+	if len(records) > 1 {
+		s.records[key] = records[1:]
+	}
+	s.mu.Unlock()
+
+	if s.delays && record.Duration > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(record.Duration):
+		}
+	}
+
+	if record.Error != "" {
+		return errors.New(record.Error)
+	}
+	if res != nil && len(record.Response) > 0 {
+		if err := json.Unmarshal(record.Response, res); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to unmarshal recorded response for %s", method))
+		}
+	}
+
+	return nil
+}
+
+// ReplayEvents sends the recorded events to the handlers subscribed to their topics, in the order recorded.
+// Events are not sent when subscribed, so that the caller controls when they arrive.
+func (s *Service) ReplayEvents() error {
+	s.mu.Lock()
+	events := s.events
+	handlers := make(map[string][]eth2client.EventHandlerFunc, len(s.handlers))
+	for topic, topicHandlers := range s.handlers {
+		handlers[topic] = topicHandlers
+	}
+	s.mu.Unlock()
+
+	for _, record := range events {
+		event, err := decodeEvent(record)
+		if err != nil {
+			return err
+		}
+		for _, handler := range handlers[event.Topic] {
+			handler(event)
+		}
+	}
+
+	return nil
+}
+
+// decodeEvent decodes a recorded event.
+func decodeEvent(record *recording.Record) (*apiv1.Event, error) {
+	var topic string
+	if err := json.Unmarshal(record.Request, &topic); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal event topic")
+	}
+
+	var data interface{}
+	switch topic {
+	case "head":
+		data = &apiv1.HeadEvent{}
+	case "block":
+		data = &apiv1.BlockEvent{}
+	case "attestation":
+		data = &phase0.Attestation{}
+	case "voluntary_exit":
+		data = &phase0.SignedVoluntaryExit{}
+	case "finalized_checkpoint":
+		data = &apiv1.FinalizedCheckpointEvent{}
+	case "chain_reorg":
+		data = &apiv1.ChainReorgEvent{}
+	case "contribution_and_proof":
+		data = &altair.SignedContributionAndProof{}
+	default:
+		return nil, fmt.Errorf("unsupported event topic %s", topic)
+	}
+	if err := json.Unmarshal(record.Response, data); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to unmarshal %s event", topic))
+	}
+
+	return &apiv1.Event{
+		Topic: topic,
+		Data:  data,
+	}, nil
+}
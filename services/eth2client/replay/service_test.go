@@ -0,0 +1,208 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/eth2client/recording"
+	"github.com/attestantio/vouch/services/eth2client/replay"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// client is a minimal beacon node client.
+type client struct {
+	calls   int
+	handler eth2client.EventHandlerFunc
+}
+
+func (*client) Name() string {
+	return "mock"
+}
+
+func (*client) Address() string {
+	return "localhost:5052"
+}
+
+// AttestationData returns the number of calls made as the committee index, so that responses differ.
+func (c *client) AttestationData(_ context.Context, slot phase0.Slot, _ phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	c.calls++
+	return &phase0.AttestationData{
+		Slot:            slot,
+		Index:           phase0.CommitteeIndex(c.calls),
+		BeaconBlockRoot: phase0.Root{0x01},
+		Source:          &phase0.Checkpoint{},
+		Target:          &phase0.Checkpoint{},
+	}, nil
+}
+
+func (*client) Genesis(_ context.Context) (*apiv1.Genesis, error) {
+	return nil, errors.New("not available")
+}
+
+func (*client) Spec(ctx context.Context) (map[string]interface{}, error) {
+	return mock.NewSpecProvider().Spec(ctx)
+}
+
+func (*client) SubmitAttestations(_ context.Context, _ []*phase0.Attestation) error {
+	return nil
+}
+
+func (c *client) Events(_ context.Context, _ []string, handler eth2client.EventHandlerFunc) error {
+	c.handler = handler
+	return nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []replay.Parameter
+		err    string
+	}{
+		{
+			name: "PathMissing",
+			params: []replay.Parameter{
+				replay.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "PathInvalid",
+			params: []replay.Parameter{
+				replay.WithLogLevel(zerolog.Disabled),
+				replay.WithPath(filepath.Join(t.TempDir(), "missing.json")),
+			},
+			err: "failed to open recording: open " + filepath.Join(t.TempDir(), "missing.json") + ": no such file or directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := replay.New(ctx, test.params...)
+			if test.err != "" {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInvalidRecording(t *testing.T) {
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}\nbad\n"), 0o600))
+
+	_, err := replay.New(ctx,
+		replay.WithLogLevel(zerolog.Disabled),
+		replay.WithPath(path),
+	)
+	require.EqualError(t, err, "failed to parse record on line 2: invalid character 'b' looking for beginning of value")
+}
+
+func TestReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+	c := &client{}
+	recorder, err := recording.New(ctx,
+		recording.WithLogLevel(zerolog.Disabled),
+		recording.WithClient(c),
+		recording.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	// Record some interactions.
+	data1, err := recorder.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	data2, err := recorder.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	data3, err := recorder.AttestationData(ctx, 2, 0)
+	require.NoError(t, err)
+	_, err = recorder.Genesis(ctx)
+	require.EqualError(t, err, "not available")
+	spec, err := recorder.Spec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, recorder.SubmitAttestations(ctx, []*phase0.Attestation{}))
+	require.NoError(t, recorder.Events(ctx, []string{"head"}, func(*apiv1.Event) {}))
+	c.handler(&apiv1.Event{
+		Topic: "head",
+		Data: &apiv1.HeadEvent{
+			Slot:                      1,
+			Block:                     phase0.Root{0x01},
+			State:                     phase0.Root{0x02},
+			PreviousDutyDependentRoot: phase0.Root{0x03},
+			CurrentDutyDependentRoot:  phase0.Root{0x04},
+		},
+	})
+
+	// Replay them.
+	s, err := replay.New(ctx,
+		replay.WithLogLevel(zerolog.Disabled),
+		replay.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	// Responses are served in the order recorded, with the last repeated.
+	res, err := s.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, data1, res)
+	res, err = s.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, data2, res)
+	res, err = s.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, data2, res)
+	res, err = s.AttestationData(ctx, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, data3, res)
+
+	// Unrecorded requests are rejected.
+	_, err = s.AttestationData(ctx, 3, 0)
+	require.EqualError(t, err, "no recorded response for AttestationData")
+
+	// Errors are replayed.
+	_, err = s.Genesis(ctx)
+	require.EqualError(t, err, "not available")
+
+	// Spec values keep their types.
+	replayedSpec, err := s.Spec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, spec, replayedSpec)
+
+	require.NoError(t, s.SubmitAttestations(ctx, []*phase0.Attestation{}))
+
+	// Events are sent when requested.
+	events := make([]*apiv1.Event, 0)
+	require.NoError(t, s.Events(ctx, []string{"head"}, func(event *apiv1.Event) {
+		events = append(events, event)
+	}))
+	require.Len(t, events, 0)
+	require.NoError(t, s.ReplayEvents())
+	require.Len(t, events, 1)
+	require.Equal(t, phase0.Slot(1), events[0].Data.(*apiv1.HeadEvent).Slot)
+}
@@ -0,0 +1,74 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package switchable
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is a beacon node client that passes requests to a client that can be switched at runtime.
+type Service struct {
+	name     string
+	clientMu sync.RWMutex
+	client   interface{}
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new switchable client.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "eth2client").Str("impl", "switchable").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	return &Service{
+		name:   parameters.name,
+		client: parameters.client,
+	}, nil
+}
+
+// Switch switches to the given client.
+// Requests already in progress complete with the previous client.
+func (s *Service) Switch(client interface{}) error {
+	if client == nil {
+		return errors.New("no client supplied")
+	}
+
+	s.clientMu.Lock()
+	s.client = client
+	s.clientMu.Unlock()
+	log.Debug().Str("name", s.name).Msg("Switched client")
+
+	return nil
+}
+
+// current provides the current client.
+func (s *Service) current() interface{} {
+	s.clientMu.RLock()
+	defer s.clientMu.RUnlock()
+	return s.client
+}
@@ -0,0 +1,183 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package switchable_test
+
+import (
+	"context"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/beaconblockproposer"
+	"github.com/attestantio/vouch/services/eth2client/switchable"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// client is a minimal beacon node client.
+type client struct {
+	name string
+	// index is returned as the committee index of attestation data, to identify the client.
+	index phase0.CommitteeIndex
+}
+
+func (c *client) Name() string {
+	return c.name
+}
+
+func (*client) Address() string {
+	return "localhost:5052"
+}
+
+func (c *client) AttestationData(_ context.Context, slot phase0.Slot, _ phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	return &phase0.AttestationData{
+		Slot:   slot,
+		Index:  c.index,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []switchable.Parameter
+		err    string
+	}{
+		{
+			name: "NameMissing",
+			params: []switchable.Parameter{
+				switchable.WithLogLevel(zerolog.Disabled),
+				switchable.WithClient(&client{}),
+			},
+			err: "problem with parameters: no name specified",
+		},
+		{
+			name: "ClientMissing",
+			params: []switchable.Parameter{
+				switchable.WithLogLevel(zerolog.Disabled),
+				switchable.WithName("test"),
+			},
+			err: "problem with parameters: no client specified",
+		},
+		{
+			name: "Good",
+			params: []switchable.Parameter{
+				switchable.WithLogLevel(zerolog.Disabled),
+				switchable.WithName("test"),
+				switchable.WithClient(&client{}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := switchable.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := switchable.New(ctx,
+		switchable.WithLogLevel(zerolog.Disabled),
+		switchable.WithName("test"),
+		switchable.WithClient(&client{name: "first", index: 1}),
+	)
+	require.NoError(t, err)
+
+	data, err := s.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, phase0.CommitteeIndex(1), data.Index)
+	require.Equal(t, "first", s.Name())
+
+	require.EqualError(t, s.Switch(nil), "no client supplied")
+	require.NoError(t, s.Switch(&client{name: "second", index: 2}))
+
+	data, err = s.AttestationData(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, phase0.CommitteeIndex(2), data.Index)
+	require.Equal(t, "second", s.Name())
+
+	// Requests the client does not support are rejected.
+	_, err = s.AggregateAttestation(ctx, 1, phase0.Root{})
+	require.EqualError(t, err, "client is not an aggregate attestation provider")
+}
+
+func TestInterfaces(t *testing.T) {
+	s, err := switchable.New(context.Background(),
+		switchable.WithLogLevel(zerolog.Disabled),
+		switchable.WithName("test"),
+		switchable.WithClient(&client{}),
+	)
+	require.NoError(t, err)
+
+	// The switchable client must provide every interface used by Vouch.
+	var _ eth2client.AggregateAttestationProvider = s
+	var _ eth2client.AggregateAttestationsSubmitter = s
+	var _ eth2client.AttestationDataProvider = s
+	var _ eth2client.AttestationsSubmitter = s
+	var _ eth2client.AttesterDutiesProvider = s
+	var _ eth2client.BeaconBlockHeadersProvider = s
+	var _ eth2client.BeaconBlockProposalProvider = s
+	var _ eth2client.BeaconBlockRootProvider = s
+	var _ eth2client.BeaconBlockSubmitter = s
+	var _ eth2client.BeaconCommitteeSubscriptionsSubmitter = s
+	var _ eth2client.DomainProvider = s
+	var _ eth2client.EventsProvider = s
+	var _ eth2client.FarFutureEpochProvider = s
+	var _ eth2client.ForkScheduleProvider = s
+	var _ eth2client.GenesisTimeProvider = s
+	var _ eth2client.ProposalPreparationsSubmitter = s
+	var _ eth2client.ProposerDutiesProvider = s
+	var _ eth2client.SignedBeaconBlockProvider = s
+	var _ eth2client.SlotDurationProvider = s
+	var _ eth2client.SlotsPerEpochProvider = s
+	var _ eth2client.SpecProvider = s
+	var _ eth2client.SyncCommitteeContributionProvider = s
+	var _ eth2client.SyncCommitteeContributionsSubmitter = s
+	var _ eth2client.SyncCommitteeDutiesProvider = s
+	var _ eth2client.SyncCommitteeMessagesSubmitter = s
+	var _ eth2client.SyncCommitteeSubscriptionsSubmitter = s
+	var _ eth2client.TargetAggregatorsPerCommitteeProvider = s
+	var _ eth2client.ValidatorsProvider = s
+	var _ eth2client.NodeClientProvider = s
+	var _ eth2client.NodeVersionProvider = s
+	var _ eth2client.GenesisProvider = s
+	var _ eth2client.ForkProvider = s
+	var _ eth2client.NodeSyncingProvider = s
+	var _ beaconblockproposer.RankedBeaconBlockProposalsProvider = s
+}
+
+func TestRankedBeaconBlockProposals(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := switchable.New(ctx,
+		switchable.WithLogLevel(zerolog.Disabled),
+		switchable.WithName("test"),
+		switchable.WithClient(&client{}),
+	)
+	require.NoError(t, err)
+
+	_, err = s.RankedBeaconBlockProposals(ctx, 1, phase0.BLSSignature{}, nil)
+	require.EqualError(t, err, "client is not a beacon block proposal provider")
+}
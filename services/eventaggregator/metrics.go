@@ -0,0 +1,76 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventaggregator
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var events *prometheus.CounterVec
+var delays *prometheus.HistogramVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if events != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	events = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "eventaggregator",
+		Name:      "events_total",
+		Help:      "The number of events received from each beacon node, and whether the beacon node was first to report them.",
+	}, []string{"topic", "address", "first"})
+	if err := prometheus.Register(events); err != nil {
+		return err
+	}
+
+	delays = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "eventaggregator",
+		Name:      "delay_seconds",
+		Help:      "The time between the first beacon node reporting an event and each beacon node reporting it.",
+		Buckets: []float64{
+			0.0, 0.05, 0.1, 0.2, 0.5,
+			1.0, 2.0, 4.0, 8.0, 12.0,
+		},
+	}, []string{"topic", "address"})
+	return prometheus.Register(delays)
+}
+
+func monitorEvent(topic string, address string, first bool, delay time.Duration) {
+	if events == nil {
+		return
+	}
+	if first {
+		events.WithLabelValues(topic, address, "true").Inc()
+	} else {
+		events.WithLabelValues(topic, address, "false").Inc()
+	}
+	delays.WithLabelValues(topic, address).Observe(delay.Seconds())
+}
@@ -0,0 +1,321 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventaggregator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// retainSlots is the number of slots for which events are remembered.
+const retainSlots = 64
+
+// eventKey identifies an event regardless of the beacon node that reported it.
+type eventKey struct {
+	topic string
+	slot  phase0.Slot
+	root  phase0.Root
+}
+
+// subscription is the context of the subscriptions to a beacon node.
+type subscription struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Service aggregates events from multiple beacon nodes.
+type Service struct {
+	chainTime chaintime.Service
+
+	mu              sync.Mutex
+	eventsProviders map[string]eth2client.EventsProvider
+	addresses       []string
+	// subscriptionCtx is the context for subscriptions, taken from the first request for events.
+	subscriptionCtx context.Context
+	// subscriptions are the contexts of the subscriptions to each beacon node.
+	subscriptions map[string]*subscription
+	handlers      map[string][]eth2client.EventHandlerFunc
+	seen          map[eventKey]time.Time
+	maxSlot       phase0.Slot
+	headSlot      phase0.Slot
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new event aggregator.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "eventaggregator").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	addresses := make([]string, 0, len(parameters.eventsProviders))
+	for address := range parameters.eventsProviders {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	s := &Service{
+		chainTime:       parameters.chainTime,
+		eventsProviders: parameters.eventsProviders,
+		addresses:       addresses,
+		subscriptions:   make(map[string]*subscription),
+		handlers:        make(map[string][]eth2client.EventHandlerFunc),
+		seen:            make(map[eventKey]time.Time),
+	}
+
+	return s, nil
+}
+
+// Events feeds requested events with the given topics to the supplied handler.
+// Supported topics are "head", "block" and "chain_reorg".
+func (s *Service) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	if handler == nil {
+		return errors.New("no handler supplied")
+	}
+
+	newTopics := make([]string, 0, len(topics))
+	s.mu.Lock()
+	for _, topic := range topics {
+		switch topic {
+		case "head", "block", "chain_reorg":
+		default:
+			s.mu.Unlock()
+			return fmt.Errorf("unsupported topic %s", topic)
+		}
+		if _, exists := s.handlers[topic]; !exists {
+			newTopics = append(newTopics, topic)
+		}
+		s.handlers[topic] = append(s.handlers[topic], handler)
+	}
+	if s.subscriptionCtx == nil {
+		s.subscriptionCtx = ctx
+	}
+	addresses := s.addresses
+	s.mu.Unlock()
+
+	if len(newTopics) == 0 {
+		// Already subscribed to the beacon nodes.
+		return nil
+	}
+
+	subscribed := 0
+	for _, address := range addresses {
+		if err := s.subscribe(address, newTopics); err != nil {
+			log.Warn().Str("address", address).Strs("topics", newTopics).Err(err).Msg("Failed to subscribe to events")
+			continue
+		}
+		subscribed++
+	}
+	if subscribed == 0 {
+		// Forget the topics, so that a later request can try again.
+		s.mu.Lock()
+		for _, topic := range newTopics {
+			delete(s.handlers, topic)
+		}
+		s.mu.Unlock()
+		return errors.New("failed to subscribe to events on any beacon node")
+	}
+	log.Trace().Strs("topics", newTopics).Int("beacon_nodes", subscribed).Msg("Subscribed to events")
+
+	return nil
+}
+
+// subscribe subscribes to the given topics on the beacon node with the given address.
+func (s *Service) subscribe(address string, topics []string) error {
+	s.mu.Lock()
+	eventsProvider, exists := s.eventsProviders[address]
+	if !exists {
+		s.mu.Unlock()
+		return errors.New("unknown beacon node")
+	}
+	sub, exists := s.subscriptions[address]
+	if !exists {
+		ctx, cancel := context.WithCancel(s.subscriptionCtx)
+		sub = &subscription{ctx: ctx, cancel: cancel}
+		s.subscriptions[address] = sub
+	}
+	s.mu.Unlock()
+
+	return eventsProvider.Events(sub.ctx, topics, s.eventHandler(address))
+}
+
+// SetEventsProviders sets the beacon nodes from which events are obtained.
+// Beacon nodes that are added are subscribed to the topics already requested, and
+// subscriptions to beacon nodes that are removed are cancelled.
+func (s *Service) SetEventsProviders(eventsProviders map[string]eth2client.EventsProvider) error {
+	if len(eventsProviders) == 0 {
+		return errors.New("no events providers specified")
+	}
+
+	addresses := make([]string, 0, len(eventsProviders))
+	for address := range eventsProviders {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	s.mu.Lock()
+	added := make([]string, 0)
+	for _, address := range addresses {
+		if _, exists := s.eventsProviders[address]; !exists {
+			added = append(added, address)
+		}
+	}
+	removed := make([]string, 0)
+	for _, address := range s.addresses {
+		if _, exists := eventsProviders[address]; !exists {
+			removed = append(removed, address)
+			if sub, exists := s.subscriptions[address]; exists {
+				sub.cancel()
+				delete(s.subscriptions, address)
+			}
+		}
+	}
+	s.eventsProviders = eventsProviders
+	s.addresses = addresses
+	topics := make([]string, 0, len(s.handlers))
+	for topic := range s.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	s.mu.Unlock()
+
+	if len(topics) > 0 {
+		for _, address := range added {
+			if err := s.subscribe(address, topics); err != nil {
+				log.Warn().Str("address", address).Strs("topics", topics).Err(err).Msg("Failed to subscribe to events")
+			}
+		}
+	}
+	log.Debug().Strs("added", added).Strs("removed", removed).Msg("Updated beacon nodes")
+
+	return nil
+}
+
+// eventHandler provides the handler for events from the beacon node with the given address.
+func (s *Service) eventHandler(address string) eth2client.EventHandlerFunc {
+	return func(event *apiv1.Event) {
+		s.handleEvent(address, event)
+	}
+}
+
+// handleEvent handles an event from a beacon node, passing it on if no other beacon node has reported it.
+func (s *Service) handleEvent(address string, event *apiv1.Event) {
+	if event == nil || event.Data == nil {
+		return
+	}
+	received := time.Now()
+
+	key, err := keyForEvent(event)
+	if err != nil {
+		log.Debug().Str("address", address).Str("topic", event.Topic).Err(err).Msg("Invalid event")
+		return
+	}
+
+	s.mu.Lock()
+	if s.stale(key) {
+		s.mu.Unlock()
+		log.Trace().Str("address", address).Str("topic", key.topic).Uint64("slot", uint64(key.slot)).Msg("Stale event")
+		return
+	}
+	first, seen := s.seen[key]
+	if seen {
+		s.mu.Unlock()
+		delay := received.Sub(first)
+		log.Trace().Str("address", address).Str("topic", key.topic).Uint64("slot", uint64(key.slot)).Dur("delay", delay).Msg("Duplicate event")
+		monitorEvent(key.topic, address, false, delay)
+		return
+	}
+	s.seen[key] = received
+	if key.topic == "head" && key.slot > s.headSlot {
+		s.headSlot = key.slot
+	}
+	if key.slot > s.maxSlot {
+		s.maxSlot = key.slot
+		s.prune()
+	}
+	handlers := s.handlers[key.topic]
+	s.mu.Unlock()
+
+	log.Trace().Str("address", address).Str("topic", key.topic).Uint64("slot", uint64(key.slot)).Dur("slot_delay", received.Sub(s.chainTime.StartOfSlot(key.slot))).Msg("New event")
+	monitorEvent(key.topic, address, true, 0)
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// stale returns true if the event is too old to be passed on.  This covers events that are
+// older than those remembered, which would otherwise be reported again, and head events for
+// slots before the current head.
+// This assumes that the lock is held.
+func (s *Service) stale(key eventKey) bool {
+	if s.maxSlot >= retainSlots && key.slot < s.maxSlot-retainSlots {
+		return true
+	}
+	if key.topic == "head" && key.slot < s.headSlot {
+		return true
+	}
+
+	return false
+}
+
+// prune removes events that are too old to be reported again.
+// This assumes that the lock is held.
+func (s *Service) prune() {
+	if s.maxSlot < retainSlots {
+		return
+	}
+	minSlot := s.maxSlot - retainSlots
+	for key := range s.seen {
+		if key.slot < minSlot {
+			delete(s.seen, key)
+		}
+	}
+}
+
+// keyForEvent provides the key for an event.
+func keyForEvent(event *apiv1.Event) (eventKey, error) {
+	switch data := event.Data.(type) {
+	case *apiv1.HeadEvent:
+		return eventKey{topic: event.Topic, slot: data.Slot, root: data.Block}, nil
+	case *apiv1.BlockEvent:
+		return eventKey{topic: event.Topic, slot: data.Slot, root: data.Block}, nil
+	case *apiv1.ChainReorgEvent:
+		return eventKey{topic: event.Topic, slot: data.Slot, root: data.NewHeadBlock}, nil
+	default:
+		return eventKey{}, fmt.Errorf("unhandled event data type %T", event.Data)
+	}
+}
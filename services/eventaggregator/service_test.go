@@ -0,0 +1,340 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventaggregator_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/eventaggregator"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// eventsProvider is an events provider whose events are sent manually.
+type eventsProvider struct {
+	mu       sync.Mutex
+	handlers map[string]eth2client.EventHandlerFunc
+	ctx      context.Context
+	err      error
+}
+
+func newEventsProvider() *eventsProvider {
+	return &eventsProvider{
+		handlers: make(map[string]eth2client.EventHandlerFunc),
+	}
+}
+
+func (p *eventsProvider) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ctx = ctx
+	for _, topic := range topics {
+		p.handlers[topic] = handler
+	}
+	return nil
+}
+
+func (p *eventsProvider) send(event *apiv1.Event) {
+	p.mu.Lock()
+	handler := p.handlers[event.Topic]
+	p.mu.Unlock()
+	if handler != nil {
+		handler(event)
+	}
+}
+
+func headEvent(slot phase0.Slot, root byte) *apiv1.Event {
+	return &apiv1.Event{
+		Topic: "head",
+		Data: &apiv1.HeadEvent{
+			Slot:  slot,
+			Block: phase0.Root{root},
+		},
+	}
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []eventaggregator.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []eventaggregator.Parameter{
+				eventaggregator.WithLogLevel(zerolog.Disabled),
+				eventaggregator.WithMonitor(nil),
+				eventaggregator.WithChainTime(chainTime),
+				eventaggregator.WithEventsProviders(map[string]eth2client.EventsProvider{"a": newEventsProvider()}),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []eventaggregator.Parameter{
+				eventaggregator.WithLogLevel(zerolog.Disabled),
+				eventaggregator.WithEventsProviders(map[string]eth2client.EventsProvider{"a": newEventsProvider()}),
+			},
+			err: "problem with parameters: no chain time service specified",
+		},
+		{
+			name: "EventsProvidersMissing",
+			params: []eventaggregator.Parameter{
+				eventaggregator.WithLogLevel(zerolog.Disabled),
+				eventaggregator.WithChainTime(chainTime),
+			},
+			err: "problem with parameters: no events providers specified",
+		},
+		{
+			name: "Good",
+			params: []eventaggregator.Parameter{
+				eventaggregator.WithLogLevel(zerolog.Disabled),
+				eventaggregator.WithChainTime(chainTime),
+				eventaggregator.WithEventsProviders(map[string]eth2client.EventsProvider{"a": newEventsProvider()}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := eventaggregator.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	node1 := newEventsProvider()
+	node2 := newEventsProvider()
+	node3 := newEventsProvider()
+	node3.err = errors.New("not available")
+	s, err := eventaggregator.New(ctx,
+		eventaggregator.WithLogLevel(zerolog.Disabled),
+		eventaggregator.WithChainTime(chainTime),
+		eventaggregator.WithEventsProviders(map[string]eth2client.EventsProvider{
+			"node1": node1,
+			"node2": node2,
+			"node3": node3,
+		}),
+	)
+	require.NoError(t, err)
+
+	require.EqualError(t, s.Events(ctx, []string{"attestation"}, func(*apiv1.Event) {}), "unsupported topic attestation")
+
+	received := make([]*apiv1.Event, 0)
+	require.NoError(t, s.Events(ctx, []string{"head", "block"}, func(event *apiv1.Event) {
+		received = append(received, event)
+	}))
+	otherReceived := 0
+	require.NoError(t, s.Events(ctx, []string{"head"}, func(*apiv1.Event) {
+		otherReceived++
+	}))
+
+	// First report is delivered.
+	node1.send(headEvent(1, 0x01))
+	require.Len(t, received, 1)
+	require.Equal(t, 1, otherReceived)
+
+	// Duplicate report is not delivered.
+	node2.send(headEvent(1, 0x01))
+	require.Len(t, received, 1)
+	require.Equal(t, 1, otherReceived)
+
+	// Same slot with a different root is delivered.
+	node2.send(headEvent(1, 0x02))
+	require.Len(t, received, 2)
+
+	// Block event for the same slot and root is delivered, as it is a different topic.
+	node2.send(&apiv1.Event{
+		Topic: "block",
+		Data: &apiv1.BlockEvent{
+			Slot:  1,
+			Block: phase0.Root{0x01},
+		},
+	})
+	require.Len(t, received, 3)
+	require.Equal(t, 2, otherReceived)
+
+	// Old events are not reported again.
+	node1.send(headEvent(100, 0x01))
+	require.Len(t, received, 4)
+	node1.send(headEvent(1, 0x01))
+	require.Len(t, received, 4)
+
+	// Events without data are ignored.
+	node1.send(&apiv1.Event{Topic: "head"})
+	require.Len(t, received, 4)
+}
+
+func TestStaleEvents(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	node1 := newEventsProvider()
+	node2 := newEventsProvider()
+	s, err := eventaggregator.New(ctx,
+		eventaggregator.WithLogLevel(zerolog.Disabled),
+		eventaggregator.WithChainTime(chainTime),
+		eventaggregator.WithEventsProviders(map[string]eth2client.EventsProvider{
+			"node1": node1,
+			"node2": node2,
+		}),
+	)
+	require.NoError(t, err)
+
+	received := make([]*apiv1.Event, 0)
+	require.NoError(t, s.Events(ctx, []string{"head", "block"}, func(event *apiv1.Event) {
+		received = append(received, event)
+	}))
+
+	blockEvent := func(slot phase0.Slot, root byte) *apiv1.Event {
+		return &apiv1.Event{
+			Topic: "block",
+			Data: &apiv1.BlockEvent{
+				Slot:  slot,
+				Block: phase0.Root{root},
+			},
+		}
+	}
+
+	node1.send(headEvent(100, 0x01))
+	require.Len(t, received, 1)
+
+	// A lagging beacon node reporting an earlier head is ignored.
+	node2.send(headEvent(99, 0x02))
+	require.Len(t, received, 1)
+
+	// A new head at the same slot is delivered.
+	node2.send(headEvent(100, 0x02))
+	require.Len(t, received, 2)
+
+	// A block within the retained slots is delivered.
+	node2.send(blockEvent(50, 0x03))
+	require.Len(t, received, 3)
+
+	// A block older than the retained slots is ignored, even though it has not been seen.
+	node2.send(blockEvent(35, 0x04))
+	require.Len(t, received, 3)
+}
+
+func TestEventsUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	node := newEventsProvider()
+	node.err = errors.New("not available")
+	s, err := eventaggregator.New(ctx,
+		eventaggregator.WithLogLevel(zerolog.Disabled),
+		eventaggregator.WithChainTime(chainTime),
+		eventaggregator.WithEventsProviders(map[string]eth2client.EventsProvider{
+			"node": node,
+		}),
+	)
+	require.NoError(t, err)
+
+	require.EqualError(t, s.Events(ctx, []string{"chain_reorg"}, func(*apiv1.Event) {}), "failed to subscribe to events on any beacon node")
+}
+
+func TestSetEventsProviders(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	node1 := newEventsProvider()
+	node2 := newEventsProvider()
+	s, err := eventaggregator.New(ctx,
+		eventaggregator.WithLogLevel(zerolog.Disabled),
+		eventaggregator.WithChainTime(chainTime),
+		eventaggregator.WithEventsProviders(map[string]eth2client.EventsProvider{
+			"node1": node1,
+			"node2": node2,
+		}),
+	)
+	require.NoError(t, err)
+
+	received := 0
+	require.NoError(t, s.Events(ctx, []string{"head"}, func(*apiv1.Event) {
+		received++
+	}))
+
+	require.EqualError(t, s.SetEventsProviders(nil), "no events providers specified")
+
+	// Replace node1 with node3.
+	node3 := newEventsProvider()
+	require.NoError(t, s.SetEventsProviders(map[string]eth2client.EventsProvider{
+		"node2": node2,
+		"node3": node3,
+	}))
+
+	// Subscription to the removed node is cancelled, and the added node is subscribed.
+	require.Error(t, node1.ctx.Err())
+	require.NoError(t, node2.ctx.Err())
+	require.NotNil(t, node3.handlers["head"])
+
+	node3.send(headEvent(1, 0x01))
+	require.Equal(t, 1, received)
+	node2.send(headEvent(1, 0x01))
+	require.Equal(t, 1, received)
+}
@@ -0,0 +1,39 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package null is a node health service that considers all beacon nodes healthy.
+package null
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/nodehealth"
+)
+
+// Service is a node health service that considers all beacon nodes healthy.
+type Service struct{}
+
+// New creates a new null node health service.
+func New(_ context.Context) *Service {
+	return &Service{}
+}
+
+// State provides the health state of the beacon node with the given address.
+func (*Service) State(_ string) nodehealth.State {
+	return nodehealth.StateHealthy
+}
+
+// Filter returns the addresses of the beacon nodes that should be used, in the order supplied.
+func (*Service) Filter(addresses []string) []string {
+	return addresses
+}
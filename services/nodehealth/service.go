@@ -0,0 +1,57 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodehealth
+
+import "context"
+
+// State is the health state of a beacon node.
+type State int
+
+const (
+	// StateHealthy is a beacon node that is fit for use.
+	StateHealthy State = iota
+	// StateDegraded is a beacon node that is used only if there are no healthy beacon nodes.
+	StateDegraded
+	// StateExcluded is a beacon node that is used only if all beacon nodes are excluded.
+	StateExcluded
+)
+
+// String provides the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateHealthy:
+		return "healthy"
+	case StateDegraded:
+		return "degraded"
+	case StateExcluded:
+		return "excluded"
+	default:
+		return "unknown"
+	}
+}
+
+// Service is the node health service.
+type Service interface {
+	// State provides the health state of the beacon node with the given address.
+	State(address string) State
+
+	// Filter returns the addresses of the beacon nodes that should be used, in the order supplied.
+	Filter(addresses []string) []string
+}
+
+// AddressesSetter is the interface for updating the beacon nodes whose health is checked.
+type AddressesSetter interface {
+	// SetAddresses sets the addresses of the beacon nodes to check, and the endpoints through which they are reached.
+	SetAddresses(ctx context.Context, addresses []string, endpoints map[string]string)
+}
@@ -0,0 +1,207 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+)
+
+// status is the status of a beacon node, as obtained from its API.
+type status struct {
+	headSlot     uint64
+	isSyncing    bool
+	isOptimistic bool
+	// peers is the number of connected peers; -1 if unknown.
+	peers int64
+}
+
+type syncingJSON struct {
+	Data *struct {
+		HeadSlot     string `json:"head_slot"`
+		IsSyncing    bool   `json:"is_syncing"`
+		IsOptimistic bool   `json:"is_optimistic"`
+	} `json:"data"`
+}
+
+type peerCountJSON struct {
+	Data *struct {
+		Connected string `json:"connected"`
+	} `json:"data"`
+}
+
+// checkHealth checks the health of each beacon node and updates its state.
+func (s *Service) checkHealth(ctx context.Context, _ interface{}) {
+	s.nodesMu.RLock()
+	addresses := make([]string, 0, len(s.nodes))
+	for address := range s.nodes {
+		addresses = append(addresses, address)
+	}
+	s.nodesMu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, address := range addresses {
+		wg.Add(1)
+		go func(ctx context.Context, address string) {
+			defer wg.Done()
+			state, reason := s.assess(ctx, address)
+			s.observe(address, state, reason)
+		}(ctx, address)
+	}
+	wg.Wait()
+
+	s.nodesMu.RLock()
+	for address, n := range s.nodes {
+		monitorState(address, n.state)
+	}
+	s.nodesMu.RUnlock()
+}
+
+// assess obtains the status of a beacon node and decides the state it should be in.
+func (s *Service) assess(ctx context.Context, address string) (nodehealth.State, string) {
+	status, err := s.nodeStatus(ctx, address)
+	if err != nil {
+		log.Debug().Str("address", address).Err(err).Msg("Failed to obtain node status")
+		return nodehealth.StateExcluded, "unreachable"
+	}
+
+	currentSlot := uint64(s.chainTime.CurrentSlot())
+	headLag := uint64(0)
+	if currentSlot > status.headSlot {
+		headLag = currentSlot - status.headSlot
+	}
+	log.Trace().
+		Str("address", address).
+		Uint64("head_lag", headLag).
+		Bool("syncing", status.isSyncing).
+		Bool("optimistic", status.isOptimistic).
+		Int64("peers", status.peers).
+		Msg("Obtained node status")
+
+	switch {
+	case status.isSyncing:
+		return nodehealth.StateExcluded, "syncing"
+	case status.isOptimistic && s.excludeOptimistic:
+		return nodehealth.StateExcluded, "optimistic"
+	case headLag > s.excludedHeadLag:
+		return nodehealth.StateExcluded, fmt.Sprintf("head %d slots behind", headLag)
+	case headLag > s.degradedHeadLag:
+		return nodehealth.StateDegraded, fmt.Sprintf("head %d slots behind", headLag)
+	case s.minPeers > 0 && status.peers >= 0 && uint64(status.peers) < s.minPeers:
+		return nodehealth.StateDegraded, fmt.Sprintf("only %d peers", status.peers)
+	default:
+		return nodehealth.StateHealthy, ""
+	}
+}
+
+// observe records the state assessed for a beacon node, changing its state once
+// enough consecutive checks agree.
+func (s *Service) observe(address string, state nodehealth.State, reason string) {
+	s.nodesMu.Lock()
+	defer s.nodesMu.Unlock()
+
+	n, exists := s.nodes[address]
+	if !exists {
+		return
+	}
+
+	if !n.checked {
+		// First check, so take the state as-is.
+		n.checked = true
+		n.state = state
+		if state != nodehealth.StateHealthy {
+			log.Warn().Str("address", address).Str("state", state.String()).Str("reason", reason).Msg("Beacon node is not healthy")
+		}
+		return
+	}
+
+	if state == n.state {
+		n.count = 0
+		return
+	}
+	if state == n.candidate {
+		n.count++
+	} else {
+		n.candidate = state
+		n.count = 1
+	}
+	if n.count < s.hysteresis {
+		log.Trace().Str("address", address).Str("state", n.state.String()).Str("candidate", state.String()).Int("count", n.count).Msg("Beacon node state pending change")
+		return
+	}
+
+	e := log.Info()
+	if state != nodehealth.StateHealthy {
+		e = log.Warn()
+	}
+	e.Str("address", address).Str("old_state", n.state.String()).Str("new_state", state.String()).Str("reason", reason).Msg("Beacon node changed state")
+	n.state = state
+	n.count = 0
+	monitorTransition(address, state)
+}
+
+// nodeStatus obtains the status of a beacon node.
+// The peer count is optional, as not all beacon nodes provide it.
+func (s *Service) nodeStatus(ctx context.Context, address string) (*status, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	syncing := &syncingJSON{}
+	if err := s.get(ctx, address, "/eth/v1/node/syncing", syncing); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain sync state")
+	}
+	if syncing.Data == nil {
+		return nil, errors.New("sync state missing")
+	}
+	headSlot, err := strconv.ParseUint(syncing.Data.HeadSlot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid head slot")
+	}
+
+	res := &status{
+		headSlot:     headSlot,
+		isSyncing:    syncing.Data.IsSyncing,
+		isOptimistic: syncing.Data.IsOptimistic,
+		peers:        -1,
+	}
+
+	peerCount := &peerCountJSON{}
+	if err := s.get(ctx, address, "/eth/v1/node/peer_count", peerCount); err != nil {
+		log.Trace().Str("address", address).Err(err).Msg("Failed to obtain peer count")
+	} else if peerCount.Data != nil {
+		if peers, err := strconv.ParseInt(peerCount.Data.Connected, 10, 64); err == nil {
+			res.peers = peers
+		}
+	}
+
+	return res, nil
+}
+
+// get fetches the given path from a beacon node and decodes the JSON response.
+func (s *Service) get(ctx context.Context, address string, path string, res interface{}) error {
+	s.nodesMu.RLock()
+	endpoint, exists := s.endpoints[address]
+	s.nodesMu.RUnlock()
+	if exists {
+		address = endpoint
+	}
+
+	return util.BeaconNodeGet(ctx, s.client, address, path, res)
+}
@@ -0,0 +1,64 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"testing"
+
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserve(t *testing.T) {
+	s := &Service{
+		hysteresis: 3,
+		nodes: map[string]*node{
+			"node": {},
+		},
+	}
+
+	// First check takes the state immediately.
+	s.observe("node", nodehealth.StateExcluded, "syncing")
+	require.Equal(t, nodehealth.StateExcluded, s.State("node"))
+
+	// Requires three consecutive checks to change.
+	s.observe("node", nodehealth.StateHealthy, "")
+	s.observe("node", nodehealth.StateHealthy, "")
+	require.Equal(t, nodehealth.StateExcluded, s.State("node"))
+	s.observe("node", nodehealth.StateHealthy, "")
+	require.Equal(t, nodehealth.StateHealthy, s.State("node"))
+
+	// An interruption resets the count.
+	s.observe("node", nodehealth.StateDegraded, "behind")
+	s.observe("node", nodehealth.StateDegraded, "behind")
+	s.observe("node", nodehealth.StateHealthy, "")
+	s.observe("node", nodehealth.StateDegraded, "behind")
+	s.observe("node", nodehealth.StateDegraded, "behind")
+	require.Equal(t, nodehealth.StateHealthy, s.State("node"))
+	s.observe("node", nodehealth.StateDegraded, "behind")
+	require.Equal(t, nodehealth.StateDegraded, s.State("node"))
+
+	// A change of candidate restarts the count.
+	s.observe("node", nodehealth.StateExcluded, "syncing")
+	s.observe("node", nodehealth.StateExcluded, "syncing")
+	s.observe("node", nodehealth.StateHealthy, "")
+	require.Equal(t, nodehealth.StateDegraded, s.State("node"))
+	s.observe("node", nodehealth.StateHealthy, "")
+	s.observe("node", nodehealth.StateHealthy, "")
+	require.Equal(t, nodehealth.StateHealthy, s.State("node"))
+
+	// Unknown nodes are ignored.
+	s.observe("unknown", nodehealth.StateExcluded, "syncing")
+	require.Equal(t, nodehealth.StateHealthy, s.State("unknown"))
+}
@@ -0,0 +1,80 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var stateGauges *prometheus.GaugeVec
+var transitions *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if stateGauges != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	stateGauges = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "nodehealth",
+		Name:      "state",
+		Help:      "The health state of each beacon node; 1 for the current state, otherwise 0.",
+	}, []string{"address", "state"})
+	if err := prometheus.Register(stateGauges); err != nil {
+		return err
+	}
+
+	transitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "nodehealth",
+		Name:      "transitions_total",
+		Help:      "The number of times each beacon node has changed to each health state.",
+	}, []string{"address", "state"})
+	return prometheus.Register(transitions)
+}
+
+func monitorState(address string, state nodehealth.State) {
+	if stateGauges == nil {
+		return
+	}
+	for _, s := range states {
+		if s == state {
+			stateGauges.WithLabelValues(address, s.String()).Set(1)
+		} else {
+			stateGauges.WithLabelValues(address, s.String()).Set(0)
+		}
+	}
+}
+
+func monitorTransition(address string, state nodehealth.State) {
+	if transitions == nil {
+		return
+	}
+	transitions.WithLabelValues(address, state.String()).Inc()
+}
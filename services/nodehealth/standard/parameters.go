@@ -0,0 +1,192 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standard periodically checks the health of beacon nodes, so that
+// nodes that are syncing, optimistic or behind the chain are not used by the
+// strategies and submitters while healthier nodes are available.
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/scheduler"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel          zerolog.Level
+	monitor           metrics.Service
+	scheduler         scheduler.Service
+	chainTime         chaintime.Service
+	timeout           time.Duration
+	interval          time.Duration
+	addresses         []string
+	endpoints         map[string]string
+	degradedHeadLag   uint64
+	excludedHeadLag   uint64
+	minPeers          uint64
+	excludeOptimistic bool
+	hysteresis        int
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithScheduler sets the scheduler for the module.
+func WithScheduler(scheduler scheduler.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scheduler = scheduler
+	})
+}
+
+// WithChainTime sets the chaintime service, used to calculate head lag.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithTimeout sets the timeout for requests to beacon nodes.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithInterval sets the interval between health checks.
+func WithInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.interval = interval
+	})
+}
+
+// WithAddresses sets the addresses of the beacon nodes to check at startup.
+// Other beacon nodes are checked once they are seen by the service.
+func WithAddresses(addresses []string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.addresses = addresses
+	})
+}
+
+// WithEndpoints sets the endpoints through which beacon nodes are reached, keyed by address.
+// Beacon nodes without an endpoint are reached directly at their address.
+func WithEndpoints(endpoints map[string]string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.endpoints = endpoints
+	})
+}
+
+// WithDegradedHeadLag sets the number of slots a beacon node's head can lag the current slot before it is degraded.
+func WithDegradedHeadLag(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.degradedHeadLag = slots
+	})
+}
+
+// WithExcludedHeadLag sets the number of slots a beacon node's head can lag the current slot before it is excluded.
+func WithExcludedHeadLag(slots uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.excludedHeadLag = slots
+	})
+}
+
+// WithMinPeers sets the number of peers below which a beacon node is degraded.
+// 0 disables the check.
+func WithMinPeers(peers uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.minPeers = peers
+	})
+}
+
+// WithExcludeOptimistic sets whether beacon nodes with an optimistic head are excluded.
+func WithExcludeOptimistic(excludeOptimistic bool) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.excludeOptimistic = excludeOptimistic
+	})
+}
+
+// WithHysteresis sets the number of consecutive checks that must agree before a beacon node changes state.
+func WithHysteresis(checks int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.hysteresis = checks
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:          zerolog.GlobalLevel(),
+		monitor:           nullmetrics.New(context.Background()),
+		interval:          12 * time.Second,
+		degradedHeadLag:   2,
+		excludedHeadLag:   8,
+		excludeOptimistic: true,
+		hysteresis:        3,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.scheduler == nil {
+		return nil, errors.New("no scheduler specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	if parameters.excludedHeadLag < parameters.degradedHeadLag {
+		return nil, errors.New("excluded head lag cannot be less than degraded head lag")
+	}
+	if parameters.hysteresis < 1 {
+		return nil, errors.New("hysteresis must be at least 1")
+	}
+
+	return &parameters, nil
+}
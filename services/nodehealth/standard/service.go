@@ -0,0 +1,185 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+var states = []nodehealth.State{nodehealth.StateHealthy, nodehealth.StateDegraded, nodehealth.StateExcluded}
+
+type node struct {
+	state nodehealth.State
+	// candidate is the state to which the node is moving, and count the number of consecutive checks that agree.
+	candidate nodehealth.State
+	count     int
+	// checked is true once the node has been checked.
+	checked bool
+}
+
+// Service checks the health of beacon nodes.
+type Service struct {
+	chainTime         chaintime.Service
+	timeout           time.Duration
+	client            *http.Client
+	endpoints         map[string]string
+	degradedHeadLag   uint64
+	excludedHeadLag   uint64
+	minPeers          uint64
+	excludeOptimistic bool
+	hysteresis        int
+
+	nodesMu sync.RWMutex
+	nodes   map[string]*node
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new node health service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "nodehealth").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		chainTime:         parameters.chainTime,
+		timeout:           parameters.timeout,
+		client:            util.NewBeaconNodeHTTPClient(parameters.timeout),
+		endpoints:         parameters.endpoints,
+		degradedHeadLag:   parameters.degradedHeadLag,
+		excludedHeadLag:   parameters.excludedHeadLag,
+		minPeers:          parameters.minPeers,
+		excludeOptimistic: parameters.excludeOptimistic,
+		hysteresis:        parameters.hysteresis,
+		nodes:             make(map[string]*node),
+	}
+	for _, address := range parameters.addresses {
+		s.nodes[address] = &node{}
+	}
+
+	// Carry out an initial check in the background, so that unhealthy nodes are excluded as soon as possible
+	// without holding up startup if the beacon nodes are slow to respond.
+	go s.checkHealth(ctx, nil)
+
+	interval := parameters.interval
+	runtimeFunc := func(ctx context.Context, data interface{}) (time.Time, error) {
+		return time.Now().Add(interval), nil
+	}
+	if err := parameters.scheduler.SchedulePeriodicJob(ctx,
+		"Node health",
+		"Check node health",
+		runtimeFunc,
+		nil,
+		s.checkHealth,
+		nil,
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to schedule periodic node health check")
+	}
+
+	return s, nil
+}
+
+// State provides the health state of the beacon node with the given address.
+// Beacon nodes that have yet to be checked are considered healthy.
+func (s *Service) State(address string) nodehealth.State {
+	s.nodesMu.RLock()
+	defer s.nodesMu.RUnlock()
+	n, exists := s.nodes[address]
+	if !exists {
+		return nodehealth.StateHealthy
+	}
+	return n.state
+}
+
+// SetAddresses sets the addresses of the beacon nodes to check, and the endpoints through which they are reached.
+// The state of beacon nodes that remain is retained, and beacon nodes that are added are checked immediately.
+func (s *Service) SetAddresses(ctx context.Context, addresses []string, endpoints map[string]string) {
+	added := false
+	s.nodesMu.Lock()
+	nodes := make(map[string]*node, len(addresses))
+	for _, address := range addresses {
+		n, exists := s.nodes[address]
+		if !exists {
+			n = &node{}
+			added = true
+		}
+		nodes[address] = n
+	}
+	s.nodes = nodes
+	s.endpoints = endpoints
+	s.nodesMu.Unlock()
+
+	if added {
+		s.checkHealth(ctx, nil)
+	}
+}
+
+// Filter returns the addresses of the beacon nodes that should be used, in the order supplied.
+// Healthy beacon nodes are returned if there are any, otherwise degraded beacon nodes.
+// If all beacon nodes are excluded then all are returned, as an excluded beacon node is
+// better than none at all.
+// Beacon nodes not previously seen by the service are added to those it checks.
+func (s *Service) Filter(addresses []string) []string {
+	healthy := make([]string, 0, len(addresses))
+	degraded := make([]string, 0)
+	s.nodesMu.Lock()
+	for _, address := range addresses {
+		n, exists := s.nodes[address]
+		if !exists {
+			n = &node{}
+			s.nodes[address] = n
+		}
+		switch n.state {
+		case nodehealth.StateHealthy:
+			healthy = append(healthy, address)
+		case nodehealth.StateDegraded:
+			degraded = append(degraded, address)
+		}
+	}
+	s.nodesMu.Unlock()
+
+	if len(healthy) > 0 {
+		return healthy
+	}
+	if len(degraded) > 0 {
+		log.Trace().Strs("addresses", degraded).Msg("No healthy beacon nodes; using degraded beacon nodes")
+		return degraded
+	}
+	if len(addresses) > 0 {
+		log.Debug().Strs("addresses", addresses).Msg("All beacon nodes excluded; using all")
+	}
+	return addresses
+}
@@ -0,0 +1,315 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/services/nodehealth/standard"
+	mockscheduler "github.com/attestantio/vouch/services/scheduler/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// nodeServer provides a beacon node API with the given status.
+func nodeServer(headSlot uint64, isSyncing bool, isOptimistic bool, peers int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/node/syncing":
+			fmt.Fprintf(w, `{"data":{"head_slot":"%d","sync_distance":"0","is_syncing":%t,"is_optimistic":%t}}`, headSlot, isSyncing, isOptimistic)
+		case "/eth/v1/node/peer_count":
+			fmt.Fprintf(w, `{"data":{"disconnected":"0","connecting":"0","connected":"%d","disconnecting":"0"}}`, peers)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "SchedulerMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no scheduler specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithTimeout(time.Second),
+			},
+			err: "problem with parameters: no chain time service specified",
+		},
+		{
+			name: "TimeoutMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "IntervalZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithInterval(0),
+			},
+			err: "problem with parameters: interval must be positive",
+		},
+		{
+			name: "HeadLagsInverted",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithDegradedHeadLag(4),
+				standard.WithExcludedHeadLag(2),
+			},
+			err: "problem with parameters: excluded head lag cannot be less than degraded head lag",
+		},
+		{
+			name: "HysteresisZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+				standard.WithHysteresis(0),
+			},
+			err: "problem with parameters: hysteresis must be at least 1",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithScheduler(mockscheduler.New()),
+				standard.WithChainTime(chainTime),
+				standard.WithTimeout(time.Second),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis 100 slots ago.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now().Add(-100*12*time.Second))),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	healthy := nodeServer(100, false, false, 50)
+	defer healthy.Close()
+	behind := nodeServer(95, false, false, 50)
+	defer behind.Close()
+	farBehind := nodeServer(50, false, false, 50)
+	defer farBehind.Close()
+	syncing := nodeServer(100, true, false, 50)
+	defer syncing.Close()
+	optimistic := nodeServer(100, false, true, 50)
+	defer optimistic.Close()
+	fewPeers := nodeServer(100, false, false, 2)
+	defer fewPeers.Close()
+	unreachable := nodeServer(100, false, false, 50)
+	unreachable.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithChainTime(chainTime),
+		standard.WithTimeout(time.Second),
+		standard.WithMinPeers(10),
+		standard.WithAddresses([]string{
+			healthy.URL,
+			behind.URL,
+			farBehind.URL,
+			syncing.URL,
+			optimistic.URL,
+			fewPeers.URL,
+			unreachable.URL,
+		}),
+	)
+	require.NoError(t, err)
+
+	// Initial check runs in the background.
+	require.Eventually(t, func() bool {
+		return s.State(healthy.URL) == nodehealth.StateHealthy &&
+			s.State(behind.URL) == nodehealth.StateDegraded &&
+			s.State(farBehind.URL) == nodehealth.StateExcluded &&
+			s.State(syncing.URL) == nodehealth.StateExcluded &&
+			s.State(optimistic.URL) == nodehealth.StateExcluded &&
+			s.State(fewPeers.URL) == nodehealth.StateDegraded &&
+			s.State(unreachable.URL) == nodehealth.StateExcluded
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tests := []struct {
+		name      string
+		addresses []string
+		expected  []string
+	}{
+		{
+			name:      "Empty",
+			addresses: []string{},
+			expected:  []string{},
+		},
+		{
+			name:      "Healthy",
+			addresses: []string{behind.URL, healthy.URL, syncing.URL},
+			expected:  []string{healthy.URL},
+		},
+		{
+			name:      "Degraded",
+			addresses: []string{behind.URL, syncing.URL, fewPeers.URL},
+			expected:  []string{behind.URL, fewPeers.URL},
+		},
+		{
+			name:      "Excluded",
+			addresses: []string{syncing.URL, unreachable.URL},
+			expected:  []string{syncing.URL, unreachable.URL},
+		},
+		{
+			name:      "Unknown",
+			addresses: []string{"http://unknown:5052", syncing.URL},
+			expected:  []string{"http://unknown:5052"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, s.Filter(test.addresses))
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now().Add(-100*12*time.Second))),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	server := nodeServer(100, false, false, 50)
+	defer server.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithChainTime(chainTime),
+		standard.WithTimeout(time.Second),
+		standard.WithAddresses([]string{"https://node.example.com:5052", "http://127.0.0.1:1"}),
+		standard.WithEndpoints(map[string]string{
+			"https://node.example.com:5052": server.URL,
+		}),
+	)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return s.State("http://127.0.0.1:1") == nodehealth.StateExcluded
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, nodehealth.StateHealthy, s.State("https://node.example.com:5052"))
+}
+
+func TestSetAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis 100 slots ago.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now().Add(-100*12*time.Second))),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	healthy := nodeServer(100, false, false, 50)
+	defer healthy.Close()
+	syncing := nodeServer(100, true, false, 50)
+	defer syncing.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithScheduler(mockscheduler.New()),
+		standard.WithChainTime(chainTime),
+		standard.WithTimeout(time.Second),
+		standard.WithAddresses([]string{healthy.URL}),
+	)
+	require.NoError(t, err)
+
+	// Added node is checked immediately.
+	s.SetAddresses(ctx, []string{healthy.URL, syncing.URL}, nil)
+	require.Equal(t, nodehealth.StateHealthy, s.State(healthy.URL))
+	require.Equal(t, nodehealth.StateExcluded, s.State(syncing.URL))
+
+	// Removed node is forgotten.
+	s.SetAddresses(ctx, []string{healthy.URL}, nil)
+	require.Equal(t, nodehealth.StateHealthy, s.State(syncing.URL))
+}
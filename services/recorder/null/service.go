@@ -0,0 +1,49 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package null is a strategy decision recorder that records nothing.
+package null
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/recorder"
+)
+
+// Service is a strategy decision recorder that records nothing.
+type Service struct{}
+
+// decision is a strategy decision that records nothing.
+type decision struct{}
+
+// New creates a new null strategy decision recorder.
+func New(_ context.Context) *Service {
+	return &Service{}
+}
+
+// NewDecision starts a new decision for the given operation and slot, to which
+// the named providers have been sent requests.
+func (*Service) NewDecision(_ string, _ phase0.Slot, _ []string) recorder.Decision {
+	return &decision{}
+}
+
+// Record records a decision, along with the provider whose response was selected.
+func (*Service) Record(_ recorder.Decision, _ string) {}
+
+// Response adds a successful response from a provider to the decision.
+func (*decision) Response(_ string, _ time.Duration, _ float64, _ interface{}) {}
+
+// Failure adds a failed response from a provider to the decision.
+func (*decision) Failure(_ string, _ time.Duration, _ error) {}
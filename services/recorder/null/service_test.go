@@ -0,0 +1,34 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package null_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	nullrecorder "github.com/attestantio/vouch/services/recorder/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	s := nullrecorder.New(context.Background())
+	decision := s.NewDecision("test", 1, []string{"a"})
+	require.NotNil(t, decision)
+	// Ensure that none of these panic.
+	decision.Response("a", time.Second, 1, nil)
+	decision.Failure("a", time.Second, errors.New("failed"))
+	s.Record(decision, "a")
+}
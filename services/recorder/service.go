@@ -0,0 +1,39 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Decision is the record of a single strategy decision.
+type Decision interface {
+	// Response adds a successful response from a provider to the decision.
+	Response(provider string, latency time.Duration, score float64, response interface{})
+
+	// Failure adds a failed response from a provider to the decision.
+	Failure(provider string, latency time.Duration, err error)
+}
+
+// Service is the strategy decision recorder service.
+type Service interface {
+	// NewDecision starts a new decision for the given operation and slot, to which
+	// the named providers have been sent requests.
+	NewDecision(operation string, slot phase0.Slot, providers []string) Decision
+
+	// Record records a decision, along with the provider whose response was selected.
+	Record(decision Decision, winner string)
+}
@@ -0,0 +1,146 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/recorder"
+)
+
+// Decision is the record of a single strategy decision.
+type Decision struct {
+	mu        sync.Mutex
+	started   time.Time
+	providers []string
+	finalised bool
+
+	Timestamp time.Time   `json:"timestamp"`
+	Operation string      `json:"operation"`
+	Slot      phase0.Slot `json:"slot"`
+	Winner    string      `json:"winner,omitempty"`
+	Responses []*Response `json:"responses"`
+}
+
+// Response is the record of a single provider's response to a strategy request.
+type Response struct {
+	Provider string          `json:"provider"`
+	Latency  int64           `json:"latency_ms"`
+	Score    *float64        `json:"score,omitempty"`
+	Root     string          `json:"root,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// NewDecision starts a new decision for the given operation and slot, to which
+// the named providers have been sent requests.
+func (*Service) NewDecision(operation string, slot phase0.Slot, providers []string) recorder.Decision {
+	now := time.Now()
+	return &Decision{
+		started:   now,
+		providers: providers,
+		Timestamp: now,
+		Operation: operation,
+		Slot:      slot,
+		Responses: make([]*Response, 0, len(providers)),
+	}
+}
+
+// Response adds a successful response from a provider to the decision.
+// The response is recorded in full, along with its root to allow responses
+// from different providers to be compared easily.
+func (d *Decision) Response(provider string, latency time.Duration, score float64, response interface{}) {
+	var data json.RawMessage
+	if encoded, err := json.Marshal(response); err == nil {
+		data = encoded
+	}
+
+	root := ""
+	switch r := response.(type) {
+	case interface{ HashTreeRoot() ([32]byte, error) }:
+		if hashRoot, err := r.HashTreeRoot(); err == nil {
+			root = fmt.Sprintf("%#x", hashRoot)
+		}
+	case interface{ Root() (phase0.Root, error) }:
+		if hashRoot, err := r.Root(); err == nil {
+			root = fmt.Sprintf("%#x", hashRoot)
+		}
+	}
+
+	d.add(&Response{
+		Provider: provider,
+		Latency:  latency.Milliseconds(),
+		Score:    &score,
+		Root:     root,
+		Data:     data,
+	})
+}
+
+// Failure adds a failed response from a provider to the decision.
+func (d *Decision) Failure(provider string, latency time.Duration, err error) {
+	msg := "unknown error"
+	if err != nil {
+		msg = err.Error()
+	}
+	d.add(&Response{
+		Provider: provider,
+		Latency:  latency.Milliseconds(),
+		Error:    msg,
+	})
+}
+
+func (d *Decision) add(response *Response) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.finalised {
+		return
+	}
+	d.Responses = append(d.Responses, response)
+}
+
+// finalise sets the winner of the decision, marks any providers that did not
+// respond as timed out, and returns the decision as a JSON line.
+func (d *Decision) finalise(winner string) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.finalised = true
+	d.Winner = winner
+
+	responded := make(map[string]bool, len(d.Responses))
+	for _, response := range d.Responses {
+		responded[response.Provider] = true
+	}
+	for _, provider := range d.providers {
+		if !responded[provider] {
+			d.Responses = append(d.Responses, &Response{
+				Provider: provider,
+				Latency:  time.Since(d.started).Milliseconds(),
+				Error:    "no response",
+			})
+		}
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,109 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standard records the decisions made by strategies, including the
+// response from each provider, for later offline analysis.
+package standard
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel zerolog.Level
+	monitor  metrics.Service
+	path     string
+	maxSize  int64
+	maxFiles int
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithPath sets the path of the file to which decisions are written.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// WithMaxSize sets the size in bytes at which the file is rotated.
+func WithMaxSize(maxSize int64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxSize = maxSize
+	})
+}
+
+// WithMaxFiles sets the number of rotated files to keep.
+func WithMaxFiles(maxFiles int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxFiles = maxFiles
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(context.Background()),
+		maxSize:  100 * 1024 * 1024,
+		maxFiles: 10,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.path == "" {
+		return nil, errors.New("no path specified")
+	}
+	if parameters.maxSize <= 0 {
+		return nil, errors.New("max size must be positive")
+	}
+	if parameters.maxFiles < 0 {
+		return nil, errors.New("max files cannot be negative")
+	}
+
+	return &parameters, nil
+}
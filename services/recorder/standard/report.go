@@ -0,0 +1,242 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Report is a summary of recorded decisions.
+type Report struct {
+	From       time.Time
+	To         time.Time
+	Operations map[string]*OperationReport
+}
+
+// OperationReport is a summary of recorded decisions for a single operation.
+type OperationReport struct {
+	// Decisions is the number of decisions made.
+	Decisions int
+	// Disagreements is the number of decisions for which providers returned differing responses.
+	Disagreements int
+	// Providers contains the summary for each provider.
+	Providers map[string]*ProviderReport
+}
+
+// ProviderReport is a summary of recorded decisions for a single provider.
+type ProviderReport struct {
+	// Requests is the number of decisions in which the provider was asked for a response.
+	Requests int
+	// Responses is the number of successful responses from the provider.
+	Responses int
+	// Wins is the number of times the provider's response was selected.
+	Wins int
+	// Disagreements is the number of responses that differed from the selected response.
+	Disagreements int
+	// ScoreGap is the total difference between the score of the selected response and the provider's response, for responses that were not selected.
+	ScoreGap float64
+	// Latency is the total latency of the provider's successful responses.
+	Latency time.Duration
+}
+
+// WinRate is the proportion of requests for which the provider's response was selected.
+func (p *ProviderReport) WinRate() float64 {
+	if p.Requests == 0 {
+		return 0
+	}
+	return float64(p.Wins) / float64(p.Requests)
+}
+
+// ErrorRate is the proportion of requests for which the provider did not provide a response.
+func (p *ProviderReport) ErrorRate() float64 {
+	if p.Requests == 0 {
+		return 0
+	}
+	return float64(p.Requests-p.Responses) / float64(p.Requests)
+}
+
+// MeanScoreGap is the mean difference between the score of the selected response and the provider's response,
+// for responses that were not selected.
+func (p *ProviderReport) MeanScoreGap() float64 {
+	if p.Responses == p.Wins {
+		return 0
+	}
+	return p.ScoreGap / float64(p.Responses-p.Wins)
+}
+
+// MeanLatency is the mean latency of the provider's successful responses.
+func (p *ProviderReport) MeanLatency() time.Duration {
+	if p.Responses == 0 {
+		return 0
+	}
+	return p.Latency / time.Duration(p.Responses)
+}
+
+// NewReport generates a report from the decisions recorded at the given path, including rotated files,
+// with timestamps in the range [from, to).  A zero time leaves the relevant end of the range open.
+func NewReport(path string, maxFiles int, from time.Time, to time.Time) (*Report, error) {
+	report := &Report{
+		From:       from,
+		To:         to,
+		Operations: make(map[string]*OperationReport),
+	}
+
+	// Read files from oldest to newest.
+	paths := make([]string, 0, maxFiles+1)
+	for i := maxFiles; i > 0; i-- {
+		paths = append(paths, rotatedPath(path, i))
+	}
+	paths = append(paths, path)
+
+	found := false
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "failed to open recorder file")
+		}
+		found = true
+		err = report.read(file)
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", path))
+		}
+	}
+	if !found {
+		return nil, errors.New("no recorder files found")
+	}
+
+	return report, nil
+}
+
+func (r *Report) read(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		decision := &Decision{}
+		if err := json.Unmarshal(scanner.Bytes(), decision); err != nil {
+			return errors.Wrap(err, "invalid decision")
+		}
+		if !r.From.IsZero() && decision.Timestamp.Before(r.From) {
+			continue
+		}
+		if !r.To.IsZero() && !decision.Timestamp.Before(r.To) {
+			continue
+		}
+		r.add(decision)
+	}
+
+	return scanner.Err()
+}
+
+func (r *Report) add(decision *Decision) {
+	operation, exists := r.Operations[decision.Operation]
+	if !exists {
+		operation = &OperationReport{
+			Providers: make(map[string]*ProviderReport),
+		}
+		r.Operations[decision.Operation] = operation
+	}
+	operation.Decisions++
+
+	var winner *Response
+	roots := make(map[string]bool)
+	for _, response := range decision.Responses {
+		if response.Provider == decision.Winner && response.Error == "" {
+			winner = response
+		}
+		if response.Root != "" {
+			roots[response.Root] = true
+		}
+	}
+	if len(roots) > 1 {
+		operation.Disagreements++
+	}
+
+	for _, response := range decision.Responses {
+		provider, exists := operation.Providers[response.Provider]
+		if !exists {
+			provider = &ProviderReport{}
+			operation.Providers[response.Provider] = provider
+		}
+		provider.Requests++
+		if response.Error != "" {
+			continue
+		}
+		provider.Responses++
+		provider.Latency += time.Duration(response.Latency) * time.Millisecond
+		if response == winner {
+			provider.Wins++
+			continue
+		}
+		if winner == nil {
+			continue
+		}
+		if winner.Score != nil && response.Score != nil {
+			provider.ScoreGap += *winner.Score - *response.Score
+		}
+		if response.Root != winner.Root {
+			provider.Disagreements++
+		}
+	}
+}
+
+// Write writes the report in human-readable form.
+func (r *Report) Write(writer io.Writer) error {
+	operations := make([]string, 0, len(r.Operations))
+	for operation := range r.Operations {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	tw := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	for _, name := range operations {
+		operation := r.Operations[name]
+		fmt.Fprintf(tw, "%s: %d decisions, %d with disagreements\n", name, operation.Decisions, operation.Disagreements)
+		fmt.Fprintf(tw, "  provider\trequests\twin rate\terror rate\tmean score gap\tdisagreements\tmean latency\n")
+		providers := make([]string, 0, len(operation.Providers))
+		for provider := range operation.Providers {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+		for _, name := range providers {
+			provider := operation.Providers[name]
+			fmt.Fprintf(tw, "  %s\t%d\t%.1f%%\t%.1f%%\t%.2f\t%d\t%v\n",
+				name,
+				provider.Requests,
+				provider.WinRate()*100,
+				provider.ErrorRate()*100,
+				provider.MeanScoreGap(),
+				provider.Disagreements,
+				provider.MeanLatency(),
+			)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,78 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/services/recorder/standard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.log")
+	rotated := strings.Join([]string{
+		`{"timestamp":"2022-06-01T00:00:00Z","operation":"attestation data","slot":1,"winner":"a","responses":[{"provider":"a","latency_ms":100,"score":3,"root":"0x01"},{"provider":"b","latency_ms":300,"score":3,"root":"0x01"}]}`,
+		``,
+	}, "\n")
+	current := strings.Join([]string{
+		`{"timestamp":"2022-06-01T00:00:12Z","operation":"attestation data","slot":2,"winner":"b","responses":[{"provider":"b","latency_ms":100,"score":4,"root":"0x02"},{"provider":"a","latency_ms":300,"score":2,"root":"0x03"}]}`,
+		`{"timestamp":"2022-06-01T00:00:24Z","operation":"attestation data","slot":3,"winner":"a","responses":[{"provider":"a","latency_ms":100,"score":5,"root":"0x04"},{"provider":"b","latency_ms":1000,"error":"no response"}]}`,
+		`{"timestamp":"2022-06-02T00:00:00Z","operation":"beacon block proposal","slot":7200,"winner":"a","responses":[{"provider":"a","latency_ms":100,"score":5,"root":"0x05"}]}`,
+		``,
+	}, "\n")
+	require.NoError(t, os.WriteFile(path+".1", []byte(rotated), 0o600))
+	require.NoError(t, os.WriteFile(path, []byte(current), 0o600))
+
+	report, err := standard.NewReport(path, 2, time.Time{}, time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, report.Operations, 1)
+	operation := report.Operations["attestation data"]
+	require.Equal(t, 3, operation.Decisions)
+	require.Equal(t, 1, operation.Disagreements)
+
+	a := operation.Providers["a"]
+	require.Equal(t, 3, a.Requests)
+	require.Equal(t, 3, a.Responses)
+	require.Equal(t, 2, a.Wins)
+	require.Equal(t, 1, a.Disagreements)
+	require.Equal(t, float64(2), a.MeanScoreGap())
+	require.Equal(t, float64(0), a.ErrorRate())
+
+	b := operation.Providers["b"]
+	require.Equal(t, 3, b.Requests)
+	require.Equal(t, 2, b.Responses)
+	require.Equal(t, 1, b.Wins)
+	require.Equal(t, 0, b.Disagreements)
+	require.Equal(t, float64(0), b.MeanScoreGap())
+	require.Equal(t, 200*time.Millisecond, b.MeanLatency())
+	require.InDelta(t, 1.0/3, b.ErrorRate(), 0.0001)
+
+	report, err = standard.NewReport(path, 2, time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, report.Operations, 1)
+	require.Equal(t, 1, report.Operations["beacon block proposal"].Decisions)
+
+	output := new(bytes.Buffer)
+	require.NoError(t, report.Write(output))
+	require.Contains(t, output.String(), "beacon block proposal: 1 decisions, 0 with disagreements")
+
+	_, err = standard.NewReport(filepath.Join(t.TempDir(), "missing.log"), 2, time.Time{}, time.Time{})
+	require.EqualError(t, err, "no recorder files found")
+}
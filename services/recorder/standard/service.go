@@ -0,0 +1,193 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service records strategy decisions as JSON lines, rotating the file when it
+// reaches its maximum size.
+type Service struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+	lineCh   chan *line
+}
+
+type line struct {
+	operation string
+	data      []byte
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new decision recorder.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "recorder").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		path:     parameters.path,
+		maxSize:  parameters.maxSize,
+		maxFiles: parameters.maxFiles,
+		lineCh:   make(chan *line, 1024),
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	go s.run(ctx)
+
+	return s, nil
+}
+
+// Record records a decision, along with the provider whose response was selected.
+// Providers that were asked for a response but did not provide one are recorded as
+// having timed out.  Any responses added to the decision after it has been recorded
+// are ignored.
+func (s *Service) Record(decision recorder.Decision, winner string) {
+	d, isDecision := decision.(*Decision)
+	if !isDecision {
+		log.Warn().Msg("Decision not created by this recorder; not recording")
+		return
+	}
+
+	data, err := d.finalise(winner)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to marshal decision")
+		monitorDecision(d.Operation, "failed")
+		return
+	}
+
+	select {
+	case s.lineCh <- &line{operation: d.Operation, data: data}:
+	default:
+		log.Warn().Str("operation", d.Operation).Msg("Recorder backlog full; dropping decision")
+		monitorDecision(d.Operation, "dropped")
+	}
+}
+
+// run writes lines to the file until the context is done.
+func (s *Service) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			// Write out anything that is pending before closing the file.
+			for {
+				select {
+				case l := <-s.lineCh:
+					s.write(l)
+				default:
+					if err := s.file.Close(); err != nil {
+						log.Warn().Err(err).Msg("Failed to close recorder file")
+					}
+					return
+				}
+			}
+		case l := <-s.lineCh:
+			s.write(l)
+		}
+	}
+}
+
+func (s *Service) write(l *line) {
+	if s.size > 0 && s.size+int64(len(l.data)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			log.Error().Err(err).Msg("Failed to rotate recorder file")
+			monitorDecision(l.operation, "failed")
+			return
+		}
+	}
+
+	n, err := s.file.Write(l.data)
+	s.size += int64(n)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to write decision")
+		monitorDecision(l.operation, "failed")
+		return
+	}
+	monitorDecision(l.operation, "recorded")
+}
+
+func (s *Service) open() error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open recorder file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain recorder file information")
+	}
+	s.file = file
+	s.size = info.Size()
+
+	return nil
+}
+
+// rotate moves the current file to path.1, path.1 to path.2 etc., removing
+// the oldest file if there are more than the maximum number of files.
+func (s *Service) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close recorder file")
+	}
+
+	if s.maxFiles == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to remove recorder file")
+		}
+		return s.open()
+	}
+
+	if err := os.Remove(rotatedPath(s.path, s.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove oldest recorder file")
+	}
+	for i := s.maxFiles - 1; i > 0; i-- {
+		if err := os.Rename(rotatedPath(s.path, i), rotatedPath(s.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to rotate recorder file")
+		}
+	}
+	if err := os.Rename(s.path, rotatedPath(s.path, 1)); err != nil {
+		return errors.Wrap(err, "failed to rotate recorder file")
+	}
+	log.Trace().Str("path", s.path).Msg("Rotated recorder file")
+
+	return s.open()
+}
+
+func rotatedPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
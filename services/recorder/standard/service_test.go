@@ -0,0 +1,205 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/recorder/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "decisions.log")
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorNil",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+				standard.WithPath(path),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "PathMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "MaxSizeZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithPath(path),
+				standard.WithMaxSize(0),
+			},
+			err: "problem with parameters: max size must be positive",
+		},
+		{
+			name: "MaxFilesNegative",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithPath(path),
+				standard.WithMaxFiles(-1),
+			},
+			err: "problem with parameters: max files cannot be negative",
+		},
+		{
+			name: "PathInvalid",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithPath(filepath.Join(path, "missing", "decisions.log")),
+			},
+			err: "failed to open recorder file: open " + filepath.Join(path, "missing", "decisions.log") + ": no such file or directory",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithPath(path),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func readDecisions(path string) ([]*standard.Decision, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decisions := make([]*standard.Decision, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		decision := &standard.Decision{}
+		if err := json.Unmarshal(scanner.Bytes(), decision); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, scanner.Err()
+}
+
+func TestRecord(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "decisions.log")
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	decision := s.NewDecision("attestation data", 12345, []string{"a", "b", "c"})
+	decision.Response("a", 100*time.Millisecond, 2, &phase0.Checkpoint{Epoch: 1})
+	decision.Failure("b", 200*time.Millisecond, errors.New("failed"))
+	s.Record(decision, "a")
+	// Responses after recording are ignored.
+	decision.Response("c", time.Second, 3, &phase0.Checkpoint{Epoch: 2})
+
+	require.Eventually(t, func() bool {
+		info, err := os.Stat(path)
+		return err == nil && info.Size() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	decisions, err := readDecisions(path)
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+	require.Equal(t, "attestation data", decisions[0].Operation)
+	require.Equal(t, phase0.Slot(12345), decisions[0].Slot)
+	require.Equal(t, "a", decisions[0].Winner)
+	require.Len(t, decisions[0].Responses, 3)
+
+	require.Equal(t, "a", decisions[0].Responses[0].Provider)
+	require.Equal(t, int64(100), decisions[0].Responses[0].Latency)
+	require.Equal(t, float64(2), *decisions[0].Responses[0].Score)
+	require.NotEmpty(t, decisions[0].Responses[0].Root)
+	checkpoint := &phase0.Checkpoint{}
+	require.NoError(t, json.Unmarshal(decisions[0].Responses[0].Data, checkpoint))
+	require.Equal(t, phase0.Epoch(1), checkpoint.Epoch)
+	require.Empty(t, decisions[0].Responses[0].Error)
+
+	require.Equal(t, "b", decisions[0].Responses[1].Provider)
+	require.Nil(t, decisions[0].Responses[1].Score)
+	require.Empty(t, decisions[0].Responses[1].Data)
+	require.Equal(t, "failed", decisions[0].Responses[1].Error)
+
+	require.Equal(t, "c", decisions[0].Responses[2].Provider)
+	require.Equal(t, "no response", decisions[0].Responses[2].Error)
+}
+
+func TestRotate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "decisions.log")
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithPath(path),
+		standard.WithMaxSize(1),
+		standard.WithMaxFiles(2),
+	)
+	require.NoError(t, err)
+
+	// Each decision is larger than the maximum size, so each goes in its own file.
+	for i := 1; i <= 4; i++ {
+		decision := s.NewDecision("test", phase0.Slot(i), []string{"a"})
+		decision.Response("a", time.Millisecond, 1, nil)
+		s.Record(decision, "a")
+		require.Eventually(t, func() bool {
+			decisions, err := readDecisions(path)
+			return err == nil && len(decisions) == 1 && decisions[0].Slot == phase0.Slot(i)
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	decisions, err := readDecisions(path + ".1")
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(3), decisions[0].Slot)
+	decisions, err = readDecisions(path + ".2")
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(2), decisions[0].Slot)
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
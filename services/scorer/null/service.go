@@ -0,0 +1,39 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package null is a scorer that always returns the built-in scores.
+package null
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/scorer"
+)
+
+// Service is a scorer that always returns the built-in scores.
+type Service struct{}
+
+// New creates a new null scorer.
+func New(_ context.Context) *Service {
+	return &Service{}
+}
+
+// Score obtains scores for the candidates for a decision.
+func (*Service) Score(_ context.Context,
+	_ string,
+	_ phase0.Slot,
+	candidates []*scorer.Candidate,
+) []float64 {
+	return scorer.BuiltinScores(candidates)
+}
@@ -0,0 +1,32 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package null_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/scorer"
+	nullscorer "github.com/attestantio/vouch/services/scorer/null"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	s := nullscorer.New(context.Background())
+	require.Equal(t, []float64{5, 3}, s.Score(context.Background(), "test", 1, []*scorer.Candidate{
+		{Provider: "a", Data: &phase0.AttestationData{}, BuiltinScore: 5},
+		{Provider: "b", Data: &phase0.AttestationData{}, BuiltinScore: 3},
+	}))
+}
@@ -0,0 +1,51 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorer
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Candidate is a response from a provider to be scored.
+type Candidate struct {
+	// Provider is the name of the provider that returned the candidate.
+	Provider string
+	// Data is the candidate itself.
+	Data interface{}
+	// BuiltinScore is the score given to the candidate by the strategy.
+	BuiltinScore float64
+}
+
+// Service is the external scorer service.
+type Service interface {
+	// Score obtains scores for all of the candidates for a decision, in the same order as the candidates.
+	// If a score cannot be obtained for every candidate the built-in scores are returned, so that the
+	// candidates for a decision are always compared using scores on the same scale.
+	Score(ctx context.Context,
+		operation string,
+		slot phase0.Slot,
+		candidates []*Candidate,
+	) []float64
+}
+
+// BuiltinScores returns the built-in scores of the candidates.
+func BuiltinScores(candidates []*Candidate) []float64 {
+	scores := make([]float64, len(candidates))
+	for i := range candidates {
+		scores[i] = candidates[i].BuiltinScore
+	}
+	return scores
+}
@@ -0,0 +1,72 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var requests *prometheus.CounterVec
+var durations *prometheus.HistogramVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if requests != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_scorer",
+		Name:      "requests_total",
+		Help:      "The number of requests to the external scorer.",
+	}, []string{"operation", "result"})
+	if err := prometheus.Register(requests); err != nil {
+		return err
+	}
+
+	durations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_scorer",
+		Name:      "duration_seconds",
+		Help:      "The time taken for requests to the external scorer.",
+		Buckets: []float64{
+			0.01, 0.02, 0.03, 0.04, 0.05, 0.06, 0.07, 0.08, 0.09, 0.1,
+			0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0,
+		},
+	}, []string{"operation"})
+	return prometheus.Register(durations)
+}
+
+func monitorRequest(operation string, result string, duration time.Duration) {
+	if requests == nil {
+		return
+	}
+	requests.WithLabelValues(operation, result).Inc()
+	durations.WithLabelValues(operation).Observe(duration.Seconds())
+}
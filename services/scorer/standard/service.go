@@ -0,0 +1,205 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service obtains scores from an external scorer.
+type Service struct {
+	address string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// request is the body of the request sent to the external scorer.
+type request struct {
+	Operation  string              `json:"operation"`
+	Slot       phase0.Slot         `json:"slot,string"`
+	Candidates []*requestCandidate `json:"candidates"`
+}
+
+// requestCandidate is a single candidate in the request sent to the external scorer.
+type requestCandidate struct {
+	Provider     string      `json:"provider"`
+	Version      string      `json:"version,omitempty"`
+	BuiltinScore float64     `json:"builtin_score"`
+	Candidate    interface{} `json:"candidate"`
+}
+
+// response is the body of the response returned by the external scorer.
+type response struct {
+	Scores []*float64 `json:"scores"`
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new external scorer.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "scorer").Str("impl", "standard").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		address: parameters.address,
+		timeout: parameters.timeout,
+		client:  &http.Client{},
+	}
+
+	return s, nil
+}
+
+// Score obtains scores for all of the candidates for a decision in a single request.
+// If the external scorer does not return scores for all candidates before its timeout,
+// or returns an error, the built-in scores are returned instead.
+func (s *Service) Score(ctx context.Context,
+	operation string,
+	slot phase0.Slot,
+	candidates []*scorer.Candidate,
+) []float64 {
+	if len(candidates) == 0 {
+		return []float64{}
+	}
+
+	started := time.Now()
+	scores, err := s.score(ctx, operation, slot, candidates)
+	if err != nil {
+		log.Debug().Str("operation", operation).Int("candidates", len(candidates)).Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to obtain external scores; using built-in scores")
+		if errors.Is(err, context.DeadlineExceeded) {
+			monitorRequest(operation, "timeout", time.Since(started))
+		} else {
+			monitorRequest(operation, "failed", time.Since(started))
+		}
+		return scorer.BuiltinScores(candidates)
+	}
+	log.Trace().Str("operation", operation).Dur("elapsed", time.Since(started)).Floats64("builtin_scores", scorer.BuiltinScores(candidates)).Floats64("scores", scores).Msg("Obtained external scores")
+	monitorRequest(operation, "succeeded", time.Since(started))
+
+	return scores
+}
+
+func (s *Service) score(ctx context.Context,
+	operation string,
+	slot phase0.Slot,
+	candidates []*scorer.Candidate,
+) (
+	[]float64,
+	error,
+) {
+	req := &request{
+		Operation:  operation,
+		Slot:       slot,
+		Candidates: make([]*requestCandidate, len(candidates)),
+	}
+	for i, candidate := range candidates {
+		reqCandidate, err := newRequestCandidate(candidate)
+		if err != nil {
+			return nil, err
+		}
+		req.Candidates[i] = reqCandidate
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.address, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+	defer httpResp.Body.Close()
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scorer returned status %d", httpResp.StatusCode)
+	}
+	resp := &response{}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return nil, errors.Wrap(err, "invalid response")
+	}
+	if len(resp.Scores) != len(candidates) {
+		return nil, fmt.Errorf("expected %d scores in response, received %d", len(candidates), len(resp.Scores))
+	}
+	scores := make([]float64, len(resp.Scores))
+	for i := range resp.Scores {
+		if resp.Scores[i] == nil {
+			return nil, fmt.Errorf("no score for candidate %d in response", i)
+		}
+		scores[i] = *resp.Scores[i]
+	}
+
+	return scores, nil
+}
+
+// newRequestCandidate creates the request entry for a candidate.
+func newRequestCandidate(candidate *scorer.Candidate) (*requestCandidate, error) {
+	res := &requestCandidate{
+		Provider:     candidate.Provider,
+		BuiltinScore: candidate.BuiltinScore,
+		Candidate:    candidate.Data,
+	}
+	if proposal, isProposal := candidate.Data.(*spec.VersionedBeaconBlock); isProposal {
+		// Send the block itself rather than the versioned wrapper.
+		res.Version = strings.ToLower(proposal.Version.String())
+		switch proposal.Version {
+		case spec.DataVersionPhase0:
+			res.Candidate = proposal.Phase0
+		case spec.DataVersionAltair:
+			res.Candidate = proposal.Altair
+		case spec.DataVersionBellatrix:
+			res.Candidate = proposal.Bellatrix
+		default:
+			return nil, fmt.Errorf("unhandled block version %v", proposal.Version)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,203 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/attestantio/vouch/services/scorer/standard"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []standard.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorNil",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithMonitor(nil),
+				standard.WithAddress("http://localhost:8080/score"),
+				standard.WithTimeout(100 * time.Millisecond),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "AddressMissing",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithTimeout(100 * time.Millisecond),
+			},
+			err: "problem with parameters: no address specified",
+		},
+		{
+			name: "AddressInvalid",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithAddress("grpc://localhost:8080"),
+				standard.WithTimeout(100 * time.Millisecond),
+			},
+			err: "problem with parameters: address must be an HTTP or HTTPS URL",
+		},
+		{
+			name: "TimeoutZero",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithAddress("http://localhost:8080/score"),
+			},
+			err: "problem with parameters: timeout must be positive",
+		},
+		{
+			name: "Good",
+			params: []standard.Parameter{
+				standard.WithLogLevel(zerolog.Disabled),
+				standard.WithAddress("http://localhost:8080/score"),
+				standard.WithTimeout(100 * time.Millisecond),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := standard.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Slot       string                   `json:"slot"`
+			Candidates []map[string]interface{} `json:"candidates"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Candidates) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch req.Candidates[0]["provider"] {
+		case "good":
+			// Scores are double the built-in scores.
+			scores := make([]float64, len(req.Candidates))
+			for i := range req.Candidates {
+				scores[i] = req.Candidates[i]["builtin_score"].(float64) * 2
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"scores": scores})
+		case "block":
+			if req.Candidates[0]["version"] != "phase0" || req.Slot != "12345" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"scores":[100]}`))
+		case "slow":
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"scores":[100,100]}`))
+		case "short":
+			_, _ = w.Write([]byte(`{"scores":[100]}`))
+		case "noscore":
+			_, _ = w.Write([]byte(`{"scores":[100,null]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	s, err := standard.New(ctx,
+		standard.WithLogLevel(zerolog.Disabled),
+		standard.WithAddress(server.URL),
+		standard.WithTimeout(100*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	proposal, err := mock.NewBeaconBlockProposalProvider().BeaconBlockProposal(ctx, 12345, phase0.BLSSignature{}, nil)
+	require.NoError(t, err)
+
+	// pair creates a pair of attestation data candidates, with the named provider first.
+	pair := func(provider string) []*scorer.Candidate {
+		return []*scorer.Candidate{
+			{Provider: provider, Data: &phase0.AttestationData{}, BuiltinScore: 5},
+			{Provider: "other", Data: &phase0.AttestationData{}, BuiltinScore: 3},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		candidates []*scorer.Candidate
+		scores     []float64
+	}{
+		{
+			name:       "Empty",
+			candidates: []*scorer.Candidate{},
+			scores:     []float64{},
+		},
+		{
+			name:       "Good",
+			candidates: pair("good"),
+			scores:     []float64{10, 6},
+		},
+		{
+			name: "Block",
+			candidates: []*scorer.Candidate{
+				{Provider: "block", Data: proposal, BuiltinScore: 5},
+			},
+			scores: []float64{100},
+		},
+		{
+			name:       "Slow",
+			candidates: pair("slow"),
+			scores:     []float64{5, 3},
+		},
+		{
+			name:       "Short",
+			candidates: pair("short"),
+			scores:     []float64{5, 3},
+		},
+		{
+			name:       "NoScore",
+			candidates: pair("noscore"),
+			scores:     []float64{5, 3},
+		},
+		{
+			name:       "Error",
+			candidates: pair("error"),
+			scores:     []float64{5, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.scores, s.Score(ctx, "test", 12345, test.candidates))
+		})
+	}
+}
@@ -57,3 +57,14 @@ func (*Service) sign(ctx context.Context,
 	copy(signature[:], sig.Marshal())
 	return signature, nil
 }
+
+// checkClockDrift returns an error if signing should be refused due to clock drift.
+func (s *Service) checkClockDrift() error {
+	if s.clockDriftChecker == nil {
+		return nil
+	}
+	if err := s.clockDriftChecker.CheckDrift(); err != nil {
+		return errors.Wrap(err, "refusing to sign")
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/clockdrift"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
 	"github.com/pkg/errors"
@@ -24,11 +25,12 @@ import (
 )
 
 type parameters struct {
-	logLevel       zerolog.Level
-	monitor        metrics.SignerMonitor
-	clientMonitor  metrics.ClientMonitor
-	specProvider   eth2client.SpecProvider
-	domainProvider eth2client.DomainProvider
+	logLevel          zerolog.Level
+	monitor           metrics.SignerMonitor
+	clientMonitor     metrics.ClientMonitor
+	specProvider      eth2client.SpecProvider
+	domainProvider    eth2client.DomainProvider
+	clockDriftChecker clockdrift.Checker
 }
 
 // Parameter is the interface for service parameters.
@@ -77,6 +79,14 @@ func WithDomainProvider(provider eth2client.DomainProvider) Parameter {
 	})
 }
 
+// WithClockDriftChecker sets the clock drift checker.
+// If supplied, signing is refused when the checker reports excessive drift.
+func WithClockDriftChecker(checker clockdrift.Checker) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clockDriftChecker = checker
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
@@ -102,6 +112,7 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.domainProvider == nil {
 		return nil, errors.New("no domain provider specified")
 	}
+	// Clock drift checker is optional.
 
 	return &parameters, nil
 }
@@ -19,6 +19,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/clockdrift"
 	"github.com/attestantio/vouch/services/metrics"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -39,6 +40,7 @@ type Service struct {
 	syncCommitteeSelectionProofDomainType *phase0.DomainType
 	contributionAndProofDomainType        *phase0.DomainType
 	domainProvider                        eth2client.DomainProvider
+	clockDriftChecker                     clockdrift.Checker
 }
 
 // module-wide log.
@@ -125,6 +127,7 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeSelectionProofDomainType: syncCommitteeSelectionProofDomainType,
 		contributionAndProofDomainType:        contributionAndProofDomainType,
 		domainProvider:                        parameters.domainProvider,
+		clockDriftChecker:                     parameters.clockDriftChecker,
 	}
 
 	return s, nil
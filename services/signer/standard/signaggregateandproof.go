@@ -30,6 +30,10 @@ func (s *Service) SignAggregateAndProof(ctx context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
 	// Fetch the domain.
 	domain, err := s.domainProvider.Domain(ctx,
 		s.aggregateAndProofDomainType,
@@ -35,6 +35,10 @@ func (s *Service) SignBeaconAttestation(ctx context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
 	domain, err := s.domainProvider.Domain(ctx,
 		s.beaconAttesterDomainType,
 		phase0.Epoch(slot/s.slotsPerEpoch))
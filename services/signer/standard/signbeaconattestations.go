@@ -36,6 +36,10 @@ func (s *Service) SignBeaconAttestations(ctx context.Context,
 	[]phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return nil, err
+	}
+
 	span, ctx := opentracing.StartSpanFromContext(ctx, "signer.SignBeaconAttestations")
 	defer span.Finish()
 
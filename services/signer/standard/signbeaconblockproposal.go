@@ -33,6 +33,9 @@ func (s *Service) SignBeaconBlockProposal(ctx context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
 
 	// Fetch the domain.
 	domain, err := s.domainProvider.Domain(ctx,
@@ -30,6 +30,10 @@ func (s *Service) SignContributionAndProof(ctx context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
 	if s.contributionAndProofDomainType == nil {
 		return phase0.BLSSignature{}, errors.New("no contribution and proof domain type available; cannot sign")
 	}
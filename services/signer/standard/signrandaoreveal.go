@@ -31,6 +31,10 @@ func (s *Service) SignRANDAOReveal(ctx context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
 	var messageRoot phase0.Root
 	epoch := phase0.Epoch(slot / s.slotsPerEpoch)
 	binary.LittleEndian.PutUint64(messageRoot[:], uint64(epoch))
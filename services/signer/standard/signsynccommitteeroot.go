@@ -31,6 +31,10 @@ func (s *Service) SignSyncCommitteeRoot(ctx context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
 	if s.syncCommitteeDomainType == nil {
 		return phase0.BLSSignature{}, errors.New("no sync committee domain type available; cannot sign")
 	}
@@ -32,6 +32,10 @@ func (s *Service) SignSyncCommitteeSelection(ctx context.Context,
 	phase0.BLSSignature,
 	error,
 ) {
+	if err := s.checkClockDrift(); err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
 	if s.syncCommitteeSelectionProofDomainType == nil {
 		return phase0.BLSSignature{}, errors.New("no sync committee selection proof domain type, cannot sign")
 	}
@@ -0,0 +1,174 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is the submitter for signed items.
+type Service struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// submission is a single line in the output file.
+type submission struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+}
+
+// New creates a new submitter.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "submitter").Str("impl", "dryrun").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	file, err := os.OpenFile(parameters.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open output file")
+	}
+
+	s := &Service{
+		file: file,
+	}
+
+	go func(ctx context.Context, s *Service) {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.file.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close output file")
+		}
+	}(ctx, s)
+
+	return s, nil
+}
+
+// SubmitBeaconBlock writes a block.
+func (s *Service) SubmitBeaconBlock(_ context.Context, block *spec.VersionedSignedBeaconBlock) error {
+	if block == nil {
+		return errors.New("no beacon block supplied")
+	}
+
+	return s.write("beaconblock", block)
+}
+
+// SubmitAttestations writes multiple attestations.
+func (s *Service) SubmitAttestations(_ context.Context, attestations []*phase0.Attestation) error {
+	if len(attestations) == 0 {
+		return errors.New("no attestations supplied")
+	}
+
+	return s.write("attestation", attestations)
+}
+
+// SubmitBeaconCommitteeSubscriptions writes a batch of beacon committee subscriptions.
+func (s *Service) SubmitBeaconCommitteeSubscriptions(_ context.Context, subscriptions []*api.BeaconCommitteeSubscription) error {
+	if subscriptions == nil {
+		return errors.New("no subscriptions supplied")
+	}
+
+	return s.write("beaconcommitteesubscription", subscriptions)
+}
+
+// SubmitAggregateAttestations writes aggregate attestations.
+func (s *Service) SubmitAggregateAttestations(_ context.Context, aggregates []*phase0.SignedAggregateAndProof) error {
+	if len(aggregates) == 0 {
+		return errors.New("no aggregate attestations supplied")
+	}
+
+	return s.write("aggregateattestation", aggregates)
+}
+
+// SubmitProposalPreparations writes proposal preparations.
+func (s *Service) SubmitProposalPreparations(_ context.Context, preparations []*api.ProposalPreparation) error {
+	if len(preparations) == 0 {
+		return errors.New("no preparations supplied")
+	}
+
+	return s.write("proposalpreparation", preparations)
+}
+
+// SubmitSyncCommitteeMessages writes sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(_ context.Context, messages []*altair.SyncCommitteeMessage) error {
+	if len(messages) == 0 {
+		return errors.New("no sync committee messages supplied")
+	}
+
+	return s.write("synccommitteemessage", messages)
+}
+
+// SubmitSyncCommitteeSubscriptions writes a batch of sync committee subscriptions.
+func (s *Service) SubmitSyncCommitteeSubscriptions(_ context.Context, subscriptions []*api.SyncCommitteeSubscription) error {
+	if len(subscriptions) == 0 {
+		return errors.New("no sync committee subscriptions supplied")
+	}
+
+	return s.write("synccommitteesubscription", subscriptions)
+}
+
+// SubmitSyncCommitteeContributions writes sync committee contributions.
+func (s *Service) SubmitSyncCommitteeContributions(_ context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	if len(contributionAndProofs) == 0 {
+		return errors.New("no sync committee contribution and proofs supplied")
+	}
+
+	return s.write("synccommitteecontribution", contributionAndProofs)
+}
+
+// write writes a would-be submission to the output file.
+func (s *Service) write(messageType string, data interface{}) error {
+	line, err := json.Marshal(&submission{
+		Timestamp: time.Now(),
+		Type:      messageType,
+		Data:      data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal submission")
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(line); err != nil {
+		return errors.Wrap(err, "failed to write submission")
+	}
+	log.Trace().Str("message_type", messageType).Msg("Wrote submission")
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/attestantio/vouch/services/submitter/dryrun"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dryrun.json")
+
+	tests := []struct {
+		name   string
+		params []dryrun.Parameter
+		err    string
+	}{
+		{
+			name: "PathMissing",
+			params: []dryrun.Parameter{
+				dryrun.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "PathBad",
+			params: []dryrun.Parameter{
+				dryrun.WithLogLevel(zerolog.Disabled),
+				dryrun.WithPath(filepath.Join(path, "missing", "dryrun.json")),
+			},
+			err: "failed to open output file: open " + filepath.Join(path, "missing", "dryrun.json") + ": no such file or directory",
+		},
+		{
+			name: "Good",
+			params: []dryrun.Parameter{
+				dryrun.WithLogLevel(zerolog.Disabled),
+				dryrun.WithPath(path),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := dryrun.New(context.Background(), test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dryrun.json")
+
+	s, err := dryrun.New(ctx,
+		dryrun.WithLogLevel(zerolog.Disabled),
+		dryrun.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	require.EqualError(t, s.SubmitBeaconBlock(ctx, nil), "no beacon block supplied")
+	require.EqualError(t, s.SubmitAttestations(ctx, nil), "no attestations supplied")
+	require.EqualError(t, s.SubmitBeaconCommitteeSubscriptions(ctx, nil), "no subscriptions supplied")
+	require.EqualError(t, s.SubmitAggregateAttestations(ctx, nil), "no aggregate attestations supplied")
+	require.EqualError(t, s.SubmitProposalPreparations(ctx, nil), "no preparations supplied")
+	require.EqualError(t, s.SubmitSyncCommitteeMessages(ctx, nil), "no sync committee messages supplied")
+	require.EqualError(t, s.SubmitSyncCommitteeSubscriptions(ctx, nil), "no sync committee subscriptions supplied")
+	require.EqualError(t, s.SubmitSyncCommitteeContributions(ctx, nil), "no sync committee contribution and proofs supplied")
+
+	require.NoError(t, s.SubmitAttestations(ctx, []*phase0.Attestation{
+		{
+			AggregationBits: []byte{0x01, 0x02},
+			Data: &phase0.AttestationData{
+				Slot:   5,
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{},
+			},
+		},
+	}))
+	require.NoError(t, s.SubmitProposalPreparations(ctx, []*api.ProposalPreparation{
+		{
+			ValidatorIndex: 1,
+		},
+	}))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	types := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		require.NotEmpty(t, line.Data)
+		types = append(types, line.Type)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, []string{"attestation", "proposalpreparation"}, types)
+}
+
+func TestInterfaces(t *testing.T) {
+	s, err := dryrun.New(context.Background(),
+		dryrun.WithLogLevel(zerolog.Disabled),
+		dryrun.WithPath(filepath.Join(t.TempDir(), "dryrun.json")),
+	)
+	require.NoError(t, err)
+	require.Implements(t, (*submitter.BeaconBlockSubmitter)(nil), s)
+	require.Implements(t, (*submitter.AttestationsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.BeaconCommitteeSubscriptionsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.AggregateAttestationsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.ProposalPreparationsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.SyncCommitteeMessagesSubmitter)(nil), s)
+	require.Implements(t, (*submitter.SyncCommitteeSubscriptionsSubmitter)(nil), s)
+	require.Implements(t, (*submitter.SyncCommitteeContributionsSubmitter)(nil), s)
+}
@@ -0,0 +1,92 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immediate
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/errorclassifier"
+)
+
+// The slot functions below obtain the slot of a message, used to bound retries.
+// Malformed messages return slot 0, for which any deadline will have passed.
+
+func blockSlot(block *spec.VersionedSignedBeaconBlock) phase0.Slot {
+	slot, err := block.Slot()
+	if err != nil {
+		return 0
+	}
+	return slot
+}
+
+func attestationsSlot(attestations []*phase0.Attestation) phase0.Slot {
+	if attestations[0] == nil || attestations[0].Data == nil {
+		return 0
+	}
+	return attestations[0].Data.Slot
+}
+
+func aggregatesSlot(aggregates []*phase0.SignedAggregateAndProof) phase0.Slot {
+	if aggregates[0] == nil ||
+		aggregates[0].Message == nil ||
+		aggregates[0].Message.Aggregate == nil ||
+		aggregates[0].Message.Aggregate.Data == nil {
+		return 0
+	}
+	return aggregates[0].Message.Aggregate.Data.Slot
+}
+
+func syncCommitteeMessagesSlot(messages []*altair.SyncCommitteeMessage) phase0.Slot {
+	if messages[0] == nil {
+		return 0
+	}
+	return messages[0].Slot
+}
+
+func contributionAndProofsSlot(contributionAndProofs []*altair.SignedContributionAndProof) phase0.Slot {
+	if contributionAndProofs[0] == nil ||
+		contributionAndProofs[0].Message == nil ||
+		contributionAndProofs[0].Message.Contribution == nil {
+		return 0
+	}
+	return contributionAndProofs[0].Message.Contribution.Slot
+}
+
+// address returns the address of the submitter, if available.
+func address(submitter interface{}) string {
+	if service, isService := submitter.(eth2client.Service); isService {
+		return service.Address()
+	}
+	return "<unknown>"
+}
+
+// classifyError classifies an error returned by a submitter.  If the error shows that
+// the beacon node already has the message it is not an error as far as we are concerned,
+// so is cleared.
+func (s *Service) classifyError(ctx context.Context, submitter interface{}, messageType string, err error) error {
+	if err == nil {
+		return nil
+	}
+	err = s.classifier.Wrap(ctx, submitter, messageType, err)
+	if errorclassifier.ClassOf(err).Benign() {
+		log.Trace().Str("beacon_node_address", address(submitter)).Str("message_type", messageType).Msg("Node already has message; ignored")
+		return nil
+	}
+
+	return err
+}
@@ -18,8 +18,11 @@ import (
 	"context"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	nullerrorclassifier "github.com/attestantio/vouch/services/errorclassifier/null"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/submitter/retry"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -35,6 +38,8 @@ type parameters struct {
 	syncCommitteeMessagesSubmitter        eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitter   eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitter   eth2client.SyncCommitteeContributionsSubmitter
+	retrier                               *retry.Service
+	classifier                            errorclassifier.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -118,11 +123,27 @@ func WithProposalPreparationsSubmitter(submitter eth2client.ProposalPreparations
 	})
 }
 
+// WithRetrier sets the retrier for failed submissions.
+// If this is not set failed submissions are not retried.
+func WithRetrier(retrier *retry.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retrier = retrier
+	})
+}
+
+// WithClassifier sets the classifier for errors returned by beacon nodes.
+func WithClassifier(classifier errorclassifier.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.classifier = classifier
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
 		logLevel:      zerolog.GlobalLevel(),
 		clientMonitor: nullmetrics.New(context.Background()),
+		classifier:    nullerrorclassifier.New(context.Background()),
 	}
 	for _, p := range params {
 		if params != nil {
@@ -133,6 +154,9 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.clientMonitor == nil {
 		return nil, errors.New("no client monitor specified")
 	}
+	if parameters.classifier == nil {
+		return nil, errors.New("no classifier specified")
+	}
 	if parameters.beaconBlockSubmitter == nil {
 		return nil, errors.New("no beacon block submitter specified")
 	}
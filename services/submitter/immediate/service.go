@@ -23,7 +23,9 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/errorclassifier"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/submitter/retry"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -40,6 +42,8 @@ type Service struct {
 	syncCommitteeMessagesSubmitter        eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitter   eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitter   eth2client.SyncCommitteeContributionsSubmitter
+	retrier                               *retry.Service
+	classifier                            errorclassifier.Service
 }
 
 // module-wide log.
@@ -68,6 +72,8 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeMessagesSubmitter:        parameters.syncCommitteeMessagesSubmitter,
 		syncCommitteeSubscriptionsSubmitter:   parameters.syncCommitteeSubscriptionsSubmitter,
 		syncCommitteeContributionsSubmitter:   parameters.syncCommitteeContributionsSubmitter,
+		retrier:                               parameters.retrier,
+		classifier:                            parameters.classifier,
 	}
 
 	return s, nil
@@ -80,7 +86,9 @@ func (s *Service) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSi
 	}
 
 	started := time.Now()
-	err := s.beaconBlockSubmitter.SubmitBeaconBlock(ctx, block)
+	err := s.retrier.Do(ctx, "beaconblock", blockSlot(block), address(s.beaconBlockSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.beaconBlockSubmitter, "beaconblock", s.beaconBlockSubmitter.SubmitBeaconBlock(ctx, block))
+	})
 	if service, isService := s.beaconBlockSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit beacon block", err == nil, time.Since(started))
 	} else {
@@ -107,7 +115,9 @@ func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0
 	}
 
 	started := time.Now()
-	err := s.attestationsSubmitter.SubmitAttestations(ctx, attestations)
+	err := s.retrier.Do(ctx, "attestation", attestationsSlot(attestations), address(s.attestationsSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.attestationsSubmitter, "attestation", s.attestationsSubmitter.SubmitAttestations(ctx, attestations))
+	})
 	if service, isService := s.attestationsSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit attestations", err == nil, time.Since(started))
 	} else {
@@ -143,7 +153,9 @@ func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscr
 		}
 	}
 	started := time.Now()
-	err := s.beaconCommitteeSubscriptionsSubmitter.SubmitBeaconCommitteeSubscriptions(ctx, subs)
+	err := s.retrier.Do(ctx, "beaconcommitteesubscription", 0, address(s.beaconCommitteeSubscriptionsSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.beaconCommitteeSubscriptionsSubmitter, "beaconcommitteesubscription", s.beaconCommitteeSubscriptionsSubmitter.SubmitBeaconCommitteeSubscriptions(ctx, subs))
+	})
 	if service, isService := s.beaconCommitteeSubscriptionsSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit beacon committee subscription", err == nil, time.Since(started))
 	} else {
@@ -178,7 +190,9 @@ func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []
 	}
 
 	started := time.Now()
-	err := s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, aggregates)
+	err := s.retrier.Do(ctx, "aggregateattestation", aggregatesSlot(aggregates), address(s.aggregateAttestationsSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.aggregateAttestationsSubmitter, "aggregateattestation", s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, aggregates))
+	})
 	if service, isService := s.aggregateAttestationsSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit aggregate attestation", err == nil, time.Since(started))
 	} else {
@@ -205,7 +219,9 @@ func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations [
 	}
 
 	started := time.Now()
-	err := s.proposalPreparationsSubmitter.SubmitProposalPreparations(ctx, preparations)
+	err := s.retrier.Do(ctx, "proposalpreparation", 0, address(s.proposalPreparationsSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.proposalPreparationsSubmitter, "proposalpreparation", s.proposalPreparationsSubmitter.SubmitProposalPreparations(ctx, preparations))
+	})
 	if service, isService := s.proposalPreparationsSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit proposal preparations", err == nil, time.Since(started))
 	} else {
@@ -232,7 +248,9 @@ func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*a
 	}
 
 	started := time.Now()
-	err := s.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(ctx, messages)
+	err := s.retrier.Do(ctx, "synccommitteemessage", syncCommitteeMessagesSlot(messages), address(s.syncCommitteeMessagesSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.syncCommitteeMessagesSubmitter, "synccommitteemessage", s.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(ctx, messages))
+	})
 	if service, isService := s.aggregateAttestationsSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit sync committee messages", err == nil, time.Since(started))
 	} else {
@@ -259,7 +277,9 @@ func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscrip
 	}
 
 	started := time.Now()
-	err := s.syncCommitteeSubscriptionsSubmitter.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+	err := s.retrier.Do(ctx, "synccommitteesubscription", 0, address(s.syncCommitteeSubscriptionsSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.syncCommitteeSubscriptionsSubmitter, "synccommitteesubscription", s.syncCommitteeSubscriptionsSubmitter.SubmitSyncCommitteeSubscriptions(ctx, subscriptions))
+	})
 	if service, isService := s.syncCommitteeSubscriptionsSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit sync committee subscription", err == nil, time.Since(started))
 	} else {
@@ -286,7 +306,9 @@ func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contribu
 	}
 
 	started := time.Now()
-	err := s.syncCommitteeContributionsSubmitter.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+	err := s.retrier.Do(ctx, "synccommitteecontribution", contributionAndProofsSlot(contributionAndProofs), address(s.syncCommitteeContributionsSubmitter), func(ctx context.Context) error {
+		return s.classifyError(ctx, s.syncCommitteeContributionsSubmitter, "synccommitteecontribution", s.syncCommitteeContributionsSubmitter.SubmitSyncCommitteeContributions(ctx, contributionAndProofs))
+	})
 	if service, isService := s.syncCommitteeContributionsSubmitter.(eth2client.Service); isService {
 		s.clientMonitor.ClientOperation(service.Address(), "submit sync committee contribution and proofs", err == nil, time.Since(started))
 	} else {
@@ -0,0 +1,73 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var recorded *prometheus.CounterVec
+var rebroadcasts *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if recorded != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	recorded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "submitter_journal",
+		Name:      "recorded_total",
+		Help:      "The number of messages recorded in the journal.",
+	}, []string{"message_type"})
+	if err := prometheus.Register(recorded); err != nil {
+		return err
+	}
+
+	rebroadcasts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "submitter_journal",
+		Name:      "rebroadcasts_total",
+		Help:      "The number of unacknowledged messages rebroadcast from the journal on startup.",
+	}, []string{"message_type", "result"})
+	return prometheus.Register(rebroadcasts)
+}
+
+func monitorRecorded(messageType string) {
+	if recorded == nil {
+		return
+	}
+	recorded.WithLabelValues(messageType).Inc()
+}
+
+func monitorRebroadcast(messageType string, result string) {
+	if rebroadcasts == nil {
+		return
+	}
+	rebroadcasts.WithLabelValues(messageType, result).Inc()
+}
@@ -0,0 +1,148 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package journal is a submitter that records signed messages in a journal
+// before passing them on for submission, and marks them as acknowledged once
+// submitted.  On startup any unacknowledged messages that are still relevant
+// are submitted again, so messages are not lost if Vouch stops between signing
+// and submission.
+package journal
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel     zerolog.Level
+	monitor      metrics.Service
+	chainTime    chaintime.Service
+	specProvider eth2client.SpecProvider
+	submitter    submitter.Service
+	path         string
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithChainTime sets the chain time service.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithSpecProvider sets the spec provider.
+func WithSpecProvider(provider eth2client.SpecProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.specProvider = provider
+	})
+}
+
+// WithSubmitter sets the submitter to which messages are passed.
+func WithSubmitter(submitter submitter.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.submitter = submitter
+	})
+}
+
+// WithPath sets the path of the journal file.
+func WithPath(path string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.path = path
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(context.Background()),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	if parameters.specProvider == nil {
+		return nil, errors.New("no spec provider specified")
+	}
+	if parameters.submitter == nil {
+		return nil, errors.New("no submitter specified")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.AttestationsSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit attestations")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.AggregateAttestationsSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit aggregate attestations")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.BeaconBlockSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit beacon blocks")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.BeaconCommitteeSubscriptionsSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit beacon committee subscriptions")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.ProposalPreparationsSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit proposal preparations")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.SyncCommitteeMessagesSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit sync committee messages")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.SyncCommitteeSubscriptionsSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit sync committee subscriptions")
+	}
+	if _, isSubmitter := parameters.submitter.(submitter.SyncCommitteeContributionsSubmitter); !isSubmitter {
+		return nil, errors.New("submitter does not submit sync committee contributions")
+	}
+	if parameters.path == "" {
+		return nil, errors.New("no path specified")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,365 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/submitter"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// compactInterval is the number of lines written to the journal between compactions.
+const compactInterval = 1024
+
+// Service is a submitter that journals messages.
+type Service struct {
+	chainTime                             chaintime.Service
+	slotsPerEpoch                         uint64
+	attestationsSubmitter                 submitter.AttestationsSubmitter
+	aggregateAttestationsSubmitter        submitter.AggregateAttestationsSubmitter
+	beaconBlockSubmitter                  submitter.BeaconBlockSubmitter
+	beaconCommitteeSubscriptionsSubmitter submitter.BeaconCommitteeSubscriptionsSubmitter
+	proposalPreparationsSubmitter         submitter.ProposalPreparationsSubmitter
+	syncCommitteeMessagesSubmitter        submitter.SyncCommitteeMessagesSubmitter
+	syncCommitteeSubscriptionsSubmitter   submitter.SyncCommitteeSubscriptionsSubmitter
+	syncCommitteeContributionsSubmitter   submitter.SyncCommitteeContributionsSubmitter
+	path                                  string
+	mu                                    sync.Mutex
+	file                                  *os.File
+	pending                               map[uint64]*entry
+	nextID                                uint64
+	writes                                int
+	written                               uint64
+	// syncMu serialises syncs and compactions; if both locks are required it is taken before mu.
+	syncMu sync.Mutex
+	synced uint64
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new journal submitter.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "submitter").Str("impl", "journal").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	spec, err := parameters.specProvider.Spec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	tmp, exists := spec["SLOTS_PER_EPOCH"]
+	if !exists {
+		return nil, errors.New("SLOTS_PER_EPOCH not found in spec")
+	}
+	slotsPerEpoch, ok := tmp.(uint64)
+	if !ok {
+		return nil, errors.New("SLOTS_PER_EPOCH of unexpected type")
+	}
+
+	s := &Service{
+		chainTime:                             parameters.chainTime,
+		slotsPerEpoch:                         slotsPerEpoch,
+		attestationsSubmitter:                 parameters.submitter.(submitter.AttestationsSubmitter),
+		aggregateAttestationsSubmitter:        parameters.submitter.(submitter.AggregateAttestationsSubmitter),
+		beaconBlockSubmitter:                  parameters.submitter.(submitter.BeaconBlockSubmitter),
+		beaconCommitteeSubscriptionsSubmitter: parameters.submitter.(submitter.BeaconCommitteeSubscriptionsSubmitter),
+		proposalPreparationsSubmitter:         parameters.submitter.(submitter.ProposalPreparationsSubmitter),
+		syncCommitteeMessagesSubmitter:        parameters.submitter.(submitter.SyncCommitteeMessagesSubmitter),
+		syncCommitteeSubscriptionsSubmitter:   parameters.submitter.(submitter.SyncCommitteeSubscriptionsSubmitter),
+		syncCommitteeContributionsSubmitter:   parameters.submitter.(submitter.SyncCommitteeContributionsSubmitter),
+		path:                                  parameters.path,
+		pending:                               make(map[uint64]*entry),
+		nextID:                                1,
+	}
+
+	if err := s.load(); err != nil {
+		return nil, errors.Wrap(err, "failed to load journal")
+	}
+
+	// Obtain the unacknowledged entries before compaction, which removes those that are no longer relevant.
+	s.syncMu.Lock()
+	s.mu.Lock()
+	if err := s.compact(); err != nil {
+		s.mu.Unlock()
+		s.syncMu.Unlock()
+		return nil, errors.Wrap(err, "failed to compact journal")
+	}
+	entries := make([]*entry, 0, len(s.pending))
+	for _, e := range s.pending {
+		entries = append(entries, e)
+	}
+	s.mu.Unlock()
+	s.syncMu.Unlock()
+
+	if len(entries) > 0 {
+		log.Info().Int("entries", len(entries)).Msg("Rebroadcasting unacknowledged messages from journal")
+		go s.rebroadcast(ctx, entries)
+	}
+
+	go func(ctx context.Context, s *Service) {
+		<-ctx.Done()
+		s.syncMu.Lock()
+		defer s.syncMu.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.file.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close journal")
+		}
+	}(ctx, s)
+
+	return s, nil
+}
+
+// load loads the unacknowledged entries from the journal.
+func (s *Service) load() error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// No journal, so nothing to load.
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		e := &entry{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			// Most likely a partial line written as Vouch stopped.
+			log.Warn().Err(err).Msg("Failed to parse journal entry; ignoring")
+			continue
+		}
+		if e.ID >= s.nextID {
+			s.nextID = e.ID + 1
+		}
+		if e.Acked {
+			delete(s.pending, e.ID)
+		} else {
+			s.pending[e.ID] = e
+		}
+	}
+
+	return scanner.Err()
+}
+
+// record durably records a message in the journal, returning its ID.
+func (s *Service) record(messageType string, slot phase0.Slot, data interface{}) (uint64, error) {
+	msg, err := json.Marshal(data)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to marshal message")
+	}
+
+	s.mu.Lock()
+	e := &entry{
+		ID:   s.nextID,
+		Type: messageType,
+		Slot: uint64(slot),
+		Data: msg,
+	}
+	if err := s.write(e); err != nil {
+		s.mu.Unlock()
+		return 0, err
+	}
+	s.nextID++
+	s.pending[e.ID] = e
+	written := s.written
+	s.mu.Unlock()
+
+	// Ensure that the entry is on disk before the message is submitted.
+	if err := s.sync(written); err != nil {
+		return 0, err
+	}
+	monitorRecorded(messageType)
+	s.compactIfRequired()
+
+	return e.ID, nil
+}
+
+// sync ensures that the journal is on disk up to and including the given write.
+// Writes made while a sync is in progress are covered by the next sync, so
+// concurrent callers share syncs rather than each carrying out their own, and
+// no caller waits for more than two.
+func (s *Service) sync(written uint64) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	if s.synced >= written {
+		// Covered by a sync carried out while we were waiting.
+		return nil
+	}
+
+	s.mu.Lock()
+	file := s.file
+	target := s.written
+	s.mu.Unlock()
+
+	if err := file.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync journal")
+	}
+	s.synced = target
+
+	return nil
+}
+
+// ack marks a message in the journal as acknowledged.
+func (s *Service) ack(id uint64) {
+	s.mu.Lock()
+	if _, exists := s.pending[id]; !exists {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.pending, id)
+	// The acknowledgement is not synced to disk; if it is lost the message will be
+	// submitted again on restart, which is harmless.
+	err := s.write(&entry{ID: id, Acked: true})
+	s.mu.Unlock()
+	if err != nil {
+		log.Warn().Err(err).Uint64("id", id).Msg("Failed to acknowledge journal entry")
+		return
+	}
+
+	s.compactIfRequired()
+}
+
+// compactIfRequired compacts the journal if enough entries have been written since
+// the last compaction, regardless of whether they have been acknowledged.
+func (s *Service) compactIfRequired() {
+	s.mu.Lock()
+	required := s.writes >= compactInterval
+	s.mu.Unlock()
+	if !required {
+		return
+	}
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.writes < compactInterval {
+		// Compacted by another caller while we were waiting.
+		return
+	}
+	if err := s.compact(); err != nil {
+		log.Warn().Err(err).Msg("Failed to compact journal")
+	}
+}
+
+// write writes an entry to the journal.
+// This assumes that the lock is held.
+func (s *Service) write(e *entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal journal entry")
+	}
+	data = append(data, '\n')
+	if _, err := s.file.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write journal entry")
+	}
+	s.writes++
+	s.written++
+
+	return nil
+}
+
+// compact rewrites the journal to contain only unacknowledged entries that are still relevant.
+// The rewritten journal is synced, so this also covers any writes that are yet to be synced.
+// This assumes that both locks are held.
+func (s *Service) compact() error {
+	currentSlot := s.chainTime.CurrentSlot()
+	tmpPath := s.path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create journal")
+	}
+	for id, e := range s.pending {
+		if !s.relevant(e, currentSlot) {
+			log.Trace().Uint64("id", id).Str("message_type", e.Type).Uint64("slot", e.Slot).Msg("Dropping unacknowledged journal entry that is no longer relevant")
+			delete(s.pending, id)
+			continue
+		}
+		data, err := json.Marshal(e)
+		if err != nil {
+			file.Close()
+			return errors.Wrap(err, "failed to marshal journal entry")
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			file.Close()
+			return errors.Wrap(err, "failed to write journal entry")
+		}
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to sync journal")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close journal")
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return errors.Wrap(err, "failed to replace journal")
+	}
+
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close old journal")
+		}
+	}
+	s.file, err = os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open journal")
+	}
+	s.writes = 0
+	s.synced = s.written
+
+	return nil
+}
+
+// relevant returns true if the entry is still of use if submitted in the current slot.
+func (s *Service) relevant(e *entry, currentSlot phase0.Slot) bool {
+	slot := phase0.Slot(e.Slot)
+	if slot > currentSlot {
+		return false
+	}
+	switch e.Type {
+	case messageTypeAttestation:
+		// Attestations can be included in blocks for an epoch's worth of slots.
+		return uint64(currentSlot-slot) <= s.slotsPerEpoch
+	case messageTypeAggregateAttestation, messageTypeBeaconBlock:
+		return slot == currentSlot
+	default:
+		return false
+	}
+}
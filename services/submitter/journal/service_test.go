@@ -0,0 +1,372 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/submitter/journal"
+	nullsubmitter "github.com/attestantio/vouch/services/submitter/null"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// capturingSubmitter captures the attestations and blocks it is asked to submit.
+type capturingSubmitter struct {
+	*nullsubmitter.Service
+	mu           sync.Mutex
+	fail         bool
+	attestations []*phase0.Attestation
+	blocks       []*spec.VersionedSignedBeaconBlock
+}
+
+func (s *capturingSubmitter) SubmitAttestations(_ context.Context, attestations []*phase0.Attestation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return errors.New("failed")
+	}
+	s.attestations = append(s.attestations, attestations...)
+	return nil
+}
+
+func (s *capturingSubmitter) SubmitBeaconBlock(_ context.Context, block *spec.VersionedSignedBeaconBlock) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return errors.New("failed")
+	}
+	s.blocks = append(s.blocks, block)
+	return nil
+}
+
+func (s *capturingSubmitter) captured() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.attestations), len(s.blocks)
+}
+
+func newCapturingSubmitter(ctx context.Context, t *testing.T) *capturingSubmitter {
+	t.Helper()
+	null, err := nullsubmitter.New(ctx, nullsubmitter.WithLogLevel(zerolog.Disabled))
+	require.NoError(t, err)
+	return &capturingSubmitter{Service: null}
+}
+
+func attestation(slot phase0.Slot) *phase0.Attestation {
+	return &phase0.Attestation{
+		AggregationBits: []byte{0x01, 0x02},
+		Data: &phase0.AttestationData{
+			Slot:   slot,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+	}
+}
+
+func block(slot phase0.Slot) *spec.VersionedSignedBeaconBlock {
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot: slot,
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						BlockHash: make([]byte, 32),
+					},
+					Graffiti:          make([]byte, 32),
+					ProposerSlashings: []*phase0.ProposerSlashing{},
+					AttesterSlashings: []*phase0.AttesterSlashing{},
+					Attestations:      []*phase0.Attestation{},
+					Deposits:          []*phase0.Deposit{},
+					VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+				},
+			},
+		},
+	}
+}
+
+// unacked returns the IDs of the unacknowledged entries in the journal.
+func unacked(t *testing.T, path string) map[uint64]string {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	res := make(map[uint64]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		e := struct {
+			ID    uint64 `json:"id"`
+			Type  string `json:"type"`
+			Acked bool   `json:"acked"`
+		}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		if e.Acked {
+			delete(res, e.ID)
+		} else {
+			res[e.ID] = e.Type
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return res
+}
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	submitter := newCapturingSubmitter(ctx, t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	tests := []struct {
+		name   string
+		params []journal.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorMissing",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithMonitor(nil),
+				journal.WithChainTime(chainTime),
+				journal.WithSpecProvider(mock.NewSpecProvider()),
+				journal.WithSubmitter(submitter),
+				journal.WithPath(path),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "ChainTimeMissing",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithSpecProvider(mock.NewSpecProvider()),
+				journal.WithSubmitter(submitter),
+				journal.WithPath(path),
+			},
+			err: "problem with parameters: no chain time service specified",
+		},
+		{
+			name: "SpecProviderMissing",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithChainTime(chainTime),
+				journal.WithSubmitter(submitter),
+				journal.WithPath(path),
+			},
+			err: "problem with parameters: no spec provider specified",
+		},
+		{
+			name: "SubmitterMissing",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithChainTime(chainTime),
+				journal.WithSpecProvider(mock.NewSpecProvider()),
+				journal.WithPath(path),
+			},
+			err: "problem with parameters: no submitter specified",
+		},
+		{
+			name: "SubmitterIncomplete",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithChainTime(chainTime),
+				journal.WithSpecProvider(mock.NewSpecProvider()),
+				journal.WithSubmitter(mock.NewAttestationsSubmitter()),
+				journal.WithPath(path),
+			},
+			err: "problem with parameters: submitter does not submit aggregate attestations",
+		},
+		{
+			name: "PathMissing",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithChainTime(chainTime),
+				journal.WithSpecProvider(mock.NewSpecProvider()),
+				journal.WithSubmitter(submitter),
+			},
+			err: "problem with parameters: no path specified",
+		},
+		{
+			name: "PathBad",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithChainTime(chainTime),
+				journal.WithSpecProvider(mock.NewSpecProvider()),
+				journal.WithSubmitter(submitter),
+				journal.WithPath(filepath.Join(path, "missing", "journal")),
+			},
+			err: "failed to compact journal: failed to create journal: open " + filepath.Join(path, "missing", "journal") + ".tmp: no such file or directory",
+		},
+		{
+			name: "Good",
+			params: []journal.Parameter{
+				journal.WithLogLevel(zerolog.Disabled),
+				journal.WithChainTime(chainTime),
+				journal.WithSpecProvider(mock.NewSpecProvider()),
+				journal.WithSubmitter(submitter),
+				journal.WithPath(path),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := journal.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJournal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Genesis is set such that the current slot is 100.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now().Add(-100*12*time.Second-time.Second))),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(100), chainTime.CurrentSlot())
+
+	path := filepath.Join(t.TempDir(), "journal")
+
+	// Submit messages with a failing submitter, so that they remain unacknowledged.
+	failingSubmitter := newCapturingSubmitter(ctx, t)
+	failingSubmitter.fail = true
+	s, err := journal.New(ctx,
+		journal.WithLogLevel(zerolog.Disabled),
+		journal.WithChainTime(chainTime),
+		journal.WithSpecProvider(mock.NewSpecProvider()),
+		journal.WithSubmitter(failingSubmitter),
+		journal.WithPath(path),
+	)
+	require.NoError(t, err)
+	// Attestation within its inclusion window.
+	require.Error(t, s.SubmitAttestations(ctx, []*phase0.Attestation{attestation(90)}))
+	// Attestation outside its inclusion window.
+	require.Error(t, s.SubmitAttestations(ctx, []*phase0.Attestation{attestation(50)}))
+	// Block for the current slot.
+	require.Error(t, s.SubmitBeaconBlock(ctx, block(100)))
+	// Block for an earlier slot.
+	require.Error(t, s.SubmitBeaconBlock(ctx, block(99)))
+	require.Len(t, unacked(t, path), 4)
+
+	// A successful submission is acknowledged.
+	failingSubmitter.fail = false
+	require.NoError(t, s.SubmitAttestations(ctx, []*phase0.Attestation{attestation(100)}))
+	require.Len(t, unacked(t, path), 4)
+
+	// Start again, as if after a restart.
+	submitter := newCapturingSubmitter(ctx, t)
+	_, err = journal.New(ctx,
+		journal.WithLogLevel(zerolog.Disabled),
+		journal.WithChainTime(chainTime),
+		journal.WithSpecProvider(mock.NewSpecProvider()),
+		journal.WithSubmitter(submitter),
+		journal.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	// Only the relevant messages should be rebroadcast.
+	require.Eventually(t, func() bool {
+		attestations, blocks := submitter.captured()
+		return attestations == 1 && blocks == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, phase0.Slot(90), submitter.attestations[0].Data.Slot)
+	slot, err := submitter.blocks[0].Slot()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(100), slot)
+
+	// Rebroadcast messages are acknowledged.
+	require.Eventually(t, func() bool {
+		return len(unacked(t, path)) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestJournalCompactsWithoutAcks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Genesis is set such that the current slot is 100.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now().Add(-100*12*time.Second-time.Second))),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "journal")
+	failingSubmitter := newCapturingSubmitter(ctx, t)
+	failingSubmitter.fail = true
+	s, err := journal.New(ctx,
+		journal.WithLogLevel(zerolog.Disabled),
+		journal.WithChainTime(chainTime),
+		journal.WithSpecProvider(mock.NewSpecProvider()),
+		journal.WithSubmitter(failingSubmitter),
+		journal.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	// Submit messages concurrently, none of which are acknowledged.  Those outside
+	// their inclusion window are dropped when the journal is compacted.
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(relevant bool) {
+			defer wg.Done()
+			slot := phase0.Slot(50)
+			if relevant {
+				slot = 90
+			}
+			for j := 0; j < 100; j++ {
+				require.Error(t, s.SubmitAttestations(ctx, []*phase0.Attestation{attestation(slot)}))
+			}
+		}(i == 0)
+	}
+	wg.Wait()
+
+	// The journal has been compacted, but still holds the relevant messages.
+	entries := len(unacked(t, path))
+	require.Less(t, entries, 1600)
+	require.GreaterOrEqual(t, entries, 100)
+}
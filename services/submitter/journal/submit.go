@@ -0,0 +1,174 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"encoding/json"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const (
+	messageTypeAttestation          = "attestation"
+	messageTypeAggregateAttestation = "aggregateattestation"
+	messageTypeBeaconBlock          = "beaconblock"
+)
+
+// entry is a single line in the journal.  An entry either records a message, or
+// acknowledges the message with the same ID.
+type entry struct {
+	ID    uint64          `json:"id"`
+	Type  string          `json:"type,omitempty"`
+	Slot  uint64          `json:"slot,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Acked bool            `json:"acked,omitempty"`
+}
+
+// SubmitAttestations records attestations in the journal and submits them.
+func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	if len(attestations) == 0 || attestations[0].Data == nil {
+		return s.attestationsSubmitter.SubmitAttestations(ctx, attestations)
+	}
+
+	id, err := s.record(messageTypeAttestation, attestations[0].Data.Slot, attestations)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to record attestations in journal; submitting regardless")
+	}
+	if err := s.attestationsSubmitter.SubmitAttestations(ctx, attestations); err != nil {
+		return err
+	}
+	if id != 0 {
+		s.ack(id)
+	}
+
+	return nil
+}
+
+// SubmitAggregateAttestations records aggregate attestations in the journal and submits them.
+func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []*phase0.SignedAggregateAndProof) error {
+	if len(aggregates) == 0 ||
+		aggregates[0].Message == nil ||
+		aggregates[0].Message.Aggregate == nil ||
+		aggregates[0].Message.Aggregate.Data == nil {
+		return s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, aggregates)
+	}
+
+	id, err := s.record(messageTypeAggregateAttestation, aggregates[0].Message.Aggregate.Data.Slot, aggregates)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to record aggregate attestations in journal; submitting regardless")
+	}
+	if err := s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, aggregates); err != nil {
+		return err
+	}
+	if id != 0 {
+		s.ack(id)
+	}
+
+	return nil
+}
+
+// SubmitBeaconBlock records a beacon block in the journal and submits it.
+func (s *Service) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
+	if block == nil {
+		return s.beaconBlockSubmitter.SubmitBeaconBlock(ctx, block)
+	}
+	slot, err := block.Slot()
+	if err != nil {
+		return s.beaconBlockSubmitter.SubmitBeaconBlock(ctx, block)
+	}
+
+	id, err := s.record(messageTypeBeaconBlock, slot, block)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to record beacon block in journal; submitting regardless")
+	}
+	if err := s.beaconBlockSubmitter.SubmitBeaconBlock(ctx, block); err != nil {
+		return err
+	}
+	if id != 0 {
+		s.ack(id)
+	}
+
+	return nil
+}
+
+// SubmitBeaconCommitteeSubscriptions submits beacon committee subscriptions.
+func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.BeaconCommitteeSubscription) error {
+	return s.beaconCommitteeSubscriptionsSubmitter.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitProposalPreparations submits proposal preparations.
+func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations []*apiv1.ProposalPreparation) error {
+	return s.proposalPreparationsSubmitter.SubmitProposalPreparations(ctx, preparations)
+}
+
+// SubmitSyncCommitteeMessages submits sync committee messages.
+func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*altair.SyncCommitteeMessage) error {
+	return s.syncCommitteeMessagesSubmitter.SubmitSyncCommitteeMessages(ctx, messages)
+}
+
+// SubmitSyncCommitteeSubscriptions submits sync committee subscriptions.
+func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*apiv1.SyncCommitteeSubscription) error {
+	return s.syncCommitteeSubscriptionsSubmitter.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+}
+
+// SubmitSyncCommitteeContributions submits sync committee contributions.
+func (s *Service) SubmitSyncCommitteeContributions(ctx context.Context, contributionAndProofs []*altair.SignedContributionAndProof) error {
+	return s.syncCommitteeContributionsSubmitter.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
+}
+
+// rebroadcast submits unacknowledged entries from the journal.
+func (s *Service) rebroadcast(ctx context.Context, entries []*entry) {
+	for _, e := range entries {
+		log := log.With().Uint64("id", e.ID).Str("message_type", e.Type).Uint64("slot", e.Slot).Logger()
+		if err := s.resubmit(ctx, e); err != nil {
+			log.Warn().Err(err).Msg("Failed to rebroadcast message from journal")
+			monitorRebroadcast(e.Type, "failed")
+			continue
+		}
+		s.ack(e.ID)
+		log.Debug().Msg("Rebroadcast message from journal")
+		monitorRebroadcast(e.Type, "succeeded")
+	}
+}
+
+// resubmit submits the message in an entry.
+func (s *Service) resubmit(ctx context.Context, e *entry) error {
+	switch e.Type {
+	case messageTypeAttestation:
+		attestations := make([]*phase0.Attestation, 0)
+		if err := json.Unmarshal(e.Data, &attestations); err != nil {
+			return errors.Wrap(err, "failed to unmarshal attestations")
+		}
+		return s.attestationsSubmitter.SubmitAttestations(ctx, attestations)
+	case messageTypeAggregateAttestation:
+		aggregates := make([]*phase0.SignedAggregateAndProof, 0)
+		if err := json.Unmarshal(e.Data, &aggregates); err != nil {
+			return errors.Wrap(err, "failed to unmarshal aggregate attestations")
+		}
+		return s.aggregateAttestationsSubmitter.SubmitAggregateAttestations(ctx, aggregates)
+	case messageTypeBeaconBlock:
+		block := &spec.VersionedSignedBeaconBlock{}
+		if err := json.Unmarshal(e.Data, block); err != nil {
+			return errors.Wrap(err, "failed to unmarshal beacon block")
+		}
+		return s.beaconBlockSubmitter.SubmitBeaconBlock(ctx, block)
+	default:
+		return errors.New("unknown message type")
+	}
+}
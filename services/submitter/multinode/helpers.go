@@ -15,33 +15,47 @@ package multinode
 
 import (
 	"context"
-	"strings"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/semaphore"
 )
 
-// serviceInfo returns the service name and provider information.
-func (*Service) serviceInfo(ctx context.Context, submitter interface{}) (string, string) {
-	serviceName := "<unknown>"
-	provider := "<unknown>"
+// address returns the address of the submitter.
+func address(submitter interface{}) string {
 	if service, isService := submitter.(eth2client.Service); isService {
-		provider = service.Address()
+		return service.Address()
 	}
-	if service, isService := submitter.(eth2client.NodeVersionProvider); isService {
-		nodeVersion, err := service.NodeVersion(ctx)
-		if err == nil {
-			switch {
-			case strings.Contains(strings.ToLower(nodeVersion), "lighthouse"):
-				serviceName = "lighthouse"
-			case strings.Contains(strings.ToLower(nodeVersion), "prysm"):
-				serviceName = "prysm"
-			case strings.Contains(strings.ToLower(nodeVersion), "teku"):
-				serviceName = "teku"
-			case strings.Contains(strings.ToLower(nodeVersion), "nimbus"):
-				serviceName = "nimbus"
-			}
-		}
+	return "<unknown>"
+}
+
+// classifyError classifies an error returned by a submitter.  If the error shows that
+// the beacon node already has the message it is not an error as far as we are concerned,
+// so is cleared.
+func (s *Service) classifyError(ctx context.Context, submitter interface{}, messageType string, err error) error {
+	if err == nil {
+		return nil
+	}
+	err = s.classifier.Wrap(ctx, submitter, messageType, err)
+	if errorclassifier.ClassOf(err).Benign() {
+		log.Trace().Str("beacon_node_address", address(submitter)).Str("message_type", messageType).Msg("Node already has message; ignored")
+		return nil
 	}
 
-	return serviceName, provider
+	return err
+}
+
+// withSemaphore wraps a submission so that the semaphore is held for each attempt rather than
+// across all attempts, so that a beacon node that is backing off between retries does not hold
+// up submissions to other beacon nodes.
+func withSemaphore(sem *semaphore.Weighted, submit func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return errors.Wrap(err, "failed to acquire semaphore")
+		}
+		defer sem.Release(1)
+
+		return submit(ctx)
+	}
 }
@@ -0,0 +1,73 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var acknowledgements *prometheus.CounterVec
+var quorums *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if acknowledgements != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	acknowledgements = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "submitter_multinode",
+		Name:      "acknowledgements_total",
+		Help:      "The number of messages accepted by each beacon node.",
+	}, []string{"message_type", "beacon_node"})
+	if err := prometheus.Register(acknowledgements); err != nil {
+		return err
+	}
+
+	quorums = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "submitter_multinode",
+		Name:      "quorums_total",
+		Help:      "The result of waiting for a quorum of beacon nodes to accept messages.",
+	}, []string{"message_type", "result"})
+	return prometheus.Register(quorums)
+}
+
+func monitorAcknowledgement(messageType string, name string) {
+	if acknowledgements == nil {
+		return
+	}
+	acknowledgements.WithLabelValues(messageType, name).Inc()
+}
+
+func monitorQuorum(messageType string, result string) {
+	if quorums == nil {
+		return
+	}
+	quorums.WithLabelValues(messageType, result).Inc()
+}
@@ -17,18 +17,32 @@ package multinode
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/capabilities"
+	nullcapabilities "github.com/attestantio/vouch/services/capabilities/null"
+	"github.com/attestantio/vouch/services/consistency"
+	nullconsistency "github.com/attestantio/vouch/services/consistency/null"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	nullerrorclassifier "github.com/attestantio/vouch/services/errorclassifier/null"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodehealth"
+	nullnodehealth "github.com/attestantio/vouch/services/nodehealth/null"
+	"github.com/attestantio/vouch/services/submitter/retry"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
 
 type parameters struct {
 	logLevel                               zerolog.Level
+	monitor                                metrics.Service
 	timeout                                time.Duration
+	nodeHealth                             nodehealth.Service
+	consistency                            consistency.Service
+	capabilities                           capabilities.Service
 	clientMonitor                          metrics.ClientMonitor
 	processConcurrency                     int64
 	beaconBlockSubmitters                  map[string]eth2client.BeaconBlockSubmitter
@@ -39,6 +53,9 @@ type parameters struct {
 	syncCommitteeMessagesSubmitter         map[string]eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionsSubmitters   map[string]eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitters   map[string]eth2client.SyncCommitteeContributionsSubmitter
+	retrier                                *retry.Service
+	classifier                             errorclassifier.Service
+	quorums                                map[string]int
 }
 
 // Parameter is the interface for service parameters.
@@ -59,6 +76,13 @@ func WithLogLevel(logLevel zerolog.Level) Parameter {
 	})
 }
 
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
 // WithTimeout sets the timeout for calls made by the module.
 func WithTimeout(timeout time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -136,10 +160,60 @@ func WithSyncCommitteeContributionsSubmitters(submitters map[string]eth2client.S
 	})
 }
 
+// WithRetrier sets the retrier for failed submissions.
+// If this is not set failed submissions are not retried.
+func WithRetrier(retrier *retry.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retrier = retrier
+	})
+}
+
+// WithQuorums sets the number of beacon nodes that must accept each type of message
+// before a submission returns.  Message types that are not present require a single
+// beacon node to accept them.
+func WithQuorums(quorums map[string]int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.quorums = quorums
+	})
+}
+
+// WithClassifier sets the classifier for errors returned by beacon nodes.
+func WithClassifier(classifier errorclassifier.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.classifier = classifier
+	})
+}
+
+// WithNodeHealth sets the node health service, used to avoid unhealthy beacon nodes.
+func WithNodeHealth(service nodehealth.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeHealth = service
+	})
+}
+
+// WithConsistency sets the consistency service, used to avoid beacon nodes on the wrong network.
+func WithConsistency(service consistency.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.consistency = service
+	})
+}
+
+// WithCapabilities sets the capabilities service, used to avoid beacon nodes that do not support a request.
+func WithCapabilities(service capabilities.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.capabilities = service
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
 		logLevel:      zerolog.GlobalLevel(),
+		monitor:       nullmetrics.New(context.Background()),
+		classifier:    nullerrorclassifier.New(context.Background()),
+		capabilities:  nullcapabilities.New(context.Background()),
+		nodeHealth:    nullnodehealth.New(context.Background()),
+		consistency:   nullconsistency.New(context.Background()),
 		clientMonitor: nullmetrics.New(context.Background()),
 	}
 	for _, p := range params {
@@ -148,6 +222,21 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 		}
 	}
 
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.classifier == nil {
+		return nil, errors.New("no classifier specified")
+	}
+	if parameters.capabilities == nil {
+		return nil, errors.New("no capabilities service specified")
+	}
+	if parameters.nodeHealth == nil {
+		return nil, errors.New("no node health service specified")
+	}
+	if parameters.consistency == nil {
+		return nil, errors.New("no consistency service specified")
+	}
 	if parameters.timeout == 0 {
 		return nil, errors.New("no timeout specified")
 	}
@@ -181,6 +270,11 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if len(parameters.syncCommitteeContributionsSubmitters) == 0 {
 		return nil, errors.New("no sync committee contributions submitters specified")
 	}
+	for messageType, quorum := range parameters.quorums {
+		if quorum < 1 {
+			return nil, fmt.Errorf("quorum for %s must be at least 1", messageType)
+		}
+	}
 
 	return &parameters, nil
 }
@@ -0,0 +1,83 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multinode
+
+import (
+	"sync"
+	"time"
+)
+
+// quorum tracks the beacon nodes that have accepted a submission.
+type quorum struct {
+	messageType string
+	required    int
+	mu          sync.Mutex
+	acks        int
+	reached     chan struct{}
+}
+
+// newQuorum creates a quorum for the submission of a message type to a number of beacon nodes.
+func (s *Service) newQuorum(messageType string, nodes int) *quorum {
+	required, exists := s.quorums[messageType]
+	if !exists {
+		required = 1
+	}
+	if required > nodes {
+		required = nodes
+	}
+
+	return &quorum{
+		messageType: messageType,
+		required:    required,
+		reached:     make(chan struct{}),
+	}
+}
+
+// ack records that the named beacon node has accepted the submission.
+func (q *quorum) ack(name string) {
+	monitorAcknowledgement(q.messageType, name)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.acks++
+	if q.acks == q.required {
+		close(q.reached)
+	}
+}
+
+// wait waits until either the quorum is reached or the timeout passes, returning the
+// number of beacon nodes that have accepted the submission at that point.
+// Submissions that are still outstanding continue in the background.
+func (q *quorum) wait(timeout time.Duration) int {
+	select {
+	case <-q.reached:
+	case <-time.After(timeout):
+	}
+
+	q.mu.Lock()
+	acks := q.acks
+	q.mu.Unlock()
+
+	switch {
+	case acks >= q.required:
+		monitorQuorum(q.messageType, "reached")
+	case acks > 0:
+		log.Warn().Str("message_type", q.messageType).Int("required", q.required).Int("acknowledgements", acks).Msg("Quorum not reached before timeout")
+		monitorQuorum(q.messageType, "partial")
+	default:
+		monitorQuorum(q.messageType, "failed")
+	}
+
+	return acks
+}
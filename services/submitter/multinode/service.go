@@ -18,7 +18,12 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/errorclassifier"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/services/submitter/retry"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -28,6 +33,9 @@ import (
 type Service struct {
 	clientMonitor                         metrics.ClientMonitor
 	timeout                               time.Duration
+	nodeHealth                            nodehealth.Service
+	consistency                           consistency.Service
+	capabilities                          capabilities.Service
 	processConcurrency                    int64
 	beaconBlockSubmitters                 map[string]eth2client.BeaconBlockSubmitter
 	attestationsSubmitters                map[string]eth2client.AttestationsSubmitter
@@ -37,13 +45,16 @@ type Service struct {
 	syncCommitteeMessagesSubmitter        map[string]eth2client.SyncCommitteeMessagesSubmitter
 	syncCommitteeSubscriptionSubmitters   map[string]eth2client.SyncCommitteeSubscriptionsSubmitter
 	syncCommitteeContributionsSubmitters  map[string]eth2client.SyncCommitteeContributionsSubmitter
+	retrier                               *retry.Service
+	classifier                            errorclassifier.Service
+	quorums                               map[string]int
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new beacon block propsal strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -55,9 +66,16 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
 	s := &Service{
 		clientMonitor:                         parameters.clientMonitor,
 		timeout:                               parameters.timeout,
+		nodeHealth:                            parameters.nodeHealth,
+		consistency:                           parameters.consistency,
+		capabilities:                          parameters.capabilities,
 		processConcurrency:                    parameters.processConcurrency,
 		beaconBlockSubmitters:                 parameters.beaconBlockSubmitters,
 		attestationsSubmitters:                parameters.attestationsSubmitters,
@@ -67,6 +85,9 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		syncCommitteeMessagesSubmitter:        parameters.syncCommitteeMessagesSubmitter,
 		syncCommitteeSubscriptionSubmitters:   parameters.syncCommitteeSubscriptionsSubmitters,
 		syncCommitteeContributionsSubmitters:  parameters.syncCommitteeContributionsSubmitters,
+		retrier:                               parameters.retrier,
+		classifier:                            parameters.classifier,
+		quorums:                               parameters.quorums,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -373,6 +373,42 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no sync committee contributions submitters specified",
 		},
+		{
+			name: "MonitorMissing",
+			params: []multinode.Parameter{
+				multinode.WithLogLevel(zerolog.Disabled),
+				multinode.WithMonitor(nil),
+				multinode.WithTimeout(2 * time.Second),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithBeaconBlockSubmitters(beaconBlockSubmitters),
+				multinode.WithAttestationsSubmitters(attestationsSubmitters),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(beaconCommitteeSubscriptionsSubmitters),
+				multinode.WithAggregateAttestationsSubmitters(aggregateAttestationsSubmitters),
+				multinode.WithProposalPreparationsSubmitters(proposalPrepartionsSubmitters),
+				multinode.WithSyncCommitteeMessagesSubmitters(syncCommitteeMessagesSubmitters),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(syncCommitteeSubscriptionsSubmitters),
+				multinode.WithSyncCommitteeContributionsSubmitters(syncCommitteeContributionsSubmitters),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "QuorumZero",
+			params: []multinode.Parameter{
+				multinode.WithLogLevel(zerolog.Disabled),
+				multinode.WithTimeout(2 * time.Second),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithBeaconBlockSubmitters(beaconBlockSubmitters),
+				multinode.WithAttestationsSubmitters(attestationsSubmitters),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(beaconCommitteeSubscriptionsSubmitters),
+				multinode.WithAggregateAttestationsSubmitters(aggregateAttestationsSubmitters),
+				multinode.WithProposalPreparationsSubmitters(proposalPrepartionsSubmitters),
+				multinode.WithSyncCommitteeMessagesSubmitters(syncCommitteeMessagesSubmitters),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(syncCommitteeSubscriptionsSubmitters),
+				multinode.WithSyncCommitteeContributionsSubmitters(syncCommitteeContributionsSubmitters),
+				multinode.WithQuorums(map[string]int{"beaconblock": 0}),
+			},
+			err: "problem with parameters: quorum for beaconblock must be at least 1",
+		},
 		{
 			name: "Good",
 			params: []multinode.Parameter{
@@ -15,7 +15,6 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -30,51 +29,45 @@ func (s *Service) SubmitAggregateAttestations(ctx context.Context, aggregates []
 		return errors.New("no aggregate attestations supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.aggregateAttestationsSubmitters {
-		go s.submitAggregateAttestations(ctx, sem, w, name, aggregates, submitter)
+	names := make([]string, 0, len(s.aggregateAttestationsSubmitters))
+	for name := range s.aggregateAttestationsSubmitters {
+		names = append(names, name)
+	}
+	names = s.nodeHealth.Filter(s.consistency.Filter(names))
+	q := s.newQuorum("aggregateattestation", len(names))
+	for _, name := range names {
+		go s.submitAggregateAttestations(ctx, sem, q, name, aggregates, s.aggregateAttestationsSubmitters[name])
+	}
+	if q.wait(s.timeout) == 0 {
+		return errors.New("no successful submissions before timeout")
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return nil
 }
 
 // submitAggregateAttestations carries out the internal work of submitting aggregate attestations.
 // skipcq: RVV-B0001
 func (s *Service) submitAggregateAttestations(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	aggregates []*phase0.SignedAggregateAndProof,
 	submitter eth2client.AggregateAttestationsSubmitter,
 ) {
 	log := log.With().Str("beacon_node_address", name).Uint64("slot", uint64(aggregates[0].Message.Aggregate.Data.Slot)).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
-	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
-	err := submitter.SubmitAggregateAttestations(ctx, aggregates)
+	err := s.retrier.Do(ctx, "aggregateattestation", aggregates[0].Message.Aggregate.Data.Slot, name, withSemaphore(sem, func(ctx context.Context) error {
+		return s.classifyError(ctx, submitter, "aggregateattestation", submitter.SubmitAggregateAttestations(ctx, aggregates))
+	}))
 
-	s.clientMonitor.ClientOperation(address, "submit aggregate attestations", err == nil, time.Since(started))
+	s.clientMonitor.ClientOperation(address(submitter), "submit aggregate attestations", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit aggregate attestations")
 		return
 	}
 
-	w.Signal()
+	q.ack(name)
 	log.Trace().Msg("Submitted aggregate attestations")
 }
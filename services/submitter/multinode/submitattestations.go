@@ -21,6 +21,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/errorclassifier"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/semaphore"
@@ -32,80 +33,93 @@ func (s *Service) SubmitAttestations(ctx context.Context, attestations []*phase0
 		return errors.New("no attestations supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.attestationsSubmitters {
-		go s.submitAttestations(ctx, sem, w, name, attestations, submitter)
+	names := make([]string, 0, len(s.attestationsSubmitters))
+	for name := range s.attestationsSubmitters {
+		names = append(names, name)
+	}
+	names = s.nodeHealth.Filter(s.consistency.Filter(names))
+	q := s.newQuorum("attestation", len(names))
+	for _, name := range names {
+		go s.submitAttestations(ctx, sem, q, name, attestations, s.attestationsSubmitters[name])
+	}
+	if q.wait(s.timeout) == 0 {
+		return errors.New("no successful submissions before timeout")
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return nil
 }
 
 // submitAttestations carries out the internal work of submitting attestations.
 // skipcq: RVV-B0001
 func (s *Service) submitAttestations(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	attestations []*phase0.Attestation,
 	submitter eth2client.AttestationsSubmitter,
 ) {
 	log := log.With().Str("beacon_node_address", name).Uint64("slot", uint64(attestations[0].Data.Slot)).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
-	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
-	_, err := util.Scatter(len(attestations), int(s.processConcurrency), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
-		return nil, submitter.SubmitAttestations(ctx, attestations[offset:offset+entries])
-	})
-	if err != nil {
-		err = s.handleAttestationsError(ctx, submitter, err)
-	}
+	// Only the batches that fail are retried, so that attestations the beacon node has accepted are not sent again.
+	pending := attestations
+	rejected := false
+	err := s.retrier.Do(ctx, "attestation", attestations[0].Data.Slot, name, withSemaphore(sem, func(ctx context.Context) error {
+		failed := make([]*phase0.Attestation, 0)
+		_, err := util.Scatter(len(pending), int(s.processConcurrency), func(offset int, entries int, mu *sync.RWMutex) (interface{}, error) {
+			batch := pending[offset : offset+entries]
+			unknownHead, err := s.handleAttestationsError(ctx, submitter, submitter.SubmitAttestations(ctx, batch))
+			if err != nil {
+				mu.Lock()
+				failed = append(failed, batch...)
+				mu.Unlock()
+				return nil, err
+			}
+			if unknownHead {
+				mu.Lock()
+				rejected = true
+				mu.Unlock()
+			}
+			return nil, nil
+		})
+		pending = failed
+		return err
+	}))
 
-	s.clientMonitor.ClientOperation(address, "submit attestations", err == nil, time.Since(started))
+	s.clientMonitor.ClientOperation(address(submitter), "submit attestations", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit attestations")
 		return
 	}
+	if rejected {
+		// The node did not accept the attestations, so does not count towards the quorum.
+		log.Debug().Msg("Node rejected attestations as it does not know the head block")
+		return
+	}
 
-	w.Signal()
+	q.ack(name)
 	log.Trace().Msg("Submitted attestations")
 }
 
+// handleAttestationsError handles an error returned when submitting attestations.
+// It returns true if the error was cleared because the node does not know the head block.
 func (s *Service) handleAttestationsError(ctx context.Context,
 	submitter eth2client.AttestationsSubmitter,
 	err error,
-) error {
-	serverType, _ := s.serviceInfo(ctx, submitter)
-	switch {
-	case serverType == "lighthouse" && strings.Contains(err.Error(), "PriorAttestationKnown"):
-		// Lighthouse rejects duplicate attestations.  It is possible that an attestation we sent
-		// to another node already propagated to this node, so ignore the error.
-		log.Trace().Msg("Node already knows about attestation; ignored")
-		// Not an error as far as we are concerned, so clear it.
-		err = nil
-	case serverType == "lighthouse" && strings.Contains(err.Error(), "UnknownHeadBlock"):
-		// Lighthouse rejects an attestation for a block  that is not its current head.  It is possible
+) (
+	bool,
+	error,
+) {
+	err = s.classifyError(ctx, submitter, "attestation", err)
+	if errorclassifier.ClassOf(err) == errorclassifier.ClassNodeBehind && strings.Contains(err.Error(), "UnknownHeadBlock") {
+		// Lighthouse rejects an attestation for a block that is not its current head.  It is possible
 		// that the node is just behind, and we can't do anything about it anyway at this point having
 		// already signed an attestation for this slot, so ignore the error.
 		log.Debug().Err(err).Msg("Node does not know head block; rejected")
 		// Not an error as far as we are concerned, so clear it.
-		err = nil
+		return true, nil
 	}
 
-	return err
+	return false, err
 }
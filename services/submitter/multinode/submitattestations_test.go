@@ -15,13 +15,18 @@ package multinode_test
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	standarderrorclassifier "github.com/attestantio/vouch/services/errorclassifier/standard"
 	"github.com/attestantio/vouch/services/submitter/multinode"
+	"github.com/attestantio/vouch/services/submitter/retry"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/attestantio/vouch/testutil"
 	"github.com/rs/zerolog"
@@ -280,3 +285,208 @@ func TestSubmitAttestationsSleepySuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+// batchAttestationsSubmitter fails the first submission of each listed attestation with a retryable error,
+// and records the attestations that it is sent.
+type batchAttestationsSubmitter struct {
+	mu        sync.Mutex
+	failSlots map[phase0.Slot]bool
+	submitted []phase0.Slot
+}
+
+func (m *batchAttestationsSubmitter) SubmitAttestations(_ context.Context, attestations []*phase0.Attestation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, attestation := range attestations {
+		m.submitted = append(m.submitted, attestation.Data.Slot)
+	}
+	for _, attestation := range attestations {
+		if m.failSlots[attestation.Data.Slot] {
+			delete(m.failSlots, attestation.Data.Slot)
+			return errors.New("POST failed with status 503")
+		}
+	}
+	return nil
+}
+
+func newRetrier(t *testing.T, backoff time.Duration) *retry.Service {
+	t.Helper()
+
+	chainTime, err := standardchaintime.New(context.Background(),
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+	retrier, err := retry.New(context.Background(),
+		retry.WithLogLevel(zerolog.Disabled),
+		retry.WithChainTime(chainTime),
+		retry.WithPolicies(map[string]*retry.Policy{
+			"attestation": {MaxAttempts: 3, InitialBackoff: backoff, MaxBackoff: backoff},
+		}),
+	)
+	require.NoError(t, err)
+	return retrier
+}
+
+func newAttestationsService(t *testing.T, retrier *retry.Service, concurrency int64, submitters map[string]eth2client.AttestationsSubmitter) *multinode.Service {
+	t.Helper()
+
+	s, err := multinode.New(context.Background(),
+		multinode.WithLogLevel(zerolog.Disabled),
+		multinode.WithTimeout(time.Second),
+		multinode.WithProcessConcurrency(concurrency),
+		multinode.WithRetrier(retrier),
+		multinode.WithAttestationsSubmitters(submitters),
+		multinode.WithBeaconBlockSubmitters(map[string]eth2client.BeaconBlockSubmitter{
+			"1": mock.NewBeaconBlockSubmitter(),
+		}),
+		multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+			"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+			"1": mock.NewAggregateAttestationsSubmitter(),
+		}),
+		multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+			"1": mock.NewProposalPreparationsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+			"1": mock.NewSyncCommitteeMessagesSubmitter(),
+		}),
+		multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+			"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+			"1": mock.NewSyncCommitteeContributionsSubmitter(),
+		}),
+	)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSubmitAttestationsRetryFailedBatches(t *testing.T) {
+	ctx := context.Background()
+
+	submitter := &batchAttestationsSubmitter{
+		failSlots: map[phase0.Slot]bool{2: true},
+	}
+	s := newAttestationsService(t, newRetrier(t, time.Millisecond), 2, map[string]eth2client.AttestationsSubmitter{
+		"1": submitter,
+	})
+
+	// Two attestations with a concurrency of two results in two batches of one.
+	err := s.SubmitAttestations(ctx, []*phase0.Attestation{
+		{Data: &phase0.AttestationData{Slot: 1}},
+		{Data: &phase0.AttestationData{Slot: 2}},
+	})
+	require.NoError(t, err)
+
+	// Only the failed batch should have been sent again.
+	submitter.mu.Lock()
+	defer submitter.mu.Unlock()
+	require.ElementsMatch(t, []phase0.Slot{1, 2, 2}, submitter.submitted)
+}
+
+func TestSubmitAttestationsRetryReleasesSemaphore(t *testing.T) {
+	ctx := context.Background()
+
+	// With a concurrency of one, a beacon node backing off must not hold up the other.
+	s := newAttestationsService(t, newRetrier(t, 500*time.Millisecond), 1, map[string]eth2client.AttestationsSubmitter{
+		"1": &batchAttestationsSubmitter{failSlots: map[phase0.Slot]bool{1: true}},
+		"2": &batchAttestationsSubmitter{},
+	})
+
+	started := time.Now()
+	err := s.SubmitAttestations(ctx, []*phase0.Attestation{
+		{Data: &phase0.AttestationData{Slot: 1}},
+	})
+	require.NoError(t, err)
+	require.Less(t, time.Since(started), 250*time.Millisecond)
+}
+
+// syncingAttestationsSubmitter rejects attestations as the beacon node is syncing.
+type syncingAttestationsSubmitter struct{}
+
+func (*syncingAttestationsSubmitter) SubmitAttestations(_ context.Context, _ []*phase0.Attestation) error {
+	return errors.New("POST failed with status 503: beacon node is currently syncing")
+}
+
+func TestSubmitAttestationsSyncingQuorum(t *testing.T) {
+	ctx := context.Background()
+
+	classifier, err := standarderrorclassifier.New(ctx,
+		standarderrorclassifier.WithLogLevel(zerolog.Disabled),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		submitters map[string]eth2client.AttestationsSubmitter
+		err        string
+	}{
+		{
+			name: "Partial",
+			submitters: map[string]eth2client.AttestationsSubmitter{
+				"1": mock.NewAttestationsSubmitter(),
+				"2": &syncingAttestationsSubmitter{},
+			},
+		},
+		{
+			name: "None",
+			submitters: map[string]eth2client.AttestationsSubmitter{
+				"1": &syncingAttestationsSubmitter{},
+				"2": &syncingAttestationsSubmitter{},
+			},
+			err: "no successful submissions before timeout",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := multinode.New(ctx,
+				multinode.WithLogLevel(zerolog.Disabled),
+				multinode.WithTimeout(100*time.Millisecond),
+				multinode.WithProcessConcurrency(2),
+				multinode.WithClassifier(classifier),
+				multinode.WithRetrier(newRetrier(t, time.Millisecond)),
+				multinode.WithQuorums(map[string]int{"attestation": 2}),
+				multinode.WithAttestationsSubmitters(test.submitters),
+				multinode.WithBeaconBlockSubmitters(map[string]eth2client.BeaconBlockSubmitter{
+					"1": mock.NewBeaconBlockSubmitter(),
+				}),
+				multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+					"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+					"1": mock.NewAggregateAttestationsSubmitter(),
+				}),
+				multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+					"1": mock.NewProposalPreparationsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+					"1": mock.NewSyncCommitteeMessagesSubmitter(),
+				}),
+				multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+					"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+				}),
+				multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+					"1": mock.NewSyncCommitteeContributionsSubmitter(),
+				}),
+			)
+			require.NoError(t, err)
+
+			// The syncing node does not count towards the quorum, so the submission waits for the timeout.
+			started := time.Now()
+			err = s.SubmitAttestations(ctx, []*phase0.Attestation{
+				{Data: &phase0.AttestationData{Slot: 1}},
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.GreaterOrEqual(t, time.Since(started), 100*time.Millisecond)
+		})
+	}
+}
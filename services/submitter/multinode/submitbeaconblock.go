@@ -15,7 +15,6 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -30,30 +29,28 @@ func (s *Service) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSi
 		return errors.New("no beacon block supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.beaconBlockSubmitters {
-		go s.submitBeaconBlock(ctx, sem, w, name, block, submitter)
+	names := make([]string, 0, len(s.beaconBlockSubmitters))
+	for name := range s.beaconBlockSubmitters {
+		names = append(names, name)
+	}
+	names = s.nodeHealth.Filter(s.consistency.Filter(names))
+	q := s.newQuorum("beaconblock", len(names))
+	for _, name := range names {
+		go s.submitBeaconBlock(ctx, sem, q, name, block, s.beaconBlockSubmitters[name])
+	}
+	if q.wait(s.timeout) == 0 {
+		return errors.New("no successful submissions before timeout")
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return nil
 }
 
 // submitBeaconBlock carries out the internal work of submitting beacon blocks.
 // skipcq: RVV-B0001
 func (s *Service) submitBeaconBlock(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	block *spec.VersionedSignedBeaconBlock,
 	submitter eth2client.BeaconBlockSubmitter,
@@ -64,22 +61,18 @@ func (s *Service) submitBeaconBlock(ctx context.Context,
 		return
 	}
 	log := log.With().Str("beacon_node_address", name).Uint64("slot", uint64(slot)).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
-	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
-	err = submitter.SubmitBeaconBlock(ctx, block)
+	err = s.retrier.Do(ctx, "beaconblock", slot, name, withSemaphore(sem, func(ctx context.Context) error {
+		return s.classifyError(ctx, submitter, "beaconblock", submitter.SubmitBeaconBlock(ctx, block))
+	}))
 
-	s.clientMonitor.ClientOperation(address, "submit beacon block", err == nil, time.Since(started))
+	s.clientMonitor.ClientOperation(address(submitter), "submit beacon block", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit beacon block")
 		return
 	}
 
-	w.Signal()
+	q.ack(name)
 	log.Trace().Msg("Submitted beacon block")
 }
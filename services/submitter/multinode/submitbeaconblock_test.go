@@ -248,3 +248,105 @@ func TestSubmitBeaconBlockSleepySuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestSubmitBeaconBlockQuorum(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := multinode.New(context.Background(),
+		multinode.WithLogLevel(zerolog.Disabled),
+		multinode.WithTimeout(time.Second),
+		multinode.WithProcessConcurrency(3),
+		multinode.WithBeaconBlockSubmitters(map[string]eth2client.BeaconBlockSubmitter{
+			"1": mock.NewBeaconBlockSubmitter(),
+			"2": mock.NewBeaconBlockSubmitter(),
+			"3": mock.NewSleepyBeaconBlockSubmitter(100*time.Millisecond, mock.NewBeaconBlockSubmitter()),
+		}),
+		multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+			"1": mock.NewAttestationsSubmitter(),
+		}),
+		multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+			"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+			"1": mock.NewAggregateAttestationsSubmitter(),
+		}),
+		multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+			"1": mock.NewProposalPreparationsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+			"1": mock.NewSyncCommitteeMessagesSubmitter(),
+		}),
+		multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+			"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+			"1": mock.NewSyncCommitteeContributionsSubmitter(),
+		}),
+		multinode.WithQuorums(map[string]int{"beaconblock": 3}),
+	)
+	require.NoError(t, err)
+
+	// Should wait for the sleepy submitter, but not for the timeout.
+	started := time.Now()
+	err = s.SubmitBeaconBlock(ctx, &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionAltair,
+		Altair: &altair.SignedBeaconBlock{
+			Message: &altair.BeaconBlock{
+				Slot: 1,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(started), 100*time.Millisecond)
+	require.Less(t, time.Since(started), time.Second)
+}
+
+func TestSubmitBeaconBlockQuorumPartial(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := multinode.New(context.Background(),
+		multinode.WithLogLevel(zerolog.Disabled),
+		multinode.WithTimeout(100*time.Millisecond),
+		multinode.WithProcessConcurrency(2),
+		multinode.WithBeaconBlockSubmitters(map[string]eth2client.BeaconBlockSubmitter{
+			"1": mock.NewBeaconBlockSubmitter(),
+			"2": mock.NewErroringBeaconBlockSubmitter(),
+		}),
+		multinode.WithAttestationsSubmitters(map[string]eth2client.AttestationsSubmitter{
+			"1": mock.NewAttestationsSubmitter(),
+		}),
+		multinode.WithBeaconCommitteeSubscriptionsSubmitters(map[string]eth2client.BeaconCommitteeSubscriptionsSubmitter{
+			"1": mock.NewBeaconCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithAggregateAttestationsSubmitters(map[string]eth2client.AggregateAttestationsSubmitter{
+			"1": mock.NewAggregateAttestationsSubmitter(),
+		}),
+		multinode.WithProposalPreparationsSubmitters(map[string]eth2client.ProposalPreparationsSubmitter{
+			"1": mock.NewProposalPreparationsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeMessagesSubmitters(map[string]eth2client.SyncCommitteeMessagesSubmitter{
+			"1": mock.NewSyncCommitteeMessagesSubmitter(),
+		}),
+		multinode.WithSyncCommitteeSubscriptionsSubmitters(map[string]eth2client.SyncCommitteeSubscriptionsSubmitter{
+			"1": mock.NewSyncCommitteeSubscriptionsSubmitter(),
+		}),
+		multinode.WithSyncCommitteeContributionsSubmitters(map[string]eth2client.SyncCommitteeContributionsSubmitter{
+			"1": mock.NewSyncCommitteeContributionsSubmitter(),
+		}),
+		multinode.WithQuorums(map[string]int{"beaconblock": 2}),
+	)
+	require.NoError(t, err)
+
+	// Quorum is not reached, but one node accepted the block so the submission succeeds at the timeout.
+	started := time.Now()
+	err = s.SubmitBeaconBlock(ctx, &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionAltair,
+		Altair: &altair.SignedBeaconBlock{
+			Message: &altair.BeaconBlock{
+				Slot: 1,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(started), 100*time.Millisecond)
+}
@@ -15,7 +15,6 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -30,51 +29,45 @@ func (s *Service) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscr
 		return errors.New("no subscriptions supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.beaconCommitteeSubscriptionSubmitters {
-		go s.submitBeaconCommitteeSubscriptions(ctx, sem, w, name, subscriptions, submitter)
+	names := make([]string, 0, len(s.beaconCommitteeSubscriptionSubmitters))
+	for name := range s.beaconCommitteeSubscriptionSubmitters {
+		names = append(names, name)
+	}
+	names = s.nodeHealth.Filter(s.consistency.Filter(names))
+	q := s.newQuorum("beaconcommitteesubscription", len(names))
+	for _, name := range names {
+		go s.submitBeaconCommitteeSubscriptions(ctx, sem, q, name, subscriptions, s.beaconCommitteeSubscriptionSubmitters[name])
+	}
+	if q.wait(s.timeout) == 0 {
+		return errors.New("no successful submissions before timeout")
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return nil
 }
 
 // submitBeaconCommitteeSubscriptions carries out the internal work of submitting beacon committee subscriptions.
 // skipcq: RVV-B0001
 func (s *Service) submitBeaconCommitteeSubscriptions(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	subscriptions []*api.BeaconCommitteeSubscription,
 	submitter eth2client.BeaconCommitteeSubscriptionsSubmitter,
 ) {
 	log := log.With().Str("beacon_node_address", name).Int("subscriptions", len(subscriptions)).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
-	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
-	err := submitter.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
+	err := s.retrier.Do(ctx, "beaconcommitteesubscription", 0, name, withSemaphore(sem, func(ctx context.Context) error {
+		return s.classifyError(ctx, submitter, "beaconcommitteesubscription", submitter.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions))
+	}))
 
-	s.clientMonitor.ClientOperation(address, "submit beacon committee subscription", err == nil, time.Since(started))
+	s.clientMonitor.ClientOperation(address(submitter), "submit beacon committee subscription", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit beacon committee subscription")
 		return
 	}
 
-	w.Signal()
+	q.ack(name)
 	log.Trace().Msg("Submitted beacon committee subscriptions")
 }
@@ -15,11 +15,11 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/vouch/services/capabilities"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/semaphore"
 )
@@ -30,51 +30,45 @@ func (s *Service) SubmitProposalPreparations(ctx context.Context, preparations [
 		return errors.New("no proposal preparations supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.proposalPreparationsSubmitters {
-		go s.submitProposalPreparations(ctx, sem, w, name, preparations, submitter)
+	names := make([]string, 0, len(s.proposalPreparationsSubmitters))
+	for name := range s.proposalPreparationsSubmitters {
+		names = append(names, name)
+	}
+	names = s.capabilities.Filter(capabilities.CapabilityProposalPreparations, s.nodeHealth.Filter(s.consistency.Filter(names)))
+	q := s.newQuorum("proposalpreparation", len(names))
+	for _, name := range names {
+		go s.submitProposalPreparations(ctx, sem, q, name, preparations, s.proposalPreparationsSubmitters[name])
+	}
+	if q.wait(s.timeout) == 0 {
+		return errors.New("no successful proposal preparations before timeout")
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful proposal preparations before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return nil
 }
 
 // submitProposalPreparations carries out the internal work of submitting proposal preparations.
 // skipcq: RVV-B0001
 func (s *Service) submitProposalPreparations(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	preparations []*api.ProposalPreparation,
 	submitter eth2client.ProposalPreparationsSubmitter,
 ) {
 	log := log.With().Str("beacon_node_address", name).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
-	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
-	err := submitter.SubmitProposalPreparations(ctx, preparations)
+	err := s.retrier.Do(ctx, "proposalpreparation", 0, name, withSemaphore(sem, func(ctx context.Context) error {
+		return s.classifyError(ctx, submitter, "proposalpreparation", submitter.SubmitProposalPreparations(ctx, preparations))
+	}))
 
-	s.clientMonitor.ClientOperation(address, "submit proposal preparations", err == nil, time.Since(started))
+	s.clientMonitor.ClientOperation(address(submitter), "submit proposal preparations", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit proposal preparations")
 		return
 	}
 
-	w.Signal()
+	q.ack(name)
 	log.Trace().Msg("Submitted proposal preparations")
 }
@@ -15,13 +15,11 @@ package multinode
 
 import (
 	"context"
-	"encoding/json"
-	"strings"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/vouch/services/capabilities"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/semaphore"
 )
@@ -32,135 +30,45 @@ func (s *Service) SubmitSyncCommitteeMessages(ctx context.Context, messages []*a
 		return errors.New("no sync committee messages supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.syncCommitteeMessagesSubmitter {
-		go s.submitSyncCommitteeMessages(ctx, sem, w, name, messages, submitter)
+	names := make([]string, 0, len(s.syncCommitteeMessagesSubmitter))
+	for name := range s.syncCommitteeMessagesSubmitter {
+		names = append(names, name)
+	}
+	names = s.capabilities.Filter(capabilities.CapabilitySyncCommitteeMessages, s.nodeHealth.Filter(s.consistency.Filter(names)))
+	q := s.newQuorum("synccommitteemessage", len(names))
+	for _, name := range names {
+		go s.submitSyncCommitteeMessages(ctx, sem, q, name, messages, s.syncCommitteeMessagesSubmitter[name])
+	}
+	if q.wait(s.timeout) == 0 {
+		return errors.New("no successful submissions before timeout")
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return nil
 }
 
 // submitSyncCommitteeMessages carries out the internal work of submitting sync committee messages.
 // skipcq: RVV-B0001
 func (s *Service) submitSyncCommitteeMessages(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	messages []*altair.SyncCommitteeMessage,
 	submitter eth2client.SyncCommitteeMessagesSubmitter,
 ) {
 	log := log.With().Str("beacon_node_address", name).Uint64("slot", uint64(messages[0].Slot)).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
-	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
-	err := submitter.SubmitSyncCommitteeMessages(ctx, messages)
-	if err != nil {
-		err = s.handleSubmitSyncCommitteeMessagesError(ctx, submitter, err)
-	}
+	err := s.retrier.Do(ctx, "synccommitteemessage", messages[0].Slot, name, withSemaphore(sem, func(ctx context.Context) error {
+		return s.classifyError(ctx, submitter, "synccommitteemessage", submitter.SubmitSyncCommitteeMessages(ctx, messages))
+	}))
 
-	s.clientMonitor.ClientOperation(address, "submit sync committee messages", err == nil, time.Since(started))
+	s.clientMonitor.ClientOperation(address(submitter), "submit sync committee messages", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit sync committee messages")
 		return
 	}
 
-	w.Signal()
+	q.ack(name)
 	log.Trace().Msg("Submitted sync committee messages")
 }
-
-type lhErrorResponse struct {
-	Code     int                       `json:"code"`
-	Message  string                    `json:"string"`
-	Failures []*lhErrorResponseFailure `json:"failures"`
-}
-
-type lhErrorResponseFailure struct {
-	Index   int    `json:"index"`
-	Message string `json:"message"`
-}
-
-type tekuErrorResponse struct {
-	Code     string                      `json:"code"`
-	Message  string                      `json:"string"`
-	Failures []*tekuErrorResponseFailure `json:"failures"`
-}
-
-type tekuErrorResponseFailure struct {
-	Index   string `json:"index"`
-	Message string `json:"message"`
-}
-
-func (s *Service) handleSubmitSyncCommitteeMessagesError(ctx context.Context,
-	submitter eth2client.SyncCommitteeMessagesSubmitter,
-	err error,
-) error {
-	// Fetch the JSON response from the error.
-	errorStr := err.Error()
-	jsonIndex := strings.Index(errorStr, "{")
-	if jsonIndex == -1 {
-		log.Warn().Err(err).Msg("Failed to submit sync committee messages")
-		return err
-	}
-
-	serverType, provider := s.serviceInfo(ctx, submitter)
-	allowedFailures := 0
-	switch serverType {
-	case "lighthouse":
-		resp := lhErrorResponse{}
-		if jsonErr := json.Unmarshal([]byte(errorStr[jsonIndex:]), &resp); jsonErr != nil {
-			log.Warn().Err(err).Msg("Failed to submit sync committee messages")
-			return err
-		}
-		for i := 0; i < len(resp.Failures); i++ {
-			switch {
-			case strings.HasPrefix(resp.Failures[i].Message, "Verification: PriorSyncCommitteeMessageKnown"):
-				log.Trace().Str("provider", provider).Int("index", resp.Failures[i].Index).Msg("Message already received for that slot; ignoring")
-				allowedFailures++
-			default:
-				log.Trace().Str("provider", provider).Int("index", resp.Failures[i].Index).Str("msg", resp.Failures[i].Message).Msg("Real lighthouse error")
-			}
-		}
-		if len(resp.Failures) == allowedFailures {
-			log.Trace().Str("provider", provider).Msg("Errors from node are allowable; continuing")
-			return nil
-		}
-	case "teku":
-		resp := tekuErrorResponse{}
-		if jsonErr := json.Unmarshal([]byte(errorStr[jsonIndex:]), &resp); jsonErr != nil {
-			log.Trace().Err(err).Msg("Failed to submit sync committee messages")
-			return err
-		}
-		for i := 0; i < len(resp.Failures); i++ {
-			switch {
-			case resp.Failures[i].Message == "Ignoring sync committee message as a duplicate was processed during validation":
-				log.Trace().Str("provider", provider).Str("index", resp.Failures[i].Index).Msg("Message already received for that slot; ignoring")
-				allowedFailures++
-			default:
-				log.Trace().Str("provider", provider).Str("index", resp.Failures[i].Index).Str("msg", resp.Failures[i].Message).Msg("Real teku error")
-			}
-		}
-		if len(resp.Failures) == allowedFailures {
-			log.Trace().Str("provider", provider).Msg("Errors from Lighthouse node are allowable; continuing")
-			return nil
-		}
-	}
-
-	log.Warn().Str("server", serverType).Err(err).Msg("Failed to submit sync committee messages")
-	return err
-}
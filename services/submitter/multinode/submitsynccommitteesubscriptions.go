@@ -15,11 +15,11 @@ package multinode
 
 import (
 	"context"
-	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/vouch/services/capabilities"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/semaphore"
 )
@@ -30,51 +30,45 @@ func (s *Service) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscrip
 		return errors.New("no sync committee subscriptions supplied")
 	}
 
-	var err error
 	sem := semaphore.NewWeighted(s.processConcurrency)
-	w := sync.NewCond(&sync.Mutex{})
-	w.L.Lock()
-	for name, submitter := range s.syncCommitteeSubscriptionSubmitters {
-		go s.submitSyncCommitteeSubscriptions(ctx, sem, w, name, subscriptions, submitter)
+	names := make([]string, 0, len(s.syncCommitteeSubscriptionSubmitters))
+	for name := range s.syncCommitteeSubscriptionSubmitters {
+		names = append(names, name)
+	}
+	names = s.capabilities.Filter(capabilities.CapabilitySyncCommitteeSubscriptions, s.nodeHealth.Filter(s.consistency.Filter(names)))
+	q := s.newQuorum("synccommitteesubscription", len(names))
+	for _, name := range names {
+		go s.submitSyncCommitteeSubscriptions(ctx, sem, q, name, subscriptions, s.syncCommitteeSubscriptionSubmitters[name])
+	}
+	if q.wait(s.timeout) == 0 {
+		return errors.New("no successful submissions before timeout")
 	}
-	// Also set a timeout condition, in case no submitters return.
-	go func(s *Service, w *sync.Cond) {
-		time.Sleep(s.timeout)
-		err = errors.New("no successful submissions before timeout")
-		w.Signal()
-	}(s, w)
-	w.Wait()
-	w.L.Unlock()
 
-	return err
+	return nil
 }
 
 // submitSyncCommitteeSubscriptions carries out the internal work of submitting sync committee subscriptions.
 // skipcq: RVV-B0001
 func (s *Service) submitSyncCommitteeSubscriptions(ctx context.Context,
 	sem *semaphore.Weighted,
-	w *sync.Cond,
+	q *quorum,
 	name string,
 	subscriptions []*api.SyncCommitteeSubscription,
 	submitter eth2client.SyncCommitteeSubscriptionsSubmitter,
 ) {
 	log := log.With().Str("beacon_node_address", name).Int("subscriptions", len(subscriptions)).Logger()
-	if err := sem.Acquire(ctx, 1); err != nil {
-		log.Error().Err(err).Msg("Failed to acquire semaphore")
-		return
-	}
-	defer sem.Release(1)
 
-	_, address := s.serviceInfo(ctx, submitter)
 	started := time.Now()
-	err := submitter.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
+	err := s.retrier.Do(ctx, "synccommitteesubscription", 0, name, withSemaphore(sem, func(ctx context.Context) error {
+		return s.classifyError(ctx, submitter, "synccommitteesubscription", submitter.SubmitSyncCommitteeSubscriptions(ctx, subscriptions))
+	}))
 
-	s.clientMonitor.ClientOperation(address, "submit sync committee subscriptions", err == nil, time.Since(started))
+	s.clientMonitor.ClientOperation(address(submitter), "submit sync committee subscriptions", err == nil, time.Since(started))
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to submit sync committee subscriptions")
 		return
 	}
 
-	w.Signal()
+	q.ack(name)
 	log.Trace().Msg("Submitted sync committee subscriptions")
 }
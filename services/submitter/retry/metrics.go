@@ -0,0 +1,73 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var attempts *prometheus.CounterVec
+var outcomes *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if attempts != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	attempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "submitter_retry",
+		Name:      "attempts_total",
+		Help:      "The number of attempts to submit messages to beacon nodes.",
+	}, []string{"message_type"})
+	if err := prometheus.Register(attempts); err != nil {
+		return err
+	}
+
+	outcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "submitter_retry",
+		Name:      "outcomes_total",
+		Help:      "The final outcome of submitting messages to beacon nodes.",
+	}, []string{"message_type", "outcome"})
+	return prometheus.Register(outcomes)
+}
+
+func monitorAttempt(messageType string) {
+	if attempts == nil {
+		return
+	}
+	attempts.WithLabelValues(messageType).Inc()
+}
+
+func monitorOutcome(messageType string, outcome string) {
+	if outcomes == nil {
+		return
+	}
+	outcomes.WithLabelValues(messageType, outcome).Inc()
+}
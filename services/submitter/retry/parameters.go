@@ -0,0 +1,112 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry retries the submission of messages to beacon nodes that
+// fail with transient errors, backing off exponentially between attempts
+// and giving up once the message's deadline within its slot has passed.
+package retry
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel  zerolog.Level
+	monitor   metrics.Service
+	chainTime chaintime.Service
+	policies  map[string]*Policy
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithChainTime sets the chaintime service, used to calculate deadlines.
+func WithChainTime(service chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = service
+	})
+}
+
+// WithPolicies sets the retry policies, keyed by message type.
+func WithPolicies(policies map[string]*Policy) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.policies = policies
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel: zerolog.GlobalLevel(),
+		monitor:  nullmetrics.New(context.Background()),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	for messageType, policy := range parameters.policies {
+		if policy == nil {
+			return nil, errors.Errorf("no policy specified for %s", messageType)
+		}
+		if policy.MaxAttempts < 1 {
+			return nil, errors.Errorf("max attempts for %s must be at least 1", messageType)
+		}
+		if policy.InitialBackoff < 0 {
+			return nil, errors.Errorf("initial backoff for %s cannot be negative", messageType)
+		}
+		if policy.MaxBackoff < policy.InitialBackoff {
+			return nil, errors.Errorf("max backoff for %s cannot be less than initial backoff", messageType)
+		}
+		if policy.Deadline < 0 {
+			return nil, errors.Errorf("deadline for %s cannot be negative", messageType)
+		}
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,148 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Policy defines how the submission of a type of message is retried.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.  The delay doubles for each subsequent retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries.
+	MaxBackoff time.Duration
+	// Deadline is the time from the start of the message's slot after which no retries are made.
+	// If this is 0 retries are bounded only by the context.
+	Deadline time.Duration
+}
+
+// Service retries the submission of messages.
+// A nil service is valid, and makes a single attempt.
+type Service struct {
+	chainTime chaintime.Service
+	policies  map[string]*Policy
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new retry service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "submitter").Str("impl", "retry").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	s := &Service{
+		chainTime: parameters.chainTime,
+		policies:  parameters.policies,
+	}
+
+	return s, nil
+}
+
+// Do submits a message of the given type for the given slot to the named beacon node, retrying
+// according to the policy for the message type if the submission fails with a retryable error.
+// Messages that are not for a specific slot should supply a slot of 0, and have no deadline in their policy.
+func (s *Service) Do(ctx context.Context,
+	messageType string,
+	slot phase0.Slot,
+	name string,
+	submit func(ctx context.Context) error,
+) error {
+	if s == nil {
+		return submit(ctx)
+	}
+
+	policy, exists := s.policies[messageType]
+	if !exists {
+		policy = &Policy{MaxAttempts: 1}
+	}
+	var deadline time.Time
+	if policy.Deadline > 0 {
+		deadline = s.chainTime.StartOfSlot(slot).Add(policy.Deadline)
+	}
+	log := log.With().Str("message_type", messageType).Str("beacon_node_address", name).Uint64("slot", uint64(slot)).Logger()
+
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		monitorAttempt(messageType)
+		err := submit(ctx)
+		if err == nil {
+			if attempt > 1 {
+				log.Debug().Int("attempt", attempt).Msg("Submission succeeded on retry")
+			}
+			monitorOutcome(messageType, "succeeded")
+			return nil
+		}
+
+		if !retryable(ctx, err) {
+			monitorOutcome(messageType, "failed")
+			return err
+		}
+		if attempt >= policy.MaxAttempts {
+			monitorOutcome(messageType, "exhausted")
+			return err
+		}
+		if !deadline.IsZero() && time.Now().Add(backoff).After(deadline) {
+			log.Debug().Int("attempt", attempt).Err(err).Msg("Submission failed; no time to retry before deadline")
+			monitorOutcome(messageType, "deadline")
+			return err
+		}
+
+		log.Debug().Int("attempt", attempt).Dur("backoff", backoff).Err(err).Msg("Submission failed; retrying")
+		select {
+		case <-ctx.Done():
+			monitorOutcome(messageType, "deadline")
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// retryable returns true if the error is considered transient.  Errors that have
+// not been classified by the submitter are classified without reference to the client.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// Context is done, so no point retrying.
+		return false
+	}
+
+	return errorclassifier.ClassOf(err).Retryable()
+}
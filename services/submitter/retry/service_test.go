@@ -0,0 +1,247 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/services/submitter/retry"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []retry.Parameter
+		err    string
+	}{
+		{
+			name: "ChainTimeMissing",
+			params: []retry.Parameter{
+				retry.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no chain time service specified",
+		},
+		{
+			name: "PolicyNil",
+			params: []retry.Parameter{
+				retry.WithLogLevel(zerolog.Disabled),
+				retry.WithChainTime(chainTime),
+				retry.WithPolicies(map[string]*retry.Policy{
+					"attestation": nil,
+				}),
+			},
+			err: "problem with parameters: no policy specified for attestation",
+		},
+		{
+			name: "MaxAttemptsZero",
+			params: []retry.Parameter{
+				retry.WithLogLevel(zerolog.Disabled),
+				retry.WithChainTime(chainTime),
+				retry.WithPolicies(map[string]*retry.Policy{
+					"attestation": {},
+				}),
+			},
+			err: "problem with parameters: max attempts for attestation must be at least 1",
+		},
+		{
+			name: "InitialBackoffNegative",
+			params: []retry.Parameter{
+				retry.WithLogLevel(zerolog.Disabled),
+				retry.WithChainTime(chainTime),
+				retry.WithPolicies(map[string]*retry.Policy{
+					"attestation": {MaxAttempts: 3, InitialBackoff: -1},
+				}),
+			},
+			err: "problem with parameters: initial backoff for attestation cannot be negative",
+		},
+		{
+			name: "MaxBackoffLow",
+			params: []retry.Parameter{
+				retry.WithLogLevel(zerolog.Disabled),
+				retry.WithChainTime(chainTime),
+				retry.WithPolicies(map[string]*retry.Policy{
+					"attestation": {MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Millisecond},
+				}),
+			},
+			err: "problem with parameters: max backoff for attestation cannot be less than initial backoff",
+		},
+		{
+			name: "DeadlineNegative",
+			params: []retry.Parameter{
+				retry.WithLogLevel(zerolog.Disabled),
+				retry.WithChainTime(chainTime),
+				retry.WithPolicies(map[string]*retry.Policy{
+					"attestation": {MaxAttempts: 3, Deadline: -1},
+				}),
+			},
+			err: "problem with parameters: deadline for attestation cannot be negative",
+		},
+		{
+			name: "Good",
+			params: []retry.Parameter{
+				retry.WithLogLevel(zerolog.Disabled),
+				retry.WithChainTime(chainTime),
+				retry.WithPolicies(map[string]*retry.Policy{
+					"attestation": {MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Second, Deadline: 4 * time.Second},
+				}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := retry.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNilService(t *testing.T) {
+	ctx := context.Background()
+
+	var s *retry.Service
+	attempts := 0
+	err := s.Do(ctx, "attestation", 0, "node", func(ctx context.Context) error {
+		attempts++
+		return errors.New("connection reset")
+	})
+	require.EqualError(t, err, "connection reset")
+	require.Equal(t, 1, attempts)
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis is set such that the current slot started 1 second ago.
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now().Add(-time.Second))),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	s, err := retry.New(ctx,
+		retry.WithLogLevel(zerolog.Disabled),
+		retry.WithChainTime(chainTime),
+		retry.WithPolicies(map[string]*retry.Policy{
+			"attestation": {MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond},
+			"beaconblock": {MaxAttempts: 10, InitialBackoff: 200 * time.Millisecond, MaxBackoff: time.Second, Deadline: 1500 * time.Millisecond},
+		}),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		messageType string
+		failures    int
+		failure     error
+		attempts    int
+		err         string
+	}{
+		{
+			name:        "Immediate",
+			messageType: "attestation",
+			attempts:    1,
+		},
+		{
+			name:        "SucceedsOnRetry",
+			messageType: "attestation",
+			failures:    2,
+			failure:     errors.New("POST failed with status 503"),
+			attempts:    3,
+		},
+		{
+			name:        "Exhausted",
+			messageType: "attestation",
+			failures:    5,
+			failure:     errors.New("POST failed with status 503"),
+			attempts:    3,
+			err:         "POST failed with status 503",
+		},
+		{
+			name:        "NotRetryable",
+			messageType: "attestation",
+			failures:    5,
+			failure:     errors.New("POST failed with status 400"),
+			attempts:    1,
+			err:         "POST failed with status 400",
+		},
+		{
+			name:        "Cancelled",
+			messageType: "attestation",
+			failures:    5,
+			failure:     context.Canceled,
+			attempts:    1,
+			err:         "context canceled",
+		},
+		{
+			name:        "UnknownType",
+			messageType: "unknown",
+			failures:    5,
+			failure:     errors.New("connection reset"),
+			attempts:    1,
+			err:         "connection reset",
+		},
+		{
+			name:        "Deadline",
+			messageType: "beaconblock",
+			failures:    10,
+			failure:     errors.New("connection reset"),
+			// Attempts at 0ms and 200ms; the next retry would be at 600ms, past the 500ms left before the deadline.
+			attempts: 2,
+			err:      "connection reset",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			err := s.Do(ctx, test.messageType, chainTime.CurrentSlot(), "node", func(ctx context.Context) error {
+				attempts++
+				if attempts <= test.failures {
+					return test.failure
+				}
+				return nil
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.attempts, attempts)
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 )
@@ -26,6 +28,7 @@ import (
 type aggregateAttestationResponse struct {
 	provider  string
 	aggregate *phase0.Attestation
+	latency   time.Duration
 	score     float64
 }
 
@@ -37,61 +40,93 @@ func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, at
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
-	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
+	// The soft timeout is based on the historical latency of the providers.
+	// If a deadline is set both timeouts are reduced as required to complete by the deadline.
+	timeout, softTimeout, err := s.timeouts(slot)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, softTimeout)
+
+	// Avoid providers that have been failing.
+	providers := s.reliability.Providers(s.nodeHealth.Filter(s.consistency.Filter(s.aggregateAttestationProviderNames)))
+	decision := s.recorder.NewDecision("aggregate attestation", slot, providers)
 
-	respCh := make(chan *aggregateAttestationResponse, len(s.aggregateAttestationProviders))
-	errCh := make(chan error, len(s.aggregateAttestationProviders))
+	respCh := make(chan *aggregateAttestationResponse, len(providers))
+	errCh := make(chan error, len(providers))
 	// Kick off the requests.
-	for name, provider := range s.aggregateAttestationProviders {
-		go s.aggregateAttestation(ctx, started, name, provider, respCh, errCh, slot, attestationDataRoot)
+	for _, name := range providers {
+		provider := s.aggregateAttestationProviders[name]
+		go s.aggregateAttestation(ctx, started, decision, name, provider, respCh, errCh, slot, attestationDataRoot)
 	}
 
 	// Wait for all responses (or context done).
 	responded := 0
 	errored := 0
 	timedOut := 0
-	bestScore := float64(0)
-	var bestAggregateAttestation *phase0.Attestation
-	bestProvider := ""
+	responses := make([]*aggregateAttestationResponse, 0, len(providers))
 
-	for responded+errored+timedOut != len(s.aggregateAttestationProviders) {
+	for responded+errored+timedOut != len(providers) {
 		select {
 		case <-softCtx.Done():
 			// If we have any responses at this point we consider the non-responders timed out.
 			if responded > 0 {
-				timedOut = len(s.aggregateAttestationProviders) - responded - errored
+				timedOut = len(providers) - responded - errored
 				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Msg("Soft timeout reached with responses")
 			} else {
 				log.Debug().Dur("elapsed", time.Since(started)).Int("errored", errored).Msg("Soft timeout reached with no responses")
 			}
 		case <-ctx.Done():
 			// Anyone not responded by now is considered errored.
-			errored = len(s.aggregateAttestationProviders) - responded
+			errored = len(providers) - responded
 			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Hard timeout reached")
 		case err := <-errCh:
 			errored++
 			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
 		case resp := <-respCh:
 			responded++
-			if bestAggregateAttestation == nil || resp.score > bestScore {
-				bestAggregateAttestation = resp.aggregate
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 			log.Trace().Dur("elapsed", time.Since(started)).Msg("Response")
 		}
 	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	// Score the responses together, so that they are all compared using scores on the same scale.
+	candidates := make([]*scorer.Candidate, len(responses))
+	for i, resp := range responses {
+		candidates[i] = &scorer.Candidate{
+			Provider:     resp.provider,
+			Data:         resp.aggregate,
+			BuiltinScore: resp.score,
+		}
+	}
+	scores := s.scorer.Score(ctx, "aggregate attestation", slot, candidates)
 	softCancel()
 	cancel()
-	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	bestScore := float64(0)
+	var bestAggregateAttestation *phase0.Attestation
+	bestProvider := ""
+	for i, resp := range responses {
+		decision.Response(resp.provider, resp.latency, scores[i], resp.aggregate)
+		if bestAggregateAttestation == nil ||
+			scores[i] > bestScore ||
+			(scores[i] == bestScore && s.reliability.Better(resp.provider, bestProvider)) {
+			bestAggregateAttestation = resp.aggregate
+			bestScore = scores[i]
+			bestProvider = resp.provider
+		}
+	}
+
+	s.recorder.Record(decision, bestProvider)
 
 	if bestAggregateAttestation == nil {
 		return nil, errors.New("no aggregate attestations received")
 	}
 	log.Trace().Stringer("aggregate_attestation", bestAggregateAttestation).Float64("score", bestScore).Msg("Selected best aggregate attestation")
 	if bestProvider != "" {
+		s.reliability.Selected(bestProvider)
 		s.clientMonitor.StrategyOperation("best", bestProvider, "aggregate attestation", time.Since(started))
 	}
 
@@ -99,6 +134,7 @@ func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, at
 }
 func (s *Service) aggregateAttestation(ctx context.Context,
 	started time.Time,
+	decision recorder.Decision,
 	name string,
 	provider eth2client.AggregateAttestationProvider,
 	respCh chan *aggregateAttestationResponse,
@@ -109,18 +145,24 @@ func (s *Service) aggregateAttestation(ctx context.Context,
 	aggregate, err := provider.AggregateAttestation(ctx, slot, attestationDataRoot)
 	s.clientMonitor.ClientOperation(name, "aggregate attestation", err == nil, time.Since(started))
 	if err != nil {
+		s.classifier.Classify(ctx, provider, "aggregate attestation", err)
+		decision.Failure(name, time.Since(started), err)
+		s.reliability.Failure(ctx, name, time.Since(started))
 		errCh <- errors.Wrap(err, name)
 		return
 	}
 	log.Trace().Str("provider", name).Dur("elapsed", time.Since(started)).Msg("Obtained aggregate attestation")
 	if aggregate == nil {
+		decision.Failure(name, time.Since(started), errors.New("empty aggregate attestation"))
+		s.reliability.Failure(ctx, name, time.Since(started))
 		return
 	}
+	s.reliability.Success(name, time.Since(started))
 
-	score := s.scoreAggregateAttestation(ctx, name, aggregate)
 	respCh <- &aggregateAttestationResponse{
 		provider:  name,
 		aggregate: aggregate,
-		score:     score,
+		latency:   time.Since(started),
+		score:     s.scoreAggregateAttestation(ctx, name, aggregate),
 	}
 }
@@ -15,10 +15,20 @@ package best
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/errorclassifier"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -26,17 +36,26 @@ import (
 
 // Service is the provider for attestation data.
 type Service struct {
-	clientMonitor                 metrics.ClientMonitor
-	processConcurrency            int64
-	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
-	timeout                       time.Duration
+	clientMonitor                     metrics.ClientMonitor
+	processConcurrency                int64
+	aggregateAttestationProviders     map[string]eth2client.AggregateAttestationProvider
+	aggregateAttestationProviderNames []string
+	reliability                       *reliability.Tracker
+	recorder                          recorder.Service
+	scorer                            scorer.Service
+	classifier                        errorclassifier.Service
+	timeout                           time.Duration
+	nodeHealth                        nodehealth.Service
+	consistency                       consistency.Service
+	deadline                          time.Duration
+	chainTime                         chaintime.Service
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -48,13 +67,46 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	reliabilityTracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(parameters.logLevel),
+		reliability.WithMonitor(parameters.monitor),
+		reliability.WithOperation("aggregate attestation"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reliability tracker")
+	}
+
+	aggregateAttestationProviderNames := make([]string, 0, len(parameters.aggregateAttestationProviders))
+	for name := range parameters.aggregateAttestationProviders {
+		aggregateAttestationProviderNames = append(aggregateAttestationProviderNames, name)
+	}
+	sort.Strings(aggregateAttestationProviderNames)
+
 	s := &Service{
-		timeout:                       parameters.timeout,
-		clientMonitor:                 parameters.clientMonitor,
-		processConcurrency:            parameters.processConcurrency,
-		aggregateAttestationProviders: parameters.aggregateAttestationProviders,
+		timeout:                           parameters.timeout,
+		nodeHealth:                        parameters.nodeHealth,
+		consistency:                       parameters.consistency,
+		deadline:                          parameters.deadline,
+		chainTime:                         parameters.chainTime,
+		clientMonitor:                     parameters.clientMonitor,
+		processConcurrency:                parameters.processConcurrency,
+		aggregateAttestationProviders:     parameters.aggregateAttestationProviders,
+		aggregateAttestationProviderNames: aggregateAttestationProviderNames,
+		reliability:                       reliabilityTracker,
+		recorder:                          parameters.recorder,
+		scorer:                            parameters.scorer,
+		classifier:                        parameters.classifier,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
 	return s, nil
 }
+
+// timeouts returns the hard and soft timeouts for a request for the given slot.
+func (s *Service) timeouts(slot phase0.Slot) (time.Duration, time.Duration, error) {
+	softTimeout := s.reliability.SoftTimeout(s.timeout)
+	if s.deadline == 0 {
+		return s.timeout, softTimeout, nil
+	}
+	return util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, softTimeout)
+}
@@ -62,6 +62,16 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no client monitor specified",
 		},
+		{
+			name: "MonitorMissing",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithMonitor(nil),
+				best.WithAggregateAttestationProviders(aggregateAttestationProviders),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
 		{
 			name: "AggregateAttestationProvidersNil",
 			params: []best.Parameter{
@@ -90,6 +100,26 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no aggregate attestation providers specified",
 		},
+		{
+			name: "DeadlineNegative",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				best.WithDeadline(-time.Second),
+			},
+			err: "problem with parameters: deadline cannot be negative",
+		},
+		{
+			name: "DeadlineWithoutChainTime",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				best.WithDeadline(4 * time.Second),
+			},
+			err: "problem with parameters: no chain time service specified for deadline",
+		},
 		{
 			name: "Good",
 			params: []best.Parameter{
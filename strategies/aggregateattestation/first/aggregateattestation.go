@@ -28,11 +28,22 @@ func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, at
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id")
 
+	if s.hedgeDelay > 0 {
+		return s.hedgedAggregateAttestation(ctx, slot, attestationDataRoot)
+	}
+
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
 	// We create a cancelable context with a timeout.  When a provider responds we cancel the context to cancel the other requests.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 
 	respCh := make(chan *phase0.Attestation, 1)
-	for name, provider := range s.aggregateAttestationProviders {
+	for _, name := range s.nodeHealth.Filter(s.consistency.Filter(s.aggregateAttestationProviderNames)) {
+		provider := s.aggregateAttestationProviders[name]
 		go func(ctx context.Context,
 			name string,
 			provider eth2client.AggregateAttestationProvider,
@@ -65,3 +76,43 @@ func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, at
 		return aggregate, nil
 	}
 }
+
+// hedgedAggregateAttestation obtains the aggregate attestation from providers in order of their historical latency,
+// only requesting from an additional provider if no response has been received within the hedge delay.
+func (s *Service) hedgedAggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root) (*phase0.Attestation, error) {
+	log := util.LogWithID(ctx, log, "strategy_id")
+
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res, err := s.reliability.Hedged(ctx, s.nodeHealth.Filter(s.consistency.Filter(s.aggregateAttestationProviderNames)), s.hedgeDelay, func(ctx context.Context, name string) (interface{}, error) {
+		log := log.With().Str("provider", name).Uint64("slot", uint64(slot)).Logger()
+
+		started := time.Now()
+		aggregate, err := s.aggregateAttestationProviders[name].AggregateAttestation(ctx, slot, attestationDataRoot)
+		s.clientMonitor.ClientOperation(name, "aggregate attestation", err == nil, time.Since(started))
+		if err != nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to obtain aggregate attestation")
+			return nil, err
+		}
+		if aggregate == nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Msg("Returned empty aggregate attestation")
+			return nil, errors.New("empty aggregate attestation")
+		}
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained aggregate attestation")
+
+		return aggregate, nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain aggregate attestation")
+		return nil, errors.Wrap(err, "failed to obtain aggregate attestation")
+	}
+
+	return res.(*phase0.Attestation), nil
+}
@@ -15,10 +15,17 @@ package first
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -26,16 +33,23 @@ import (
 
 // Service is the provider for attestation data.
 type Service struct {
-	clientMonitor                 metrics.ClientMonitor
-	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
-	timeout                       time.Duration
+	clientMonitor                     metrics.ClientMonitor
+	aggregateAttestationProviders     map[string]eth2client.AggregateAttestationProvider
+	timeout                           time.Duration
+	chainTime                         chaintime.Service
+	deadline                          time.Duration
+	nodeHealth                        nodehealth.Service
+	consistency                       consistency.Service
+	hedgeDelay                        time.Duration
+	aggregateAttestationProviderNames []string
+	reliability                       *reliability.Tracker
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -47,11 +61,42 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	reliabilityTracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(parameters.logLevel),
+		reliability.WithMonitor(parameters.monitor),
+		reliability.WithOperation("aggregate attestation"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reliability tracker")
+	}
+
+	aggregateAttestationProviderNames := make([]string, 0, len(parameters.aggregateAttestationProviders))
+	for name := range parameters.aggregateAttestationProviders {
+		aggregateAttestationProviderNames = append(aggregateAttestationProviderNames, name)
+	}
+	sort.Strings(aggregateAttestationProviderNames)
+
 	s := &Service{
-		aggregateAttestationProviders: parameters.aggregateAttestationProviders,
-		timeout:                       parameters.timeout,
-		clientMonitor:                 parameters.clientMonitor,
+		hedgeDelay:                        parameters.hedgeDelay,
+		aggregateAttestationProviderNames: aggregateAttestationProviderNames,
+		reliability:                       reliabilityTracker,
+		aggregateAttestationProviders:     parameters.aggregateAttestationProviders,
+		timeout:                           parameters.timeout,
+		chainTime:                         parameters.chainTime,
+		deadline:                          parameters.deadline,
+		nodeHealth:                        parameters.nodeHealth,
+		consistency:                       parameters.consistency,
+		clientMonitor:                     parameters.clientMonitor,
 	}
 
 	return s, nil
 }
+
+// requestTimeout returns the timeout for a request for the given slot.
+func (s *Service) requestTimeout(slot phase0.Slot) (time.Duration, error) {
+	if s.deadline == 0 {
+		return s.timeout, nil
+	}
+	timeout, _, err := util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, s.timeout)
+	return timeout, err
+}
@@ -62,6 +62,26 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no client monitor specified",
 		},
+		{
+			name: "MonitorMissing",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithMonitor(nil),
+				first.WithAggregateAttestationProviders(aggregateAttestationProviders),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "HedgeDelayNegative",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithHedgeDelay(-1 * time.Second),
+				first.WithAggregateAttestationProviders(aggregateAttestationProviders),
+			},
+			err: "problem with parameters: hedge delay cannot be negative",
+		},
 		{
 			name: "AggregateAttestationProvidersNil",
 			params: []first.Parameter{
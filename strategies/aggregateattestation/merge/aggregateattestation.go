@@ -0,0 +1,151 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+type aggregateAttestationResponse struct {
+	provider  string
+	aggregate *phase0.Attestation
+}
+
+// AggregateAttestation provides the merged aggregate attestation from a number of beacon nodes.
+func (s *Service) AggregateAttestation(ctx context.Context, slot phase0.Slot, attestationDataRoot phase0.Root) (*phase0.Attestation, error) {
+	started := time.Now()
+	log := util.LogWithID(ctx, log, "strategy_id")
+
+	// We have two timeouts: a soft timeout and a hard timeout.
+	// At the soft timeout, we return if we have any responses so far.
+	// At the hard timeout, we return unconditionally.
+	// The soft timeout is half the duration of the hard timeout.
+	// If a deadline is set both timeouts are reduced as required to complete by the deadline.
+	timeout, softTimeout, err := s.timeouts(slot)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, softTimeout)
+
+	names := s.nodeHealth.Filter(s.consistency.Filter(s.aggregateAttestationProviderNames))
+	respCh := make(chan *aggregateAttestationResponse, len(names))
+	errCh := make(chan error, len(names))
+	// Kick off the requests.
+	for _, name := range names {
+		provider := s.aggregateAttestationProviders[name]
+		go s.aggregateAttestation(ctx, started, name, provider, respCh, errCh, slot, attestationDataRoot)
+	}
+
+	// Wait for all responses (or context done).
+	responded := 0
+	errored := 0
+	timedOut := 0
+	responses := make([]*aggregateAttestationResponse, 0, len(names))
+
+	for responded+errored+timedOut != len(names) {
+		select {
+		case <-softCtx.Done():
+			// If we have any responses at this point we consider the non-responders timed out.
+			if responded > 0 {
+				timedOut = len(names) - responded - errored
+				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Msg("Soft timeout reached with responses")
+			} else {
+				log.Debug().Dur("elapsed", time.Since(started)).Int("errored", errored).Msg("Soft timeout reached with no responses")
+			}
+		case <-ctx.Done():
+			// Anyone not responded by now is considered errored.
+			errored = len(names) - responded
+			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Hard timeout reached")
+		case err := <-errCh:
+			errored++
+			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
+		case resp := <-respCh:
+			responded++
+			responses = append(responses, resp)
+			log.Trace().Dur("elapsed", time.Since(started)).Msg("Response")
+		}
+	}
+
+	// Only verify signatures if there is something to merge.  This is carried out
+	// before the context is cancelled so that it is bounded by the hard timeout.
+	var pubKeys []e2types.PublicKey
+	var signingRoot phase0.Root
+	if len(responses) > 1 {
+		pubKeys, signingRoot, err = s.verificationInfo(ctx, responses[0].aggregate.Data)
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to obtain information to verify aggregates; not merging")
+		}
+	}
+	softCancel()
+	cancel()
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	if len(responses) == 0 {
+		return nil, errors.New("no aggregate attestations received")
+	}
+
+	aggregate, providers := mergeAggregates(responses, pubKeys, signingRoot)
+	log.Trace().Stringer("aggregate_attestation", aggregate).Strs("providers", providers).Msg("Merged aggregate attestation")
+	s.clientMonitor.StrategyOperation("merge", providers[0], "aggregate attestation", time.Since(started))
+
+	return aggregate, nil
+}
+
+func (s *Service) aggregateAttestation(ctx context.Context,
+	started time.Time,
+	name string,
+	provider eth2client.AggregateAttestationProvider,
+	respCh chan *aggregateAttestationResponse,
+	errCh chan error,
+	slot phase0.Slot,
+	attestationDataRoot phase0.Root,
+) {
+	aggregate, err := provider.AggregateAttestation(ctx, slot, attestationDataRoot)
+	s.clientMonitor.ClientOperation(name, "aggregate attestation", err == nil, time.Since(started))
+	if err != nil {
+		errCh <- errors.Wrap(err, name)
+		return
+	}
+	log.Trace().Str("provider", name).Dur("elapsed", time.Since(started)).Msg("Obtained aggregate attestation")
+	if aggregate == nil {
+		errCh <- errors.Errorf("%s: aggregate attestation nil", name)
+		return
+	}
+	if aggregate.Data == nil {
+		errCh <- errors.Errorf("%s: aggregate attestation data nil", name)
+		return
+	}
+	dataRoot, err := aggregate.Data.HashTreeRoot()
+	if err != nil {
+		errCh <- errors.Wrapf(err, "%s: failed to obtain root of aggregate attestation data", name)
+		return
+	}
+	if dataRoot != attestationDataRoot {
+		errCh <- errors.Errorf("%s: aggregate attestation data root does not match requested root", name)
+		return
+	}
+
+	respCh <- &aggregateAttestationResponse{
+		provider:  name,
+		aggregate: aggregate,
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/aggregateattestation/merge"
+	"github.com/attestantio/vouch/testing/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateAttestation(t *testing.T) {
+	ctx := context.Background()
+
+	// Obtain the root of the data returned by the mock provider.
+	mockAggregate, err := mock.NewAggregateAttestationProvider().AggregateAttestation(ctx, 12345, phase0.Root{})
+	require.NoError(t, err)
+	attestationDataRoot, err := mockAggregate.Data.HashTreeRoot()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		params              []merge.Parameter
+		slot                phase0.Slot
+		attestationDataRoot phase0.Root
+		err                 string
+		logEntries          []string
+	}{
+		{
+			name: "Good",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good": mock.NewAggregateAttestationProvider(),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+		},
+		{
+			name: "Timeout",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"sleepy": mock.NewSleepyAggregateAttestationProvider(5*time.Second, mock.NewAggregateAttestationProvider()),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			err:                 "no aggregate attestations received",
+		},
+		{
+			name: "NilResponse",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"nil": mock.NewNilAggregateAttestationProvider(),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			err:                 "no aggregate attestations received",
+		},
+		{
+			name: "DataRootMismatch",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good": mock.NewAggregateAttestationProvider(),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot: 12345,
+			attestationDataRoot: phase0.Root{
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+				0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+			},
+			err:        "no aggregate attestations received",
+			logEntries: []string{"Responded with error"},
+		},
+		{
+			name: "MultipleUnverifiable",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good1": mock.NewAggregateAttestationProvider(),
+					"good2": mock.NewAggregateAttestationProvider(),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			logEntries:          []string{"Failed to obtain information to verify aggregates; not merging"},
+		},
+		{
+			name: "GoodMixed",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"error":  mock.NewErroringAggregateAttestationProvider(),
+					"sleepy": mock.NewSleepyAggregateAttestationProvider(time.Second, mock.NewAggregateAttestationProvider()),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+		},
+		{
+			name: "SoftTimeoutWithResponses",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(3 * time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"good":   mock.NewAggregateAttestationProvider(),
+					"sleepy": mock.NewSleepyAggregateAttestationProvider(2*time.Second, mock.NewAggregateAttestationProvider()),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			logEntries:          []string{"Soft timeout reached with responses"},
+		},
+		{
+			name: "SoftTimeoutWithoutResponses",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(3 * time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"sleepy": mock.NewSleepyAggregateAttestationProvider(2*time.Second, mock.NewAggregateAttestationProvider()),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			logEntries:          []string{"Soft timeout reached with no responses"},
+		},
+		{
+			name: "SoftTimeoutWithError",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(3 * time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{
+					"error":  mock.NewErroringAggregateAttestationProvider(),
+					"sleepy": mock.NewSleepyAggregateAttestationProvider(2*time.Second, mock.NewAggregateAttestationProvider()),
+				}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			slot:                12345,
+			attestationDataRoot: attestationDataRoot,
+			logEntries:          []string{"Soft timeout reached with no responses"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			s, err := merge.New(ctx, test.params...)
+			require.NoError(t, err)
+			aggregate, err := s.AggregateAttestation(ctx, test.slot, test.attestationDataRoot)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, aggregate)
+			}
+			for _, entry := range test.logEntries {
+				capture.AssertHasEntry(t, entry)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// mergeAggregates merges the supplied aggregates in to a single aggregate.
+// Each aggregate's signature is verified against the public keys of its
+// participants before it is used, and aggregates that fail verification are
+// ignored.  The verified aggregate with the highest coverage is used as the
+// base, and the remaining verified aggregates are added greedily in order of
+// coverage if their participants do not overlap with those already present.
+// If there is only a single aggregate, the committee public keys are not
+// available, or no aggregate verifies, the aggregate with the highest coverage
+// is returned unaltered.
+// It returns the merged aggregate and the providers of its constituent aggregates.
+func mergeAggregates(responses []*aggregateAttestationResponse,
+	pubKeys []e2types.PublicKey,
+	signingRoot phase0.Root,
+) (
+	*phase0.Attestation,
+	[]string,
+) {
+	// Order by coverage, falling back to provider name for consistency.
+	sort.Slice(responses, func(i, j int) bool {
+		iCount := responses[i].aggregate.AggregationBits.Count()
+		jCount := responses[j].aggregate.AggregationBits.Count()
+		if iCount != jCount {
+			return iCount > jCount
+		}
+		return responses[i].provider < responses[j].provider
+	})
+
+	if len(responses) == 1 || pubKeys == nil {
+		monitorMerge(0, 1)
+		return responses[0].aggregate, []string{responses[0].provider}
+	}
+
+	var base *phase0.Attestation
+	var bits bitfield.Bitlist
+	var sigs []e2types.Signature
+	var providers []string
+	for _, response := range responses {
+		sig, err := verifiedSignature(response.aggregate, pubKeys, signingRoot)
+		if err != nil {
+			log.Debug().Str("provider", response.provider).Err(err).Msg("Aggregate failed verification; ignoring")
+			continue
+		}
+		if base == nil {
+			base = response.aggregate
+			bits = make(bitfield.Bitlist, len(base.AggregationBits))
+			copy(bits, base.AggregationBits)
+			sigs = []e2types.Signature{sig}
+			providers = []string{response.provider}
+			continue
+		}
+		bits, err = addAggregate(bits, response.aggregate)
+		if err != nil {
+			log.Trace().Str("provider", response.provider).Err(err).Msg("Not merging aggregate")
+			continue
+		}
+		sigs = append(sigs, sig)
+		providers = append(providers, response.provider)
+	}
+
+	if base == nil {
+		log.Debug().Msg("No aggregates verified; not merging")
+		monitorMerge(0, 1)
+		return responses[0].aggregate, []string{responses[0].provider}
+	}
+
+	coverageGain := float64(bits.Count()-base.AggregationBits.Count()) / float64(bits.Len())
+	monitorMerge(coverageGain, len(providers))
+	if len(providers) == 1 {
+		return base, providers
+	}
+
+	log.Trace().
+		Uint64("base_participants", base.AggregationBits.Count()).
+		Uint64("merged_participants", bits.Count()).
+		Float64("coverage_gain", coverageGain).
+		Msg("Merged aggregates")
+
+	merged := &phase0.Attestation{
+		AggregationBits: bits,
+		Data:            base.Data,
+	}
+	copy(merged.Signature[:], e2types.AggregateSignatures(sigs).Marshal())
+
+	return merged, providers
+}
+
+// verifiedSignature returns the signature of the aggregate if it is valid for
+// the public keys of its participants over the signing root.
+func verifiedSignature(aggregate *phase0.Attestation,
+	pubKeys []e2types.PublicKey,
+	signingRoot phase0.Root,
+) (
+	e2types.Signature,
+	error,
+) {
+	if aggregate.AggregationBits.Len() != uint64(len(pubKeys)) {
+		return nil, errors.New("aggregation bits length does not match committee size")
+	}
+	participants := make([]e2types.PublicKey, 0, aggregate.AggregationBits.Count())
+	for i := range pubKeys {
+		if aggregate.AggregationBits.BitAt(uint64(i)) {
+			participants = append(participants, pubKeys[i])
+		}
+	}
+	if len(participants) == 0 {
+		return nil, errors.New("no participants")
+	}
+	sig, err := signature(aggregate.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	if !sig.VerifyAggregateCommon(signingRoot[:], participants) {
+		return nil, errors.New("signature does not verify")
+	}
+
+	return sig, nil
+}
+
+// addAggregate adds the participants of an aggregate to the existing bits, if possible.
+func addAggregate(bits bitfield.Bitlist, aggregate *phase0.Attestation) (bitfield.Bitlist, error) {
+	if aggregate.AggregationBits.Len() != bits.Len() {
+		return bits, errors.New("aggregation bits length differs")
+	}
+	overlaps, err := bits.Overlaps(aggregate.AggregationBits)
+	if err != nil {
+		return bits, errors.Wrap(err, "failed to check for overlap")
+	}
+	if overlaps {
+		return bits, errors.New("participants overlap")
+	}
+	merged, err := bits.Or(aggregate.AggregationBits)
+	if err != nil {
+		return bits, errors.Wrap(err, "failed to merge aggregation bits")
+	}
+
+	return merged, nil
+}
+
+// signature decodes a BLS signature.
+func signature(sig phase0.BLSSignature) (e2types.Signature, error) {
+	// Copy the signature to avoid passing memory that contains Go pointers to the BLS library.
+	data := make([]byte, len(sig))
+	copy(data, sig[:])
+	return e2types.BLSSignatureFromBytes(data)
+}
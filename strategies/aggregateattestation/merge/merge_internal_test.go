@@ -0,0 +1,213 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// signedAggregate creates an aggregate attestation signed by the keys at the given indices.
+func signedAggregate(t *testing.T,
+	data *phase0.AttestationData,
+	keys []*e2types.BLSPrivateKey,
+	indices []uint64,
+) *phase0.Attestation {
+	t.Helper()
+
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+
+	bits := bitfield.NewBitlist(uint64(len(keys)))
+	sigs := make([]e2types.Signature, 0, len(indices))
+	for _, index := range indices {
+		bits.SetBitAt(index, true)
+		sigs = append(sigs, keys[index].Sign(root[:]))
+	}
+	aggregate := &phase0.Attestation{
+		AggregationBits: bits,
+		Data:            data,
+	}
+	copy(aggregate.Signature[:], e2types.AggregateSignatures(sigs).Marshal())
+
+	return aggregate
+}
+
+func TestMergeAggregates(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keys := make([]*e2types.BLSPrivateKey, 8)
+	for i := range keys {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+
+	data := &phase0.AttestationData{
+		Slot:   1,
+		Index:  2,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}
+	otherData := &phase0.AttestationData{
+		Slot:   1,
+		Index:  3,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+
+	badSig := signedAggregate(t, data, keys, []uint64{6, 7})
+	badSig.Signature = phase0.BLSSignature{0x01}
+	// Claims participants 2 and 3 but is signed by 4 and 5.
+	wrongSigners := signedAggregate(t, data, keys, []uint64{4, 5})
+	wrongSigners.AggregationBits = bitfield.NewBitlist(uint64(len(keys)))
+	wrongSigners.AggregationBits.SetBitAt(2, true)
+	wrongSigners.AggregationBits.SetBitAt(3, true)
+
+	committee := make([]e2types.PublicKey, len(keys))
+	for i := range keys {
+		committee[i] = keys[i].PublicKey()
+	}
+
+	tests := []struct {
+		name         string
+		responses    []*aggregateAttestationResponse
+		noCommittee  bool
+		participants []uint64
+		unaltered    *phase0.Attestation
+		providers    []string
+	}{
+		{
+			name: "Single",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0, 1})},
+			},
+			participants: []uint64{0, 1},
+			providers:    []string{"a"},
+		},
+		{
+			name: "Disjoint",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0, 1})},
+				{provider: "b", aggregate: signedAggregate(t, data, keys, []uint64{2, 3, 4})},
+			},
+			participants: []uint64{0, 1, 2, 3, 4},
+			providers:    []string{"b", "a"},
+		},
+		{
+			name: "Overlapping",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0, 1, 2})},
+				{provider: "b", aggregate: signedAggregate(t, data, keys, []uint64{2, 3})},
+				{provider: "c", aggregate: signedAggregate(t, data, keys, []uint64{4, 5})},
+			},
+			participants: []uint64{0, 1, 2, 4, 5},
+			providers:    []string{"a", "c"},
+		},
+		{
+			name: "Subset",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0})},
+				{provider: "b", aggregate: signedAggregate(t, data, keys, []uint64{0, 1, 2})},
+			},
+			participants: []uint64{0, 1, 2},
+			providers:    []string{"b"},
+		},
+		{
+			name: "DifferentData",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0, 1})},
+				{provider: "b", aggregate: signedAggregate(t, otherData, keys, []uint64{2})},
+			},
+			participants: []uint64{0, 1},
+			providers:    []string{"a"},
+		},
+		{
+			name: "InvalidSignature",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0, 1, 2})},
+				{provider: "b", aggregate: badSig},
+			},
+			participants: []uint64{0, 1, 2},
+			providers:    []string{"a"},
+		},
+		{
+			name: "InvalidBaseSignature",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0})},
+				{provider: "b", aggregate: badSig},
+			},
+			participants: []uint64{0},
+			providers:    []string{"a"},
+		},
+		{
+			name: "WrongSigners",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0, 1})},
+				{provider: "b", aggregate: wrongSigners},
+			},
+			participants: []uint64{0, 1},
+			providers:    []string{"a"},
+		},
+		{
+			name: "NoneVerified",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: wrongSigners},
+				{provider: "b", aggregate: badSig},
+			},
+			unaltered: wrongSigners,
+			providers: []string{"a"},
+		},
+		{
+			name: "NoCommittee",
+			responses: []*aggregateAttestationResponse{
+				{provider: "a", aggregate: signedAggregate(t, data, keys, []uint64{0})},
+				{provider: "b", aggregate: badSig},
+			},
+			noCommittee: true,
+			unaltered:   badSig,
+			providers:   []string{"b"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pubKeys := committee
+			if test.noCommittee {
+				pubKeys = nil
+			}
+			aggregate, providers := mergeAggregates(test.responses, pubKeys, root)
+			require.Equal(t, test.providers, providers)
+			if test.unaltered != nil {
+				require.Equal(t, test.unaltered, aggregate)
+				return
+			}
+			require.Equal(t, len(test.participants), int(aggregate.AggregationBits.Count()))
+			participants := make([]e2types.PublicKey, 0, len(test.participants))
+			for _, participant := range test.participants {
+				require.True(t, aggregate.AggregationBits.BitAt(participant))
+				participants = append(participants, keys[participant].PublicKey())
+			}
+			sig, err := signature(aggregate.Signature)
+			require.NoError(t, err)
+			require.True(t, sig.(*e2types.BLSSignature).VerifyAggregateCommon(root[:], participants))
+		})
+	}
+}
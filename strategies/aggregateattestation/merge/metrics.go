@@ -0,0 +1,69 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var coverageGain prometheus.Histogram
+var aggregatesMerged prometheus.Histogram
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if coverageGain != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	coverageGain = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_aggregateattestation_merge",
+		Name:      "coverage_gain_ratio",
+		Help:      "The increase in committee coverage of the merged aggregate over the best single aggregate.",
+		Buckets:   []float64{0, 0.01, 0.02, 0.05, 0.1, 0.2, 0.3, 0.4, 0.5, 0.75, 1.0},
+	})
+	if err := prometheus.Register(coverageGain); err != nil {
+		return err
+	}
+
+	aggregatesMerged = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_aggregateattestation_merge",
+		Name:      "aggregates",
+		Help:      "The number of aggregates combined in to the merged aggregate.",
+		Buckets:   []float64{1, 2, 3, 4, 5, 6, 8, 10},
+	})
+	return prometheus.Register(aggregatesMerged)
+}
+
+func monitorMerge(gain float64, aggregates int) {
+	if coverageGain == nil {
+		return
+	}
+	coverageGain.Observe(gain)
+	aggregatesMerged.Observe(float64(aggregates))
+}
@@ -0,0 +1,220 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package merge is a strategy that obtains aggregate attestations from multiple
+// nodes and merges them to provide the most complete aggregate.
+package merge
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	nullconsistency "github.com/attestantio/vouch/services/consistency/null"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodehealth"
+	nullnodehealth "github.com/attestantio/vouch/services/nodehealth/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                      zerolog.Level
+	clientMonitor                 metrics.ClientMonitor
+	monitor                       metrics.Service
+	processConcurrency            int64
+	aggregateAttestationProviders map[string]eth2client.AggregateAttestationProvider
+	timeout                       time.Duration
+	nodeHealth                    nodehealth.Service
+	consistency                   consistency.Service
+	deadline                      time.Duration
+	chainTime                     chaintime.Service
+	specProvider                  eth2client.SpecProvider
+	domainProvider                eth2client.DomainProvider
+	beaconCommitteesProvider      eth2client.BeaconCommitteesProvider
+	validatorsProvider            eth2client.ValidatorsProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithClientMonitor sets the client monitor for the service.
+func WithClientMonitor(monitor metrics.ClientMonitor) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientMonitor = monitor
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithProcessConcurrency sets the concurrency for the service.
+func WithProcessConcurrency(concurrency int64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.processConcurrency = concurrency
+	})
+}
+
+// WithAggregateAttestationProviders sets the aggregate attestation providers.
+func WithAggregateAttestationProviders(providers map[string]eth2client.AggregateAttestationProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.aggregateAttestationProviders = providers
+	})
+}
+
+// WithTimeout sets the timeout for requests.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithDeadline sets the deadline for requests, as an offset from the start of the slot.
+// If set, the timeouts for a request are reduced as required to complete by the deadline.
+func WithDeadline(deadline time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.deadline = deadline
+	})
+}
+
+// WithChainTime sets the chain time service.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
+// WithNodeHealth sets the node health service, used to avoid unhealthy beacon nodes.
+func WithNodeHealth(service nodehealth.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeHealth = service
+	})
+}
+
+// WithConsistency sets the consistency service, used to avoid beacon nodes on the wrong network.
+func WithConsistency(service consistency.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.consistency = service
+	})
+}
+
+// WithSpecProvider sets the spec provider.
+func WithSpecProvider(provider eth2client.SpecProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.specProvider = provider
+	})
+}
+
+// WithDomainProvider sets the domain provider, used to verify aggregate signatures.
+func WithDomainProvider(provider eth2client.DomainProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.domainProvider = provider
+	})
+}
+
+// WithBeaconCommitteesProvider sets the beacon committees provider, used to verify aggregate signatures.
+func WithBeaconCommitteesProvider(provider eth2client.BeaconCommitteesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.beaconCommitteesProvider = provider
+	})
+}
+
+// WithValidatorsProvider sets the validators provider, used to verify aggregate signatures.
+func WithValidatorsProvider(provider eth2client.ValidatorsProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.validatorsProvider = provider
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:           zerolog.GlobalLevel(),
+		clientMonitor:      nullmetrics.New(context.Background()),
+		monitor:            nullmetrics.New(context.Background()),
+		nodeHealth:         nullnodehealth.New(context.Background()),
+		consistency:        nullconsistency.New(context.Background()),
+		processConcurrency: int64(runtime.GOMAXPROCS(-1)),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.clientMonitor == nil {
+		return nil, errors.New("no client monitor specified")
+	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.nodeHealth == nil {
+		return nil, errors.New("no node health service specified")
+	}
+	if parameters.consistency == nil {
+		return nil, errors.New("no consistency service specified")
+	}
+	if parameters.processConcurrency == 0 {
+		return nil, errors.New("no process concurrency specified")
+	}
+	if len(parameters.aggregateAttestationProviders) == 0 {
+		return nil, errors.New("no aggregate attestation providers specified")
+	}
+	if parameters.specProvider == nil {
+		return nil, errors.New("no spec provider specified")
+	}
+	if parameters.domainProvider == nil {
+		return nil, errors.New("no domain provider specified")
+	}
+	if parameters.beaconCommitteesProvider == nil {
+		return nil, errors.New("no beacon committees provider specified")
+	}
+	if parameters.validatorsProvider == nil {
+		return nil, errors.New("no validators provider specified")
+	}
+
+	if parameters.deadline < 0 {
+		return nil, errors.New("deadline cannot be negative")
+	}
+	if parameters.deadline != 0 && parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified for deadline")
+	}
+
+	return &parameters, nil
+}
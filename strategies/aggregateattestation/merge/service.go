@@ -0,0 +1,137 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Service is the provider for aggregate attestations.
+type Service struct {
+	clientMonitor                     metrics.ClientMonitor
+	processConcurrency                int64
+	aggregateAttestationProviders     map[string]eth2client.AggregateAttestationProvider
+	aggregateAttestationProviderNames []string
+	timeout                           time.Duration
+	nodeHealth                        nodehealth.Service
+	consistency                       consistency.Service
+	deadline                          time.Duration
+	chainTime                         chaintime.Service
+	domainProvider                    eth2client.DomainProvider
+	beaconCommitteesProvider          eth2client.BeaconCommitteesProvider
+	validatorsProvider                eth2client.ValidatorsProvider
+	slotsPerEpoch                     uint64
+	beaconAttesterDomainType          phase0.DomainType
+
+	// Committees and public keys used to verify aggregate signatures.
+	committeesMu    sync.Mutex
+	committeesEpoch phase0.Epoch
+	committees      map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+	pubKeys         map[phase0.ValidatorIndex]e2types.PublicKey
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new aggregate attestation strategy.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "aggregateattestation").Str("impl", "merge").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	spec, err := parameters.specProvider.Spec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	tmp, exists := spec["SLOTS_PER_EPOCH"]
+	if !exists {
+		return nil, errors.New("SLOTS_PER_EPOCH not found in spec")
+	}
+	slotsPerEpoch, ok := tmp.(uint64)
+	if !ok {
+		return nil, errors.New("SLOTS_PER_EPOCH of unexpected type")
+	}
+
+	tmp, exists = spec["DOMAIN_BEACON_ATTESTER"]
+	if !exists {
+		return nil, errors.New("DOMAIN_BEACON_ATTESTER not found in spec")
+	}
+	beaconAttesterDomainType, ok := tmp.(phase0.DomainType)
+	if !ok {
+		return nil, errors.New("DOMAIN_BEACON_ATTESTER of unexpected type")
+	}
+
+	aggregateAttestationProviderNames := make([]string, 0, len(parameters.aggregateAttestationProviders))
+	for name := range parameters.aggregateAttestationProviders {
+		aggregateAttestationProviderNames = append(aggregateAttestationProviderNames, name)
+	}
+	sort.Strings(aggregateAttestationProviderNames)
+
+	s := &Service{
+		timeout:                           parameters.timeout,
+		nodeHealth:                        parameters.nodeHealth,
+		consistency:                       parameters.consistency,
+		deadline:                          parameters.deadline,
+		chainTime:                         parameters.chainTime,
+		clientMonitor:                     parameters.clientMonitor,
+		processConcurrency:                parameters.processConcurrency,
+		aggregateAttestationProviders:     parameters.aggregateAttestationProviders,
+		aggregateAttestationProviderNames: aggregateAttestationProviderNames,
+		domainProvider:                    parameters.domainProvider,
+		beaconCommitteesProvider:          parameters.beaconCommitteesProvider,
+		validatorsProvider:                parameters.validatorsProvider,
+		slotsPerEpoch:                     slotsPerEpoch,
+		beaconAttesterDomainType:          beaconAttesterDomainType,
+		pubKeys:                           make(map[phase0.ValidatorIndex]e2types.PublicKey),
+	}
+	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
+
+	return s, nil
+}
+
+// timeouts returns the hard and soft timeouts for a request for the given slot.
+func (s *Service) timeouts(slot phase0.Slot) (time.Duration, time.Duration, error) {
+	softTimeout := s.timeout / 2
+	if s.deadline == 0 {
+		return s.timeout, softTimeout, nil
+	}
+	return util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, softTimeout)
+}
@@ -0,0 +1,262 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/aggregateattestation/merge"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	aggregateAttestationProviders := map[string]eth2client.AggregateAttestationProvider{
+		"localhost:1": mock.NewAggregateAttestationProvider(),
+	}
+
+	tests := []struct {
+		name   string
+		params []merge.Parameter
+		err    string
+	}{
+		{
+			name: "TimeoutMissing",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "TimeoutZero",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(0),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "ClientMonitorMissing",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithClientMonitor(nil),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no client monitor specified",
+		},
+		{
+			name: "MonitorMissing",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithMonitor(nil),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "AggregateAttestationProvidersNil",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(nil),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no aggregate attestation providers specified",
+		},
+		{
+			name: "ProcessConcurrencyZero",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithProcessConcurrency(0),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no process concurrency specified",
+		},
+		{
+			name: "AggregateAttestationProvidersEmpty",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(map[string]eth2client.AggregateAttestationProvider{}),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no aggregate attestation providers specified",
+		},
+		{
+			name: "SpecProviderMissing",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no spec provider specified",
+		},
+		{
+			name: "SpecProviderErrors",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewErroringSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "failed to obtain spec: error",
+		},
+		{
+			name: "DomainProviderMissing",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no domain provider specified",
+		},
+		{
+			name: "BeaconCommitteesProviderMissing",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no beacon committees provider specified",
+		},
+		{
+			name: "ValidatorsProviderMissing",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+			},
+			err: "problem with parameters: no validators provider specified",
+		},
+		{
+			name: "DeadlineNegative",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithDeadline(-time.Second),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: deadline cannot be negative",
+		},
+		{
+			name: "DeadlineWithoutChainTime",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithDeadline(4 * time.Second),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+			err: "problem with parameters: no chain time service specified for deadline",
+		},
+		{
+			name: "Good",
+			params: []merge.Parameter{
+				merge.WithLogLevel(zerolog.TraceLevel),
+				merge.WithTimeout(2 * time.Second),
+				merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+				merge.WithSpecProvider(mock.NewSpecProvider()),
+				merge.WithDomainProvider(mock.NewDomainProvider()),
+				merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+				merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := merge.New(context.Background(), test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInterfaces(t *testing.T) {
+	aggregateAttestationProviders := map[string]eth2client.AggregateAttestationProvider{
+		"localhost:1": mock.NewAggregateAttestationProvider(),
+	}
+
+	s, err := merge.New(context.Background(),
+		merge.WithLogLevel(zerolog.Disabled),
+		merge.WithTimeout(2*time.Second),
+		merge.WithAggregateAttestationProviders(aggregateAttestationProviders),
+		merge.WithSpecProvider(mock.NewSpecProvider()),
+		merge.WithDomainProvider(mock.NewDomainProvider()),
+		merge.WithBeaconCommitteesProvider(mock.NewBeaconCommitteesProvider()),
+		merge.WithValidatorsProvider(mock.NewValidatorsProvider()),
+	)
+	require.NoError(t, err)
+	require.Implements(t, (*eth2client.AggregateAttestationProvider)(nil), s)
+}
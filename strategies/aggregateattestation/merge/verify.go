@@ -0,0 +1,113 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// verificationInfo returns the public keys of the members of the committee that
+// attested to the given data, in committee order, and the root signed by them.
+func (s *Service) verificationInfo(ctx context.Context, data *phase0.AttestationData) ([]e2types.PublicKey, phase0.Root, error) {
+	pubKeys, err := s.committeePubKeys(ctx, data.Slot, data.Index)
+	if err != nil {
+		return nil, phase0.Root{}, err
+	}
+
+	domain, err := s.domainProvider.Domain(ctx, s.beaconAttesterDomainType, data.Target.Epoch)
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to obtain beacon attester domain")
+	}
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to obtain root of attestation data")
+	}
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: dataRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	return pubKeys, signingRoot, nil
+}
+
+// committeePubKeys returns the public keys of the members of the given committee, in committee order.
+// Committees are cached for the most recently requested epoch, and public keys indefinitely.
+func (s *Service) committeePubKeys(ctx context.Context, slot phase0.Slot, index phase0.CommitteeIndex) ([]e2types.PublicKey, error) {
+	s.committeesMu.Lock()
+	defer s.committeesMu.Unlock()
+
+	epoch := phase0.Epoch(uint64(slot) / s.slotsPerEpoch)
+	if s.committees == nil || s.committeesEpoch != epoch {
+		beaconCommittees, err := s.beaconCommitteesProvider.BeaconCommitteesAtEpoch(ctx, "head", epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain beacon committees")
+		}
+		committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		for _, committee := range beaconCommittees {
+			if _, exists := committees[committee.Slot]; !exists {
+				committees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			}
+			committees[committee.Slot][committee.Index] = committee.Validators
+		}
+		s.committees = committees
+		s.committeesEpoch = epoch
+	}
+
+	validatorIndices, exists := s.committees[slot][index]
+	if !exists {
+		return nil, fmt.Errorf("no committee %d at slot %d", index, slot)
+	}
+
+	missing := make([]phase0.ValidatorIndex, 0)
+	for _, validatorIndex := range validatorIndices {
+		if _, exists := s.pubKeys[validatorIndex]; !exists {
+			missing = append(missing, validatorIndex)
+		}
+	}
+	if len(missing) > 0 {
+		validators, err := s.validatorsProvider.Validators(ctx, "head", missing)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators")
+		}
+		for validatorIndex, validator := range validators {
+			if validator.Validator == nil {
+				continue
+			}
+			pubKey, err := e2types.BLSPublicKeyFromBytes(validator.Validator.PublicKey[:])
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid validator public key")
+			}
+			s.pubKeys[validatorIndex] = pubKey
+		}
+	}
+
+	pubKeys := make([]e2types.PublicKey, len(validatorIndices))
+	for i, validatorIndex := range validatorIndices {
+		pubKey, exists := s.pubKeys[validatorIndex]
+		if !exists {
+			return nil, fmt.Errorf("no public key for validator %d", validatorIndex)
+		}
+		pubKeys[i] = pubKey
+	}
+
+	return pubKeys, nil
+}
@@ -19,6 +19,9 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/attestantio/vouch/strategies/attestationdata/scoring"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 )
@@ -26,6 +29,7 @@ import (
 type attestationDataResponse struct {
 	provider        string
 	attestationData *phase0.AttestationData
+	latency         time.Duration
 	score           float64
 }
 
@@ -37,61 +41,93 @@ func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committ
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
-	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
+	// The soft timeout is based on the historical latency of the providers.
+	// If a deadline is set both timeouts are reduced as required to complete by the deadline.
+	timeout, softTimeout, err := s.timeouts(slot)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, softTimeout)
+
+	// Avoid providers that have been failing.
+	providers := s.reliability.Providers(s.nodeHealth.Filter(s.consistency.Filter(s.attestationDataProviderNames)))
+	decision := s.recorder.NewDecision("attestation data", slot, providers)
 
-	respCh := make(chan *attestationDataResponse, len(s.attestationDataProviders))
-	errCh := make(chan error, len(s.attestationDataProviders))
+	respCh := make(chan *attestationDataResponse, len(providers))
+	errCh := make(chan error, len(providers))
 	// Kick off the requests.
-	for name, provider := range s.attestationDataProviders {
-		go s.attestationData(ctx, started, name, provider, respCh, errCh, slot, committeeIndex)
+	for _, name := range providers {
+		provider := s.attestationDataProviders[name]
+		go s.attestationData(ctx, started, decision, name, provider, respCh, errCh, slot, committeeIndex)
 	}
 
 	// Wait for all responses (or context done).
 	responded := 0
 	errored := 0
 	timedOut := 0
-	bestScore := float64(0)
-	var bestAttestationData *phase0.AttestationData
-	bestProvider := ""
+	responses := make([]*attestationDataResponse, 0, len(providers))
 
-	for responded+errored+timedOut != len(s.attestationDataProviders) {
+	for responded+errored+timedOut != len(providers) {
 		select {
 		case <-softCtx.Done():
 			// If we have any responses at this point we consider the non-responders timed out.
 			if responded > 0 {
-				timedOut = len(s.attestationDataProviders) - responded - errored
+				timedOut = len(providers) - responded - errored
 				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Msg("Soft timeout reached with responses")
 			} else {
 				log.Debug().Dur("elapsed", time.Since(started)).Int("errored", errored).Msg("Soft timeout reached with no responses")
 			}
 		case <-ctx.Done():
 			// Anyone not responded by now is considered errored.
-			timedOut = len(s.attestationDataProviders) - responded - errored
+			timedOut = len(providers) - responded - errored
 			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Hard timeout reached")
 		case err := <-errCh:
 			errored++
 			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
 		case resp := <-respCh:
 			responded++
-			if bestAttestationData == nil || resp.score > bestScore {
-				bestAttestationData = resp.attestationData
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 			log.Trace().Dur("elapsed", time.Since(started)).Msg("Response")
 		}
 	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	// Score the responses together, so that they are all compared using scores on the same scale.
+	candidates := make([]*scorer.Candidate, len(responses))
+	for i, resp := range responses {
+		candidates[i] = &scorer.Candidate{
+			Provider:     resp.provider,
+			Data:         resp.attestationData,
+			BuiltinScore: resp.score,
+		}
+	}
+	scores := s.scorer.Score(ctx, "attestation data", slot, candidates)
 	softCancel()
 	cancel()
-	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	bestScore := float64(0)
+	var bestAttestationData *phase0.AttestationData
+	bestProvider := ""
+	for i, resp := range responses {
+		decision.Response(resp.provider, resp.latency, scores[i], resp.attestationData)
+		if bestAttestationData == nil ||
+			scores[i] > bestScore ||
+			(scores[i] == bestScore && s.reliability.Better(resp.provider, bestProvider)) {
+			bestAttestationData = resp.attestationData
+			bestScore = scores[i]
+			bestProvider = resp.provider
+		}
+	}
+
+	s.recorder.Record(decision, bestProvider)
 
 	if bestAttestationData == nil {
 		return nil, errors.New("no attestations received")
 	}
 	log.Trace().Stringer("attestation_data", bestAttestationData).Float64("score", bestScore).Msg("Selected best attestation")
 	if bestProvider != "" {
+		s.reliability.Selected(bestProvider)
 		s.clientMonitor.StrategyOperation("best", bestProvider, "attestation data", time.Since(started))
 	}
 
@@ -100,6 +136,7 @@ func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committ
 
 func (s *Service) attestationData(ctx context.Context,
 	started time.Time,
+	decision recorder.Decision,
 	name string,
 	provider eth2client.AttestationDataProvider,
 	respCh chan *attestationDataResponse,
@@ -110,28 +147,41 @@ func (s *Service) attestationData(ctx context.Context,
 	attestationData, err := provider.AttestationData(ctx, slot, committeeIndex)
 	s.clientMonitor.ClientOperation(name, "attestation data", err == nil, time.Since(started))
 	if err != nil {
+		s.classifier.Classify(ctx, provider, "attestation data", err)
+		decision.Failure(name, time.Since(started), err)
+		s.reliability.Failure(ctx, name, time.Since(started))
 		errCh <- errors.Wrap(err, name)
 		return
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained attestation data")
 
 	if attestationData == nil {
-		errCh <- errors.New("attestation data nil")
+		err = errors.New("attestation data nil")
+		decision.Failure(name, time.Since(started), err)
+		s.reliability.Failure(ctx, name, time.Since(started))
+		errCh <- err
 		return
 	}
 	if attestationData.Target == nil {
-		errCh <- errors.New("attestation data target nil")
+		err = errors.New("attestation data target nil")
+		decision.Failure(name, time.Since(started), err)
+		s.reliability.Failure(ctx, name, time.Since(started))
+		errCh <- err
 		return
 	}
 	if attestationData.Target.Epoch != s.chainTime.SlotToEpoch(slot) {
-		errCh <- errors.New("attestation data slot/target epoch mismatch; abandoning")
+		err = errors.New("attestation data slot/target epoch mismatch; abandoning")
+		decision.Failure(name, time.Since(started), err)
+		s.reliability.Failure(ctx, name, time.Since(started))
+		errCh <- err
 		return
 	}
+	s.reliability.Success(name, time.Since(started))
 
-	score := s.scoreAttestationData(ctx, name, attestationData)
 	respCh <- &attestationDataResponse{
 		provider:        name,
 		attestationData: attestationData,
-		score:           score,
+		latency:         time.Since(started),
+		score:           scoring.ScoreAttestationData(ctx, log, s.blockRootToSlotCache, name, attestationData),
 	}
 }
@@ -15,6 +15,8 @@ package best_test
 
 import (
 	"context"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,6 +26,8 @@ import (
 	"github.com/attestantio/vouch/services/cache"
 	mockcache "github.com/attestantio/vouch/services/cache/mock"
 	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	standardrecorder "github.com/attestantio/vouch/services/recorder/standard"
+	"github.com/attestantio/vouch/services/scorer"
 	"github.com/attestantio/vouch/strategies/attestationdata/best"
 	"github.com/attestantio/vouch/testing/logger"
 	"github.com/rs/zerolog"
@@ -184,3 +188,197 @@ func TestAttestationData(t *testing.T) {
 		})
 	}
 }
+
+func TestAttestationDataSkipsFailingProvider(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	capture := logger.NewLogCapture()
+	s, err := best.New(ctx,
+		best.WithLogLevel(zerolog.TraceLevel),
+		best.WithTimeout(2*time.Second),
+		best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+			"good":  mock.NewAttestationDataProvider(),
+			"error": mock.NewErroringAttestationDataProvider(),
+		}),
+		best.WithChainTime(chainTime),
+		best.WithBlockRootToSlotCache(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)),
+	)
+	require.NoError(t, err)
+
+	// Build up a history of failures for the erroring provider.
+	for i := 0; i < 10; i++ {
+		_, err := s.AttestationData(ctx, 12345, 3)
+		require.NoError(t, err)
+	}
+
+	// The erroring provider should now be skipped.
+	_, err = s.AttestationData(ctx, 12345, 3)
+	require.NoError(t, err)
+	capture.AssertHasEntry(t, "Skipping failing provider")
+}
+
+func TestAttestationDataDeadline(t *testing.T) {
+	ctx := context.Background()
+
+	// Genesis, and so the start of slot 0, is 1.2s in the past.
+	genesisTime := time.Now().Add(-1200 * time.Millisecond)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		deadline time.Duration
+		err      string
+	}{
+		{
+			name:     "Passed",
+			deadline: time.Second,
+			err:      "deadline passed",
+		},
+		{
+			name:     "CutShort",
+			deadline: 1500 * time.Millisecond,
+			err:      "no attestations received",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := best.New(ctx,
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2*time.Second),
+				best.WithDeadline(test.deadline),
+				best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"sleepy": mock.NewSleepyAttestationDataProvider(time.Second, mock.NewAttestationDataProvider()),
+				}),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)),
+			)
+			require.NoError(t, err)
+
+			started := time.Now()
+			_, err = s.AttestationData(ctx, 0, 3)
+			require.ErrorContains(t, err, test.err)
+			// The request should not run past the deadline.
+			require.Less(t, time.Since(started), 500*time.Millisecond)
+		})
+	}
+}
+
+func TestAttestationDataRecorded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "decisions.log")
+	decisionRecorder, err := standardrecorder.New(ctx,
+		standardrecorder.WithLogLevel(zerolog.Disabled),
+		standardrecorder.WithPath(path),
+	)
+	require.NoError(t, err)
+
+	s, err := best.New(ctx,
+		best.WithLogLevel(zerolog.Disabled),
+		best.WithTimeout(2*time.Second),
+		best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+			"good":  mock.NewAttestationDataProvider(),
+			"error": mock.NewErroringAttestationDataProvider(),
+		}),
+		best.WithChainTime(chainTime),
+		best.WithBlockRootToSlotCache(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)),
+		best.WithRecorder(decisionRecorder),
+	)
+	require.NoError(t, err)
+
+	_, err = s.AttestationData(ctx, 12345, 3)
+	require.NoError(t, err)
+
+	var report *standardrecorder.Report
+	require.Eventually(t, func() bool {
+		report, err = standardrecorder.NewReport(path, 0, time.Time{}, time.Time{})
+		return err == nil && len(report.Operations) == 1
+	}, time.Second, 10*time.Millisecond)
+	operation := report.Operations["attestation data"]
+	require.NotNil(t, operation)
+	require.Equal(t, 1, operation.Decisions)
+	require.Equal(t, 1, operation.Providers["good"].Wins)
+	require.Equal(t, 0, operation.Providers["error"].Responses)
+}
+
+// recordingScorer scores the named provider highest, and records the candidates it is sent.
+type recordingScorer struct {
+	mu         sync.Mutex
+	best       string
+	candidates [][]*scorer.Candidate
+}
+
+func (s *recordingScorer) Score(_ context.Context, _ string, _ phase0.Slot, candidates []*scorer.Candidate) []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.candidates = append(s.candidates, candidates)
+
+	scores := make([]float64, len(candidates))
+	for i := range candidates {
+		if candidates[i].Provider == s.best {
+			scores[i] = 1000
+		}
+	}
+	return scores
+}
+
+func TestAttestationDataScorer(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(time.Now())),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+
+	strategyScorer := &recordingScorer{best: "b"}
+	s, err := best.New(ctx,
+		best.WithLogLevel(zerolog.Disabled),
+		best.WithTimeout(2*time.Second),
+		best.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+			"a": mock.NewHeadAttestationDataProvider(phase0.Root{0x01}),
+			"b": mock.NewHeadAttestationDataProvider(phase0.Root{0x02}),
+			"c": mock.NewHeadAttestationDataProvider(phase0.Root{0x03}),
+		}),
+		best.WithChainTime(chainTime),
+		best.WithBlockRootToSlotCache(mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)),
+		best.WithScorer(strategyScorer),
+	)
+	require.NoError(t, err)
+
+	attestationData, err := s.AttestationData(ctx, 12345, 3)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root{0x02}, attestationData.BeaconBlockRoot)
+
+	// All candidates should have been scored together.
+	require.Len(t, strategyScorer.candidates, 1)
+	require.Len(t, strategyScorer.candidates[0], 3)
+}
@@ -15,12 +15,21 @@ package best
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/errorclassifier"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -28,19 +37,27 @@ import (
 
 // Service is the provider for attestation data.
 type Service struct {
-	clientMonitor            metrics.ClientMonitor
-	processConcurrency       int64
-	attestationDataProviders map[string]eth2client.AttestationDataProvider
-	timeout                  time.Duration
-	chainTime                chaintime.Service
-	blockRootToSlotCache     cache.BlockRootToSlotProvider
+	clientMonitor                metrics.ClientMonitor
+	processConcurrency           int64
+	attestationDataProviders     map[string]eth2client.AttestationDataProvider
+	attestationDataProviderNames []string
+	reliability                  *reliability.Tracker
+	recorder                     recorder.Service
+	scorer                       scorer.Service
+	classifier                   errorclassifier.Service
+	timeout                      time.Duration
+	nodeHealth                   nodehealth.Service
+	consistency                  consistency.Service
+	deadline                     time.Duration
+	chainTime                    chaintime.Service
+	blockRootToSlotCache         cache.BlockRootToSlotProvider
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -52,15 +69,47 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	reliabilityTracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(parameters.logLevel),
+		reliability.WithMonitor(parameters.monitor),
+		reliability.WithOperation("attestation data"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reliability tracker")
+	}
+
+	attestationDataProviderNames := make([]string, 0, len(parameters.attestationDataProviders))
+	for name := range parameters.attestationDataProviders {
+		attestationDataProviderNames = append(attestationDataProviderNames, name)
+	}
+	sort.Strings(attestationDataProviderNames)
+
 	s := &Service{
-		timeout:                  parameters.timeout,
-		clientMonitor:            parameters.clientMonitor,
-		processConcurrency:       parameters.processConcurrency,
-		attestationDataProviders: parameters.attestationDataProviders,
-		chainTime:                parameters.chainTime,
-		blockRootToSlotCache:     parameters.blockRootToSlotCache,
+		timeout:                      parameters.timeout,
+		nodeHealth:                   parameters.nodeHealth,
+		consistency:                  parameters.consistency,
+		deadline:                     parameters.deadline,
+		clientMonitor:                parameters.clientMonitor,
+		processConcurrency:           parameters.processConcurrency,
+		attestationDataProviders:     parameters.attestationDataProviders,
+		attestationDataProviderNames: attestationDataProviderNames,
+		reliability:                  reliabilityTracker,
+		recorder:                     parameters.recorder,
+		scorer:                       parameters.scorer,
+		classifier:                   parameters.classifier,
+		chainTime:                    parameters.chainTime,
+		blockRootToSlotCache:         parameters.blockRootToSlotCache,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
 	return s, nil
 }
+
+// timeouts returns the hard and soft timeouts for a request for the given slot.
+func (s *Service) timeouts(slot phase0.Slot) (time.Duration, time.Duration, error) {
+	softTimeout := s.reliability.SoftTimeout(s.timeout)
+	if s.deadline == 0 {
+		return s.timeout, softTimeout, nil
+	}
+	return util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, softTimeout)
+}
@@ -91,6 +91,18 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no client monitor specified",
 		},
+		{
+			name: "MonitorMissing",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithMonitor(nil),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
 		{
 			name: "AttestationDataProvidersNil",
 			params: []best.Parameter{
@@ -123,6 +135,18 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no chain time service specified",
 		},
+		{
+			name: "DeadlineNegative",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.TraceLevel),
+				best.WithTimeout(2 * time.Second),
+				best.WithAttestationDataProviders(attestationDataProviders),
+				best.WithChainTime(chainTime),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithDeadline(-time.Second),
+			},
+			err: "problem with parameters: deadline cannot be negative",
+		},
 		{
 			name: "Good",
 			params: []best.Parameter{
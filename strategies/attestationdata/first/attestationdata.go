@@ -28,11 +28,22 @@ func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committ
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id")
 
+	if s.hedgeDelay > 0 {
+		return s.hedgedAttestationData(ctx, slot, committeeIndex)
+	}
+
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
 	// We create a cancelable context with a timeout.  When a provider responds we cancel the context to cancel the other requests.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 
 	respCh := make(chan *phase0.AttestationData, 1)
-	for name, provider := range s.attestationDataProviders {
+	for _, name := range s.nodeHealth.Filter(s.consistency.Filter(s.attestationDataProviderNames)) {
+		provider := s.attestationDataProviders[name]
 		go func(ctx context.Context, name string, provider eth2client.AttestationDataProvider, ch chan *phase0.AttestationData) {
 			log := log.With().Str("provider", name).Uint64("slot", uint64(slot)).Logger()
 
@@ -62,3 +73,43 @@ func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committ
 		return attestationData, nil
 	}
 }
+
+// hedgedAttestationData obtains the attestation data from providers in order of their historical latency,
+// only requesting from an additional provider if no response has been received within the hedge delay.
+func (s *Service) hedgedAttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	log := util.LogWithID(ctx, log, "strategy_id")
+
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res, err := s.reliability.Hedged(ctx, s.nodeHealth.Filter(s.consistency.Filter(s.attestationDataProviderNames)), s.hedgeDelay, func(ctx context.Context, name string) (interface{}, error) {
+		log := log.With().Str("provider", name).Uint64("slot", uint64(slot)).Logger()
+
+		started := time.Now()
+		attestationData, err := s.attestationDataProviders[name].AttestationData(ctx, slot, committeeIndex)
+		s.clientMonitor.ClientOperation(name, "attestation data", err == nil, time.Since(started))
+		if err != nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to obtain attestation data")
+			return nil, err
+		}
+		if attestationData == nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Msg("Returned empty attestation data")
+			return nil, errors.New("empty attestation data")
+		}
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained attestation data")
+
+		return attestationData, nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain attestation data")
+		return nil, errors.Wrap(err, "failed to obtain attestation data")
+	}
+
+	return res.(*phase0.AttestationData), nil
+}
@@ -21,6 +21,8 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	mockconsistency "github.com/attestantio/vouch/services/consistency/mock"
 	"github.com/attestantio/vouch/strategies/attestationdata/first"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -86,6 +88,79 @@ func TestAttestationData(t *testing.T) {
 			slot:           12345,
 			committeeIndex: 3,
 		},
+		{
+			name: "Inconsistent",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(time.Second),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+				first.WithConsistency(mockconsistency.New("good")),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			// Inconsistent providers are never used, so expect a timeout.
+			err: "failed to obtain attestation data before timeout",
+		},
+		{
+			name: "HedgedGood",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(2 * time.Second),
+				first.WithHedgeDelay(100 * time.Millisecond),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+		},
+		{
+			name: "HedgedMixed",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(2 * time.Second),
+				first.WithHedgeDelay(100 * time.Millisecond),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"error":  mock.NewErroringAttestationDataProvider(),
+					"nil":    mock.NewNilAttestationDataProvider(),
+					"sleepy": mock.NewSleepyAttestationDataProvider(time.Second, mock.NewAttestationDataProvider()),
+				}),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+		},
+		{
+			name: "HedgedTimeout",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(time.Second),
+				first.WithHedgeDelay(100 * time.Millisecond),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"sleepy1": mock.NewSleepyAttestationDataProvider(5*time.Second, mock.NewAttestationDataProvider()),
+					"sleepy2": mock.NewSleepyAttestationDataProvider(5*time.Second, mock.NewAttestationDataProvider()),
+				}),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			err:            "failed to obtain attestation data: no response before timeout",
+		},
+		{
+			name: "HedgedAllFailed",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(time.Second),
+				first.WithHedgeDelay(100 * time.Millisecond),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"error": mock.NewErroringAttestationDataProvider(),
+					"nil":   mock.NewNilAttestationDataProvider(),
+				}),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			err:            "failed to obtain attestation data: no successful responses",
+		},
 	}
 
 	for _, test := range tests {
@@ -103,3 +178,69 @@ func TestAttestationData(t *testing.T) {
 		})
 	}
 }
+
+func TestAttestationDataDeadline(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		deadline   time.Duration
+		hedgeDelay time.Duration
+		err        string
+	}{
+		{
+			name:     "Passed",
+			deadline: time.Second,
+			err:      "deadline passed",
+		},
+		{
+			name:     "CutShort",
+			deadline: 1500 * time.Millisecond,
+			err:      "failed to obtain attestation data before timeout",
+		},
+		{
+			name:       "HedgedPassed",
+			deadline:   time.Second,
+			hedgeDelay: 100 * time.Millisecond,
+			err:        "deadline passed",
+		},
+		{
+			name:       "HedgedCutShort",
+			deadline:   1500 * time.Millisecond,
+			hedgeDelay: 100 * time.Millisecond,
+			err:        "failed to obtain attestation data",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Genesis, and so the start of slot 0, is 1.2s in the past.
+			genesisTime := time.Now().Add(-1200 * time.Millisecond)
+			chainTime, err := standardchaintime.New(ctx,
+				standardchaintime.WithLogLevel(zerolog.Disabled),
+				standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+				standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+				standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+			)
+			require.NoError(t, err)
+
+			s, err := first.New(ctx,
+				first.WithLogLevel(zerolog.Disabled),
+				first.WithTimeout(2*time.Second),
+				first.WithDeadline(test.deadline),
+				first.WithHedgeDelay(test.hedgeDelay),
+				first.WithChainTime(chainTime),
+				first.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"sleepy": mock.NewSleepyAttestationDataProvider(time.Second, mock.NewAttestationDataProvider()),
+				}),
+			)
+			require.NoError(t, err)
+
+			started := time.Now()
+			_, err = s.AttestationData(ctx, 0, 3)
+			require.ErrorContains(t, err, test.err)
+			// The request should not run past the deadline.
+			require.Less(t, time.Since(started), 500*time.Millisecond)
+		})
+	}
+}
@@ -62,6 +62,46 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no client monitor specified",
 		},
+		{
+			name: "MonitorMissing",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithMonitor(nil),
+				first.WithAttestationDataProviders(attestationDataProviders),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "HedgeDelayNegative",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithHedgeDelay(-1 * time.Second),
+				first.WithAttestationDataProviders(attestationDataProviders),
+			},
+			err: "problem with parameters: hedge delay cannot be negative",
+		},
+		{
+			name: "DeadlineNegative",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithAttestationDataProviders(attestationDataProviders),
+				first.WithDeadline(-time.Second),
+			},
+			err: "problem with parameters: deadline cannot be negative",
+		},
+		{
+			name: "DeadlineWithoutChainTime",
+			params: []first.Parameter{
+				first.WithLogLevel(zerolog.TraceLevel),
+				first.WithTimeout(2 * time.Second),
+				first.WithAttestationDataProviders(attestationDataProviders),
+				first.WithDeadline(4 * time.Second),
+			},
+			err: "problem with parameters: no chain time service specified for deadline",
+		},
 		{
 			name: "AttestationDataProvidersNil",
 			params: []first.Parameter{
@@ -0,0 +1,219 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package majority
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/strategies/attestationdata/scoring"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type attestationDataResponse struct {
+	provider        string
+	attestationData *phase0.AttestationData
+	root            phase0.Root
+	score           float64
+}
+
+// AttestationData provides the attestation data agreed upon by a quorum of beacon nodes.
+// If no quorum is reached before the timeout the highest-scoring attestation data is returned.
+func (s *Service) AttestationData(ctx context.Context, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
+	started := time.Now()
+	log := util.LogWithID(ctx, log, "strategy_id").With().Uint64("slot", uint64(slot)).Logger()
+
+	// Unlike other strategies there is no soft timeout, as returning early
+	// would defeat the purpose of waiting for a quorum.
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	providers := s.nodeHealth.Filter(s.consistency.Filter(s.attestationDataProviderNames))
+	respCh := make(chan *attestationDataResponse, len(providers))
+	errCh := make(chan error, len(providers))
+	// Kick off the requests.
+	for _, name := range providers {
+		provider := s.attestationDataProviders[name]
+		go s.attestationData(ctx, started, name, provider, respCh, errCh, slot, committeeIndex)
+	}
+
+	// Wait for a quorum, all responses, or context done.
+	responded := 0
+	errored := 0
+	timedOut := 0
+	responses := make([]*attestationDataResponse, 0, len(providers))
+	votes := make(map[phase0.Root]int)
+	var selected *attestationDataResponse
+
+	for selected == nil && timedOut == 0 && responded+errored != len(providers) {
+		select {
+		case <-ctx.Done():
+			// Anyone not responded by now is considered timed out.
+			timedOut = len(providers) - responded - errored
+			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Timeout reached")
+		case err := <-errCh:
+			errored++
+			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
+		case resp := <-respCh:
+			responded++
+			responses = append(responses, resp)
+			votes[resp.root]++
+			if votes[resp.root] >= s.quorum {
+				selected = resp
+			}
+			log.Trace().Dur("elapsed", time.Since(started)).Str("provider", resp.provider).Int("votes", votes[resp.root]).Msg("Response")
+		}
+	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	if selected != nil {
+		log.Trace().Int("votes", votes[selected.root]).Msg("Quorum reached")
+		monitorOutcome("quorum")
+		outstanding := len(providers) - responded - errored - timedOut
+		if outstanding > 0 {
+			// Continue to gather responses in the background, to report any disagreements.
+			go s.gatherOutstanding(ctx, cancel, selected.root, outstanding, respCh, errCh)
+		} else {
+			cancel()
+		}
+	} else {
+		cancel()
+		selected = s.bestResponse(responses)
+		if selected == nil {
+			monitorOutcome("failed")
+			return nil, errors.New("no attestations received")
+		}
+		log.Debug().Int("votes", votes[selected.root]).Int("quorum", s.quorum).Msg("Quorum not reached; selecting best attestation data")
+		monitorOutcome("fallback")
+	}
+
+	for _, resp := range responses {
+		if resp.root != selected.root {
+			reportDisagreement(log, resp, selected)
+		}
+	}
+
+	log.Trace().Stringer("attestation_data", selected.attestationData).Float64("score", selected.score).Msg("Selected attestation data")
+	s.clientMonitor.StrategyOperation("majority", selected.provider, "attestation data", time.Since(started))
+
+	return selected.attestationData, nil
+}
+
+// bestResponse returns the highest-scoring response.
+func (*Service) bestResponse(responses []*attestationDataResponse) *attestationDataResponse {
+	var best *attestationDataResponse
+	for _, resp := range responses {
+		if best == nil || resp.score > best.score {
+			best = resp
+		}
+	}
+	return best
+}
+
+// gatherOutstanding gathers responses received after the selection, reporting any disagreements.
+func (*Service) gatherOutstanding(ctx context.Context,
+	cancel context.CancelFunc,
+	selectedRoot phase0.Root,
+	outstanding int,
+	respCh chan *attestationDataResponse,
+	errCh chan error,
+) {
+	defer cancel()
+	for outstanding > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-errCh:
+			outstanding--
+		case resp := <-respCh:
+			outstanding--
+			if resp.root != selectedRoot {
+				reportDisagreement(log, resp, nil)
+			}
+		}
+	}
+}
+
+// reportDisagreement reports a response that disagrees with the selected attestation data.
+func reportDisagreement(log zerolog.Logger, resp *attestationDataResponse, selected *attestationDataResponse) {
+	monitorDisagreement(resp.provider)
+	e := log.Debug().
+		Str("provider", resp.provider).
+		Str("beacon_block_root", fmt.Sprintf("%#x", resp.attestationData.BeaconBlockRoot)).
+		Uint64("source_epoch", uint64(resp.attestationData.Source.Epoch)).
+		Uint64("target_epoch", uint64(resp.attestationData.Target.Epoch))
+	if selected != nil {
+		e = e.Str("selected_beacon_block_root", fmt.Sprintf("%#x", selected.attestationData.BeaconBlockRoot)).
+			Uint64("selected_source_epoch", uint64(selected.attestationData.Source.Epoch)).
+			Uint64("selected_target_epoch", uint64(selected.attestationData.Target.Epoch))
+	}
+	e.Msg("Provider disagrees with selected attestation data")
+}
+
+func (s *Service) attestationData(ctx context.Context,
+	started time.Time,
+	name string,
+	provider eth2client.AttestationDataProvider,
+	respCh chan *attestationDataResponse,
+	errCh chan error,
+	slot phase0.Slot,
+	committeeIndex phase0.CommitteeIndex,
+) {
+	attestationData, err := provider.AttestationData(ctx, slot, committeeIndex)
+	s.clientMonitor.ClientOperation(name, "attestation data", err == nil, time.Since(started))
+	if err != nil {
+		errCh <- errors.Wrap(err, name)
+		return
+	}
+	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained attestation data")
+
+	if attestationData == nil {
+		errCh <- errors.New("attestation data nil")
+		return
+	}
+	if attestationData.Source == nil {
+		errCh <- errors.New("attestation data source nil")
+		return
+	}
+	if attestationData.Target == nil {
+		errCh <- errors.New("attestation data target nil")
+		return
+	}
+	if attestationData.Target.Epoch != s.chainTime.SlotToEpoch(slot) {
+		errCh <- errors.New("attestation data slot/target epoch mismatch; abandoning")
+		return
+	}
+	root, err := attestationData.HashTreeRoot()
+	if err != nil {
+		errCh <- errors.Wrap(err, "failed to obtain attestation data root")
+		return
+	}
+
+	score := scoring.ScoreAttestationData(ctx, log, s.blockRootToSlotCache, name, attestationData)
+	respCh <- &attestationDataResponse{
+		provider:        name,
+		attestationData: attestationData,
+		root:            root,
+		score:           score,
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package majority_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	standardchaintime "github.com/attestantio/vouch/services/chaintime/standard"
+	"github.com/attestantio/vouch/strategies/attestationdata/majority"
+	"github.com/attestantio/vouch/testing/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationData(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	slotDuration := 12 * time.Second
+	slotsPerEpoch := uint64(32)
+	genesisTimeProvider := mock.NewGenesisTimeProvider(genesisTime)
+	slotDurationProvider := mock.NewSlotDurationProvider(slotDuration)
+	slotsPerEpochProvider := mock.NewSlotsPerEpochProvider(slotsPerEpoch)
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(genesisTimeProvider),
+		standardchaintime.WithSlotDurationProvider(slotDurationProvider),
+		standardchaintime.WithSlotsPerEpochProvider(slotsPerEpochProvider),
+	)
+	require.NoError(t, err)
+
+	cache := mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)
+
+	goodHead := phase0.Root{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+		0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+	}
+	otherHead := phase0.Root{0x01}
+
+	tests := []struct {
+		name           string
+		params         []majority.Parameter
+		slot           phase0.Slot
+		committeeIndex phase0.CommitteeIndex
+		err            string
+		head           phase0.Root
+		logEntries     []string
+	}{
+		{
+			name: "Good",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(2 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good": mock.NewAttestationDataProvider(),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			head:           goodHead,
+			logEntries:     []string{"Quorum reached"},
+		},
+		{
+			name: "Timeout",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"sleepy": mock.NewSleepyAttestationDataProvider(5*time.Second, mock.NewAttestationDataProvider()),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			err:            "no attestations received",
+		},
+		{
+			name: "NilResponse",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"nil": mock.NewNilAttestationDataProvider(),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			err:            "no attestations received",
+		},
+		{
+			name: "MajorityAgrees",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(2 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good1": mock.NewAttestationDataProvider(),
+					"good2": mock.NewSleepyAttestationDataProvider(100*time.Millisecond, mock.NewAttestationDataProvider()),
+					"other": mock.NewHeadAttestationDataProvider(otherHead),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			head:           goodHead,
+			logEntries:     []string{"Quorum reached", "Provider disagrees with selected attestation data"},
+		},
+		{
+			name: "NoQuorum",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(2 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good":  mock.NewAttestationDataProvider(),
+					"other": mock.NewHeadAttestationDataProvider(otherHead),
+				}),
+				majority.WithQuorum(2),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			logEntries:     []string{"Quorum not reached; selecting best attestation data"},
+		},
+		{
+			name: "QuorumWithError",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(2 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"error": mock.NewErroringAttestationDataProvider(),
+					"good1": mock.NewAttestationDataProvider(),
+					"good2": mock.NewAttestationDataProvider(),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			head:           goodHead,
+			logEntries:     []string{"Quorum reached"},
+		},
+		{
+			name: "QuorumBeforeSleepy",
+			params: []majority.Parameter{
+				majority.WithLogLevel(zerolog.TraceLevel),
+				majority.WithTimeout(3 * time.Second),
+				majority.WithAttestationDataProviders(map[string]eth2client.AttestationDataProvider{
+					"good1":  mock.NewAttestationDataProvider(),
+					"good2":  mock.NewAttestationDataProvider(),
+					"sleepy": mock.NewSleepyAttestationDataProvider(2*time.Second, mock.NewHeadAttestationDataProvider(otherHead)),
+				}),
+				majority.WithChainTime(chainTime),
+				majority.WithBlockRootToSlotCache(cache),
+			},
+			slot:           12345,
+			committeeIndex: 3,
+			head:           goodHead,
+			logEntries:     []string{"Quorum reached"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			capture := logger.NewLogCapture()
+			s, err := majority.New(context.Background(), test.params...)
+			require.NoError(t, err)
+			started := time.Now()
+			attestationData, err := s.AttestationData(context.Background(), test.slot, test.committeeIndex)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, attestationData)
+				if test.head != (phase0.Root{}) {
+					require.Equal(t, test.head, attestationData.BeaconBlockRoot)
+				}
+				require.Less(t, time.Since(started), time.Second)
+			}
+			for _, entry := range test.logEntries {
+				capture.AssertHasEntry(t, entry)
+			}
+		})
+	}
+}
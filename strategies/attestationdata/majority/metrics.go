@@ -0,0 +1,73 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package majority
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var outcomes *prometheus.CounterVec
+var disagreements *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if outcomes != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	outcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_attestationdata_majority",
+		Name:      "outcomes_total",
+		Help:      "The outcome of majority attestation data selection.",
+	}, []string{"result"})
+	if err := prometheus.Register(outcomes); err != nil {
+		return err
+	}
+
+	disagreements = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_attestationdata_majority",
+		Name:      "disagreements_total",
+		Help:      "The number of times a provider disagreed with the selected attestation data.",
+	}, []string{"provider"})
+	return prometheus.Register(disagreements)
+}
+
+func monitorOutcome(result string) {
+	if outcomes == nil {
+		return
+	}
+	outcomes.WithLabelValues(result).Inc()
+}
+
+func monitorDisagreement(provider string) {
+	if disagreements == nil {
+		return
+	}
+	disagreements.WithLabelValues(provider).Inc()
+}
@@ -0,0 +1,209 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package majority is a strategy that obtains attestation data from multiple
+// nodes and selects the data agreed upon by a quorum of them.
+package majority
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/cache"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	nullconsistency "github.com/attestantio/vouch/services/consistency/null"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodehealth"
+	nullnodehealth "github.com/attestantio/vouch/services/nodehealth/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                 zerolog.Level
+	monitor                  metrics.Service
+	clientMonitor            metrics.ClientMonitor
+	processConcurrency       int64
+	attestationDataProviders map[string]eth2client.AttestationDataProvider
+	timeout                  time.Duration
+	nodeHealth               nodehealth.Service
+	consistency              consistency.Service
+	deadline                 time.Duration
+	quorum                   int
+	chainTime                chaintime.Service
+	blockRootToSlotCache     cache.BlockRootToSlotProvider
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithClientMonitor sets the client monitor for the service.
+func WithClientMonitor(monitor metrics.ClientMonitor) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientMonitor = monitor
+	})
+}
+
+// WithProcessConcurrency sets the concurrency for the service.
+func WithProcessConcurrency(concurrency int64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.processConcurrency = concurrency
+	})
+}
+
+// WithAttestationDataProviders sets the attestation data providers.
+func WithAttestationDataProviders(providers map[string]eth2client.AttestationDataProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attestationDataProviders = providers
+	})
+}
+
+// WithTimeout sets the timeout for requests.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithQuorum sets the number of providers that must agree on attestation data.
+// If not supplied, a simple majority of the providers is required.
+func WithQuorum(quorum int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.quorum = quorum
+	})
+}
+
+// WithChainTime sets the chain time provider for this service.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
+// WithBlockRootToSlotCache sets the block root to slot cache.
+func WithBlockRootToSlotCache(cache cache.BlockRootToSlotProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.blockRootToSlotCache = cache
+	})
+}
+
+// WithDeadline sets the deadline for requests, as an offset from the start of the slot.
+// If set, the timeouts for a request are reduced as required to complete by the deadline.
+func WithDeadline(deadline time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.deadline = deadline
+	})
+}
+
+// WithNodeHealth sets the node health service, used to avoid unhealthy beacon nodes.
+func WithNodeHealth(service nodehealth.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeHealth = service
+	})
+}
+
+// WithConsistency sets the consistency service, used to avoid beacon nodes on the wrong network.
+func WithConsistency(service consistency.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.consistency = service
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:           zerolog.GlobalLevel(),
+		monitor:            nullmetrics.New(context.Background()),
+		nodeHealth:         nullnodehealth.New(context.Background()),
+		consistency:        nullconsistency.New(context.Background()),
+		clientMonitor:      nullmetrics.New(context.Background()),
+		processConcurrency: int64(runtime.GOMAXPROCS(-1)),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.nodeHealth == nil {
+		return nil, errors.New("no node health service specified")
+	}
+	if parameters.consistency == nil {
+		return nil, errors.New("no consistency service specified")
+	}
+	if parameters.clientMonitor == nil {
+		return nil, errors.New("no client monitor specified")
+	}
+	if parameters.processConcurrency == 0 {
+		return nil, errors.New("no process concurrency specified")
+	}
+	if len(parameters.attestationDataProviders) == 0 {
+		return nil, errors.New("no attestation data providers specified")
+	}
+	if parameters.quorum == 0 {
+		parameters.quorum = len(parameters.attestationDataProviders)/2 + 1
+	}
+	if parameters.quorum < 0 {
+		return nil, errors.New("quorum cannot be negative")
+	}
+	if parameters.quorum > len(parameters.attestationDataProviders) {
+		return nil, errors.New("quorum larger than number of attestation data providers")
+	}
+	if parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified")
+	}
+	if parameters.blockRootToSlotCache == nil {
+		return nil, errors.New("no block root to slot cache specified")
+	}
+
+	if parameters.deadline < 0 {
+		return nil, errors.New("deadline cannot be negative")
+	}
+	if parameters.deadline != 0 && parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified for deadline")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package majority
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/cache"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is the provider for attestation data.
+type Service struct {
+	clientMonitor                metrics.ClientMonitor
+	processConcurrency           int64
+	attestationDataProviders     map[string]eth2client.AttestationDataProvider
+	attestationDataProviderNames []string
+	timeout                      time.Duration
+	nodeHealth                   nodehealth.Service
+	consistency                  consistency.Service
+	deadline                     time.Duration
+	quorum                       int
+	chainTime                    chaintime.Service
+	blockRootToSlotCache         cache.BlockRootToSlotProvider
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new attestation data strategy.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "attestationdata").Str("impl", "majority").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	attestationDataProviderNames := make([]string, 0, len(parameters.attestationDataProviders))
+	for name := range parameters.attestationDataProviders {
+		attestationDataProviderNames = append(attestationDataProviderNames, name)
+	}
+	sort.Strings(attestationDataProviderNames)
+
+	s := &Service{
+		timeout:                      parameters.timeout,
+		nodeHealth:                   parameters.nodeHealth,
+		consistency:                  parameters.consistency,
+		deadline:                     parameters.deadline,
+		clientMonitor:                parameters.clientMonitor,
+		processConcurrency:           parameters.processConcurrency,
+		attestationDataProviders:     parameters.attestationDataProviders,
+		attestationDataProviderNames: attestationDataProviderNames,
+		quorum:                       parameters.quorum,
+		chainTime:                    parameters.chainTime,
+		blockRootToSlotCache:         parameters.blockRootToSlotCache,
+	}
+	log.Trace().Int64("process_concurrency", s.processConcurrency).Int("quorum", s.quorum).Msg("Set parameters")
+
+	return s, nil
+}
+
+// requestTimeout returns the timeout for a request for the given slot.
+func (s *Service) requestTimeout(slot phase0.Slot) (time.Duration, error) {
+	if s.deadline == 0 {
+		return s.timeout, nil
+	}
+	timeout, _, err := util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, s.timeout)
+	return timeout, err
+}
@@ -11,18 +11,24 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package best
+// Package scoring scores attestation data, for use by the strategies that
+// select between attestation data from multiple beacon nodes.
+package scoring
 
 import (
 	"context"
 	"fmt"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/cache"
+	"github.com/rs/zerolog"
 )
 
-// scoreAttestationData generates a score for attestation data.
+// ScoreAttestationData generates a score for attestation data.
 // The score is relative to the reward expected from the contents of the attestation.
-func (s *Service) scoreAttestationData(ctx context.Context,
+func ScoreAttestationData(ctx context.Context,
+	log zerolog.Logger,
+	blockRootToSlotCache cache.BlockRootToSlotProvider,
 	name string,
 	attestationData *phase0.AttestationData,
 ) float64 {
@@ -34,7 +40,7 @@ func (s *Service) scoreAttestationData(ctx context.Context,
 	score := float64(attestationData.Source.Epoch + attestationData.Target.Epoch)
 
 	// Increase score based on the nearness of the head slot.
-	slot, err := s.blockRootToSlotCache.BlockRootToSlot(ctx, attestationData.BeaconBlockRoot)
+	slot, err := blockRootToSlotCache.BlockRootToSlot(ctx, attestationData.BeaconBlockRoot)
 	if err != nil {
 		log.Warn().Str("root", fmt.Sprintf("%#x", attestationData.BeaconBlockRoot)).Err(err).Msg("Failed to obtain slot for block root")
 		slot = 0
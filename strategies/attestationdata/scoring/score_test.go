@@ -0,0 +1,82 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/cache"
+	mockcache "github.com/attestantio/vouch/services/cache/mock"
+	"github.com/attestantio/vouch/strategies/attestationdata/scoring"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreAttestationData(t *testing.T) {
+	ctx := context.Background()
+
+	blockRootToSlotCache := mockcache.New(map[phase0.Root]phase0.Slot{
+		{0x01}: 10,
+	}).(cache.BlockRootToSlotProvider)
+
+	tests := []struct {
+		name            string
+		attestationData *phase0.AttestationData
+		score           float64
+	}{
+		{
+			name:  "Nil",
+			score: 0,
+		},
+		{
+			name: "HeadAtSlot",
+			attestationData: &phase0.AttestationData{
+				Slot:            10,
+				BeaconBlockRoot: phase0.Root{0x01},
+				Source:          &phase0.Checkpoint{Epoch: 1},
+				Target:          &phase0.Checkpoint{Epoch: 2},
+			},
+			score: 4,
+		},
+		{
+			name: "HeadBehind",
+			attestationData: &phase0.AttestationData{
+				Slot:            11,
+				BeaconBlockRoot: phase0.Root{0x01},
+				Source:          &phase0.Checkpoint{Epoch: 1},
+				Target:          &phase0.Checkpoint{Epoch: 2},
+			},
+			score: 3.5,
+		},
+		{
+			name: "HeadUnknown",
+			attestationData: &phase0.AttestationData{
+				Slot:            10,
+				BeaconBlockRoot: phase0.Root{0x02},
+				Source:          &phase0.Checkpoint{Epoch: 1},
+				Target:          &phase0.Checkpoint{Epoch: 2},
+			},
+			score: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score := scoring.ScoreAttestationData(ctx, zerolog.Nop(), blockRootToSlotCache, "test", test.attestationData)
+			require.Equal(t, test.score, score)
+		})
+	}
+}
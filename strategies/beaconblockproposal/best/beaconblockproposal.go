@@ -16,11 +16,14 @@ package best
 import (
 	"bytes"
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 )
@@ -28,25 +31,60 @@ import (
 type beaconBlockResponse struct {
 	provider string
 	proposal *spec.VersionedBeaconBlock
+	latency  time.Duration
 	score    float64
 }
 
 // BeaconBlockProposal provides the best beacon block proposal from a number of beacon nodes.
 func (s *Service) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) (*spec.VersionedBeaconBlock, error) {
+	responses, err := s.rankedProposals(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses[0].proposal, nil
+}
+
+// RankedBeaconBlockProposals provides the beacon block proposals from a number of beacon nodes, ordered from best to worst.
+func (s *Service) RankedBeaconBlockProposals(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) ([]*spec.VersionedBeaconBlock, error) {
+	responses, err := s.rankedProposals(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]*spec.VersionedBeaconBlock, len(responses))
+	for i := range responses {
+		proposals[i] = responses[i].proposal
+	}
+	return proposals, nil
+}
+
+// rankedProposals obtains proposals from all beacon nodes, ordered from best to worst.
+func (s *Service) rankedProposals(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) ([]*beaconBlockResponse, error) {
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id").With().Uint64("slot", uint64(slot)).Logger()
 
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
-	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
+	// The soft timeout is based on the historical latency of the providers.
+	// If a deadline is set both timeouts are reduced as required to complete by the deadline.
+	timeout, softTimeout, err := s.timeouts(slot)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, softTimeout)
+
+	// Avoid providers that have been failing.
+	providers := s.reliability.Providers(s.nodeHealth.Filter(s.consistency.Filter(s.beaconBlockProposalProviderNames)))
+	decision := s.recorder.NewDecision("beacon block proposal", slot, providers)
 
-	respCh := make(chan *beaconBlockResponse, len(s.beaconBlockProposalProviders))
-	errCh := make(chan error, len(s.beaconBlockProposalProviders))
+	respCh := make(chan *beaconBlockResponse, len(providers))
+	errCh := make(chan error, len(providers))
 	// Kick off the requests.
-	for name, provider := range s.beaconBlockProposalProviders {
+	for _, name := range providers {
+		provider := s.beaconBlockProposalProviders[name]
 		providerGraffiti := graffiti
 		if bytes.Contains(providerGraffiti, []byte("{{CLIENT}}")) {
 			if nodeClientProvider, isProvider := provider.(eth2client.NodeClientProvider); isProvider {
@@ -61,60 +99,78 @@ func (s *Service) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, ran
 		if len(providerGraffiti) > 32 {
 			providerGraffiti = providerGraffiti[0:32]
 		}
-		go s.beaconBlockProposal(ctx, started, name, provider, respCh, errCh, slot, randaoReveal, providerGraffiti)
+		go s.beaconBlockProposal(ctx, started, decision, name, provider, respCh, errCh, slot, randaoReveal, providerGraffiti)
 	}
 
 	// Wait for all responses (or context done).
 	responded := 0
 	errored := 0
 	timedOut := 0
-	bestScore := float64(0)
-	var bestProposal *spec.VersionedBeaconBlock
-	bestProvider := ""
+	responses := make([]*beaconBlockResponse, 0, len(providers))
 
-	for responded+errored+timedOut != len(s.beaconBlockProposalProviders) {
+	for responded+errored+timedOut != len(providers) {
 		select {
 		case <-softCtx.Done():
 			// If we have any responses at this point we consider the non-responders timed out.
 			if responded > 0 {
-				timedOut = len(s.beaconBlockProposalProviders) - responded - errored
+				timedOut = len(providers) - responded - errored
 				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Msg("Soft timeout reached with responses")
 			} else {
 				log.Debug().Dur("elapsed", time.Since(started)).Int("errored", errored).Msg("Soft timeout reached with no responses")
 			}
 		case <-ctx.Done():
 			// Anyone not responded by now is considered errored.
-			timedOut = len(s.beaconBlockProposalProviders) - responded - errored
+			timedOut = len(providers) - responded - errored
 			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Hard timeout reached")
 		case err := <-errCh:
 			errored++
 			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
 		case resp := <-respCh:
 			responded++
-			if bestProposal == nil || resp.score > bestScore {
-				bestProposal = resp.proposal
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 		}
 	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	// Score the responses together, so that they are all compared using scores on the same scale.
+	candidates := make([]*scorer.Candidate, len(responses))
+	for i, resp := range responses {
+		candidates[i] = &scorer.Candidate{
+			Provider:     resp.provider,
+			Data:         resp.proposal,
+			BuiltinScore: resp.score,
+		}
+	}
+	scores := s.scorer.Score(ctx, "beacon block proposal", slot, candidates)
 	softCancel()
 	cancel()
-	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+	for i, resp := range responses {
+		resp.score = scores[i]
+		decision.Response(resp.provider, resp.latency, resp.score, resp.proposal)
+	}
 
-	if bestProposal == nil {
+	if len(responses) == 0 {
+		s.recorder.Record(decision, "")
 		return nil, errors.New("no proposals received")
 	}
-	log.Trace().Stringer("proposal", bestProposal).Float64("score", bestScore).Msg("Selected best proposal")
-	if bestProvider != "" {
-		s.clientMonitor.StrategyOperation("best", bestProvider, "beacon block proposal", time.Since(started))
-	}
+	// Stable sort so that equal scores from equally reliable providers retain the order in which they were received.
+	sort.SliceStable(responses, func(i, j int) bool {
+		if responses[i].score == responses[j].score {
+			return s.reliability.Better(responses[i].provider, responses[j].provider)
+		}
+		return responses[i].score > responses[j].score
+	})
+	log.Trace().Stringer("proposal", responses[0].proposal).Float64("score", responses[0].score).Msg("Selected best proposal")
+	s.recorder.Record(decision, responses[0].provider)
+	s.reliability.Selected(responses[0].provider)
+	s.clientMonitor.StrategyOperation("best", responses[0].provider, "beacon block proposal", time.Since(started))
 
-	return bestProposal, nil
+	return responses, nil
 }
 
 func (s *Service) beaconBlockProposal(ctx context.Context,
 	started time.Time,
+	decision recorder.Decision,
 	name string,
 	provider eth2client.BeaconBlockProposalProvider,
 	respCh chan *beaconBlockResponse,
@@ -126,18 +182,24 @@ func (s *Service) beaconBlockProposal(ctx context.Context,
 	proposal, err := provider.BeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
 	s.clientMonitor.ClientOperation(name, "beacon block proposal", err == nil, time.Since(started))
 	if err != nil {
+		s.classifier.Classify(ctx, provider, "beacon block proposal", err)
+		decision.Failure(name, time.Since(started), err)
+		s.reliability.Failure(ctx, name, time.Since(started))
 		errCh <- errors.Wrap(err, name)
 		return
 	}
 	log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained attestation data")
 	if proposal == nil {
+		decision.Failure(name, time.Since(started), errors.New("empty beacon block proposal"))
+		s.reliability.Failure(ctx, name, time.Since(started))
 		return
 	}
+	s.reliability.Success(name, time.Since(started))
 
-	score := s.scoreBeaconBlockProposal(ctx, name, proposal)
 	respCh <- &beaconBlockResponse{
 		provider: name,
 		proposal: proposal,
-		score:    score,
+		latency:  time.Since(started),
+		score:    s.scoreBeaconBlockProposal(ctx, name, proposal),
 	}
 }
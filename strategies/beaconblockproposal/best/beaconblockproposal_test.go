@@ -223,3 +223,38 @@ func TestBeaconBlockProposal(t *testing.T) {
 		})
 	}
 }
+
+func TestRankedBeaconBlockProposals(t *testing.T) {
+	ctx := context.Background()
+
+	genesisTime := time.Now()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisTimeProvider(mock.NewGenesisTimeProvider(genesisTime)),
+		standardchaintime.WithSlotDurationProvider(mock.NewSlotDurationProvider(12*time.Second)),
+		standardchaintime.WithSlotsPerEpochProvider(mock.NewSlotsPerEpochProvider(32)),
+	)
+	require.NoError(t, err)
+	cache := mockcache.New(map[phase0.Root]phase0.Slot{}).(cache.BlockRootToSlotProvider)
+
+	s, err := best.New(ctx,
+		best.WithLogLevel(zerolog.Disabled),
+		best.WithTimeout(2*time.Second),
+		best.WithEventsProvider(mock.NewEventsProvider()),
+		best.WithChainTimeService(chainTime),
+		best.WithSpecProvider(mock.NewSpecProvider()),
+		best.WithProcessConcurrency(2),
+		best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+		best.WithBeaconBlockProposalProviders(map[string]eth2client.BeaconBlockProposalProvider{
+			"good1": mock.NewBeaconBlockProposalProvider(),
+			"good2": mock.NewBeaconBlockProposalProvider(),
+			"error": mock.NewErroringBeaconBlockProposalProvider(),
+		}),
+		best.WithBlockRootToSlotCache(cache),
+	)
+	require.NoError(t, err)
+
+	proposals, err := s.RankedBeaconBlockProposals(ctx, 12345, phase0.BLSSignature{}, nil)
+	require.NoError(t, err)
+	require.Len(t, proposals, 2)
+}
@@ -0,0 +1,209 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+)
+
+// executionPayloadValue estimates the value of an execution payload to its
+// fee recipient, in Gwei.
+//
+// The payload does not contain per-transaction gas used, so the gas used by
+// the payload as a whole is apportioned across its transactions in proportion
+// to their gas limits.  Each transaction contributes its priority fee per gas
+// multiplied by its share of the gas used; the base fee is burnt so does not
+// count towards the value.  Transactions that cannot be decoded are skipped,
+// so that a single unusual transaction does not remove the value of the rest.
+func executionPayloadValue(payload *bellatrix.ExecutionPayload) (uint64, error) {
+	if payload == nil || len(payload.Transactions) == 0 || payload.GasUsed == 0 {
+		return 0, nil
+	}
+
+	// Base fee per gas is little-endian.
+	baseFeeBytes := make([]byte, len(payload.BaseFeePerGas))
+	for i := range payload.BaseFeePerGas {
+		baseFeeBytes[len(payload.BaseFeePerGas)-1-i] = payload.BaseFeePerGas[i]
+	}
+	baseFee := new(big.Int).SetBytes(baseFeeBytes)
+
+	tips := make([]*big.Int, 0, len(payload.Transactions))
+	gasLimits := make([]*big.Int, 0, len(payload.Transactions))
+	totalGasLimit := new(big.Int)
+	for i, tx := range payload.Transactions {
+		tip, gasLimit, err := transactionFee(tx, baseFee)
+		if err != nil {
+			log.Trace().Int("transaction", i).Err(err).Msg("Failed to decode transaction; skipping")
+			continue
+		}
+		tips = append(tips, tip)
+		gasLimits = append(gasLimits, gasLimit)
+		totalGasLimit.Add(totalGasLimit, gasLimit)
+	}
+	if totalGasLimit.Sign() == 0 {
+		return 0, nil
+	}
+
+	gasUsed := new(big.Int).SetUint64(payload.GasUsed)
+	value := new(big.Int)
+	for i := range tips {
+		// value += tip * gasUsed * gasLimit / totalGasLimit
+		txValue := new(big.Int).Mul(tips[i], gasUsed)
+		txValue.Mul(txValue, gasLimits[i])
+		txValue.Quo(txValue, totalGasLimit)
+		value.Add(value, txValue)
+	}
+
+	// Convert from Wei to Gwei.
+	value.Quo(value, big.NewInt(1e9))
+	if !value.IsUint64() {
+		return 0, errors.New("execution payload value overflow")
+	}
+
+	return value.Uint64(), nil
+}
+
+// transactionFee returns the priority fee per gas paid to the fee recipient,
+// and the gas limit, of an encoded transaction.
+func transactionFee(tx bellatrix.Transaction, baseFee *big.Int) (*big.Int, *big.Int, error) {
+	if len(tx) == 0 {
+		return nil, nil, errors.New("empty transaction")
+	}
+
+	var fields [][]byte
+	var err error
+	switch {
+	case tx[0] >= 0xc0:
+		// Legacy transaction: [nonce, gasPrice, gasLimit, ...].
+		fields, err = rlpListPrefix(tx, 3)
+		if err != nil {
+			return nil, nil, err
+		}
+		return legacyTip(new(big.Int).SetBytes(fields[1]), baseFee), new(big.Int).SetBytes(fields[2]), nil
+	case tx[0] == 0x01:
+		// Access list transaction: 0x01 || [chainId, nonce, gasPrice, gasLimit, ...].
+		fields, err = rlpListPrefix(tx[1:], 4)
+		if err != nil {
+			return nil, nil, err
+		}
+		return legacyTip(new(big.Int).SetBytes(fields[2]), baseFee), new(big.Int).SetBytes(fields[3]), nil
+	case tx[0] == 0x02:
+		// Dynamic fee transaction: 0x02 || [chainId, nonce, maxPriorityFeePerGas, maxFeePerGas, gasLimit, ...].
+		fields, err = rlpListPrefix(tx[1:], 5)
+		if err != nil {
+			return nil, nil, err
+		}
+		maxPriorityFee := new(big.Int).SetBytes(fields[2])
+		tip := legacyTip(new(big.Int).SetBytes(fields[3]), baseFee)
+		if maxPriorityFee.Cmp(tip) < 0 {
+			tip = maxPriorityFee
+		}
+		return tip, new(big.Int).SetBytes(fields[4]), nil
+	default:
+		return nil, nil, errors.Errorf("unhandled transaction type %#x", tx[0])
+	}
+}
+
+// legacyTip returns the portion of the gas price above the base fee.
+func legacyTip(gasPrice *big.Int, baseFee *big.Int) *big.Int {
+	tip := new(big.Int).Sub(gasPrice, baseFee)
+	if tip.Sign() < 0 {
+		return new(big.Int)
+	}
+	return tip
+}
+
+// rlpListPrefix decodes the first count items of an RLP-encoded list of strings.
+func rlpListPrefix(data []byte, count int) ([][]byte, error) {
+	payload, isList, _, err := rlpItem(data)
+	if err != nil {
+		return nil, err
+	}
+	if !isList {
+		return nil, errors.New("transaction is not an RLP list")
+	}
+
+	items := make([][]byte, 0, count)
+	for len(items) < count {
+		item, itemIsList, rest, err := rlpItem(payload)
+		if err != nil {
+			return nil, err
+		}
+		if itemIsList {
+			return nil, errors.New("unexpected RLP list in transaction")
+		}
+		items = append(items, item)
+		payload = rest
+	}
+
+	return items, nil
+}
+
+// rlpItem decodes the RLP item at the start of data, returning its contents,
+// whether it is a list, and the remaining data.
+func rlpItem(data []byte) ([]byte, bool, []byte, error) {
+	if len(data) == 0 {
+		return nil, false, nil, errors.New("RLP data too short")
+	}
+
+	prefix := data[0]
+	var offset, length uint64
+	isList := false
+	switch {
+	case prefix < 0x80:
+		return data[:1], false, data[1:], nil
+	case prefix < 0xb8:
+		offset, length = 1, uint64(prefix-0x80)
+	case prefix < 0xc0:
+		lengthOfLength := uint64(prefix - 0xb7)
+		var err error
+		length, err = rlpLength(data[1:], lengthOfLength)
+		if err != nil {
+			return nil, false, nil, err
+		}
+		offset = 1 + lengthOfLength
+	case prefix < 0xf8:
+		offset, length, isList = 1, uint64(prefix-0xc0), true
+	default:
+		lengthOfLength := uint64(prefix - 0xf7)
+		var err error
+		length, err = rlpLength(data[1:], lengthOfLength)
+		if err != nil {
+			return nil, false, nil, err
+		}
+		offset, isList = 1+lengthOfLength, true
+	}
+
+	if uint64(len(data)) < offset || uint64(len(data))-offset < length {
+		return nil, false, nil, errors.New("RLP data too short")
+	}
+
+	return data[offset : offset+length], isList, data[offset+length:], nil
+}
+
+// rlpLength decodes a big-endian RLP length.
+func rlpLength(data []byte, size uint64) (uint64, error) {
+	if size > 8 || uint64(len(data)) < size {
+		return 0, errors.New("invalid RLP length")
+	}
+	length := uint64(0)
+	for i := uint64(0); i < size; i++ {
+		length = length<<8 | uint64(data[i])
+	}
+
+	return length, nil
+}
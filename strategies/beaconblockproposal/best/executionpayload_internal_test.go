@@ -0,0 +1,159 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package best
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionPayloadValue(t *testing.T) {
+	// Base fee of 7 GWei, little-endian.
+	baseFee := [32]byte{0x00, 0x86, 0x3b, 0xa1, 0x01}
+
+	// Dynamic fee transaction with max priority fee 2 GWei, max fee 10 GWei, gas limit 21000.
+	dynamicFeeTx := bellatrix.Transaction{0x02, 0xd0, 0x01, 0x80, 0x84, 0x77, 0x35, 0x94, 0x00, 0x85, 0x02, 0x54, 0x0b, 0xe4, 0x00, 0x82, 0x52, 0x08}
+	// Legacy transaction with gas price 8 GWei, gas limit 21000.
+	legacyTx := bellatrix.Transaction{0xca, 0x80, 0x85, 0x01, 0xdc, 0xd6, 0x50, 0x00, 0x82, 0x52, 0x08}
+	// Access list transaction with gas price 8 GWei, gas limit 21000.
+	accessListTx := bellatrix.Transaction{0x01, 0xcb, 0x01, 0x80, 0x85, 0x01, 0xdc, 0xd6, 0x50, 0x00, 0x82, 0x52, 0x08}
+
+	tests := []struct {
+		name    string
+		payload *bellatrix.ExecutionPayload
+		value   uint64
+		err     string
+	}{
+		{
+			name:  "Nil",
+			value: 0,
+		},
+		{
+			name: "NoTransactions",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       21000,
+			},
+			value: 0,
+		},
+		{
+			name: "DynamicFee",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       21000,
+				Transactions:  []bellatrix.Transaction{dynamicFeeTx},
+			},
+			value: 42000,
+		},
+		{
+			name: "DynamicFeeCapped",
+			payload: &bellatrix.ExecutionPayload{
+				// Base fee of 9 GWei, leaving a priority fee of 1 GWei.
+				BaseFeePerGas: [32]byte{0x00, 0x1a, 0x71, 0x18, 0x02},
+				GasUsed:       21000,
+				Transactions:  []bellatrix.Transaction{dynamicFeeTx},
+			},
+			value: 21000,
+		},
+		{
+			name: "Legacy",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       21000,
+				Transactions:  []bellatrix.Transaction{legacyTx},
+			},
+			value: 21000,
+		},
+		{
+			name: "Mixed",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       63000,
+				Transactions:  []bellatrix.Transaction{dynamicFeeTx, legacyTx, accessListTx},
+			},
+			value: 84000,
+		},
+		{
+			name: "PartialGasUsed",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       21000,
+				Transactions:  []bellatrix.Transaction{dynamicFeeTx, legacyTx},
+			},
+			value: 31500,
+		},
+		{
+			name: "UnknownType",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       21000,
+				Transactions:  []bellatrix.Transaction{{0x05, 0xc0}},
+			},
+			value: 0,
+		},
+		{
+			name: "Truncated",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       21000,
+				Transactions:  []bellatrix.Transaction{legacyTx[:6]},
+			},
+			value: 0,
+		},
+		{
+			name: "SkipsUndecodable",
+			payload: &bellatrix.ExecutionPayload{
+				BaseFeePerGas: baseFee,
+				GasUsed:       21000,
+				Transactions:  []bellatrix.Transaction{{0x05, 0xc0}, dynamicFeeTx, legacyTx[:6]},
+			},
+			value: 42000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := executionPayloadValue(test.payload)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.value, value)
+			}
+		})
+	}
+}
+
+func TestScoreExecutionPayload(t *testing.T) {
+	payload := &bellatrix.ExecutionPayload{
+		BlockHash:     phase0.Hash32{0x01},
+		BaseFeePerGas: [32]byte{0x00, 0x86, 0x3b, 0xa1, 0x01},
+		GasUsed:       21000,
+		Transactions: []bellatrix.Transaction{
+			{0xca, 0x80, 0x85, 0x01, 0xdc, 0xd6, 0x50, 0x00, 0x82, 0x52, 0x08},
+		},
+	}
+	value, score := scoreExecutionPayload(payload)
+	require.Equal(t, uint64(21000), value)
+	require.InDelta(t, 21000.0/23000.0, score, 1e-9)
+
+	// Pre-merge payload has no value.
+	payload.BlockHash = phase0.Hash32{}
+	value, score = scoreExecutionPayload(payload)
+	require.Equal(t, uint64(0), value)
+	require.Equal(t, float64(0), score)
+}
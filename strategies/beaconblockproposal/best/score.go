@@ -294,6 +294,9 @@ func (s *Service) scoreBellatrixBeaconBlockProposal(ctx context.Context,
 	// Add sync committee score.
 	syncCommitteeScore := float64(blockProposal.Body.SyncAggregate.SyncCommitteeBits.Count()) * float64(s.syncRewardWeight) / float64(s.weightDenominator)
 
+	// Add execution payload score.
+	executionPayloadGwei, executionPayloadScore := scoreExecutionPayload(blockProposal.Body.ExecutionPayload)
+
 	log.Trace().
 		Uint64("slot", uint64(blockProposal.Slot)).
 		Uint64("parent_slot", uint64(parentSlot)).
@@ -303,10 +306,37 @@ func (s *Service) scoreBellatrixBeaconBlockProposal(ctx context.Context,
 		Float64("proposer_slashings", proposerSlashingScore).
 		Float64("attester_slashings", attesterSlashingScore).
 		Float64("sync_committee", syncCommitteeScore).
-		Float64("total", attestationScore+proposerSlashingScore+attesterSlashingScore+syncCommitteeScore).
-		Msg("Scored Altair block")
+		Uint64("execution_payload_gwei", executionPayloadGwei).
+		Float64("execution_payload", executionPayloadScore).
+		Float64("total", attestationScore+proposerSlashingScore+attesterSlashingScore+syncCommitteeScore+executionPayloadScore).
+		Msg("Scored Bellatrix block")
 
-	return attestationScore + proposerSlashingScore + attesterSlashingScore + syncCommitteeScore
+	return attestationScore + proposerSlashingScore + attesterSlashingScore + syncCommitteeScore + executionPayloadScore
+}
+
+// scoreExecutionPayload returns the estimated value of an execution payload in Gwei,
+// along with its score.
+func scoreExecutionPayload(payload *bellatrix.ExecutionPayload) (uint64, float64) {
+	if payload == nil {
+		return 0, 0
+	}
+	if payload.BlockHash == (phase0.Hash32{}) {
+		// Pre-merge payload, no value.
+		return 0, 0
+	}
+
+	value, err := executionPayloadValue(payload)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to obtain execution payload value; assuming none")
+		return 0, 0
+	}
+
+	// Individual attestation reward at 250K validators will be around 23,000 GWei, and
+	// this is the scale used when weighting slashings.  Use the same scale to convert
+	// fees paid to the fee recipient so that they are comparable with consensus rewards.
+	gweiPerScore := float64(23000)
+
+	return value, float64(value) / gweiPerScore
 }
 
 func scoreSlashings(attesterSlashings []*phase0.AttesterSlashing,
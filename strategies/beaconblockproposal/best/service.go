@@ -15,6 +15,7 @@ package best
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -22,7 +23,14 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/attestantio/vouch/services/cache"
 	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/errorclassifier"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rs/zerolog"
@@ -31,13 +39,21 @@ import (
 
 // Service is the provider for beacon block proposals.
 type Service struct {
-	clientMonitor                metrics.ClientMonitor
-	processConcurrency           int64
-	chainTime                    chaintime.Service
-	beaconBlockProposalProviders map[string]eth2client.BeaconBlockProposalProvider
-	signedBeaconBlockProvider    eth2client.SignedBeaconBlockProvider
-	timeout                      time.Duration
-	blockRootToSlotCache         cache.BlockRootToSlotProvider
+	clientMonitor                    metrics.ClientMonitor
+	processConcurrency               int64
+	chainTime                        chaintime.Service
+	beaconBlockProposalProviders     map[string]eth2client.BeaconBlockProposalProvider
+	beaconBlockProposalProviderNames []string
+	reliability                      *reliability.Tracker
+	recorder                         recorder.Service
+	scorer                           scorer.Service
+	classifier                       errorclassifier.Service
+	signedBeaconBlockProvider        eth2client.SignedBeaconBlockProvider
+	timeout                          time.Duration
+	nodeHealth                       nodehealth.Service
+	consistency                      consistency.Service
+	deadline                         time.Duration
+	blockRootToSlotCache             cache.BlockRootToSlotProvider
 
 	// Spec values for scoring proposals.
 	slotsPerEpoch      uint64
@@ -150,22 +166,45 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 		return nil, errors.New("WEIGHT_DENOMINATOR of unexpected type")
 	}
 
+	reliabilityTracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(parameters.logLevel),
+		reliability.WithMonitor(parameters.monitor),
+		reliability.WithOperation("beacon block proposal"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reliability tracker")
+	}
+
+	beaconBlockProposalProviderNames := make([]string, 0, len(parameters.beaconBlockProposalProviders))
+	for name := range parameters.beaconBlockProposalProviders {
+		beaconBlockProposalProviderNames = append(beaconBlockProposalProviderNames, name)
+	}
+	sort.Strings(beaconBlockProposalProviderNames)
+
 	s := &Service{
-		processConcurrency:           parameters.processConcurrency,
-		chainTime:                    parameters.chainTime,
-		beaconBlockProposalProviders: parameters.beaconBlockProposalProviders,
-		signedBeaconBlockProvider:    parameters.signedBeaconBlockProvider,
-		timeout:                      parameters.timeout,
-		blockRootToSlotCache:         parameters.blockRootToSlotCache,
-		clientMonitor:                parameters.clientMonitor,
-		slotsPerEpoch:                slotsPerEpoch,
-		timelySourceWeight:           timelySourceWeight,
-		timelyTargetWeight:           timelyTargetWeight,
-		timelyHeadWeight:             timelyHeadWeight,
-		syncRewardWeight:             syncRewardWeight,
-		proposerWeight:               proposerWeight,
-		weightDenominator:            weightDenominator,
-		priorBlocksVotes:             make(map[phase0.Root]*priorBlockVotes),
+		processConcurrency:               parameters.processConcurrency,
+		chainTime:                        parameters.chainTime,
+		beaconBlockProposalProviders:     parameters.beaconBlockProposalProviders,
+		beaconBlockProposalProviderNames: beaconBlockProposalProviderNames,
+		reliability:                      reliabilityTracker,
+		recorder:                         parameters.recorder,
+		scorer:                           parameters.scorer,
+		classifier:                       parameters.classifier,
+		signedBeaconBlockProvider:        parameters.signedBeaconBlockProvider,
+		timeout:                          parameters.timeout,
+		nodeHealth:                       parameters.nodeHealth,
+		consistency:                      parameters.consistency,
+		deadline:                         parameters.deadline,
+		blockRootToSlotCache:             parameters.blockRootToSlotCache,
+		clientMonitor:                    parameters.clientMonitor,
+		slotsPerEpoch:                    slotsPerEpoch,
+		timelySourceWeight:               timelySourceWeight,
+		timelyTargetWeight:               timelyTargetWeight,
+		timelyHeadWeight:                 timelyHeadWeight,
+		syncRewardWeight:                 syncRewardWeight,
+		proposerWeight:                   proposerWeight,
+		weightDenominator:                weightDenominator,
+		priorBlocksVotes:                 make(map[phase0.Root]*priorBlockVotes),
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
@@ -178,3 +217,12 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 
 	return s, nil
 }
+
+// timeouts returns the hard and soft timeouts for a request for the given slot.
+func (s *Service) timeouts(slot phase0.Slot) (time.Duration, time.Duration, error) {
+	softTimeout := s.reliability.SoftTimeout(s.timeout)
+	if s.deadline == 0 {
+		return s.timeout, softTimeout, nil
+	}
+	return util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, softTimeout)
+}
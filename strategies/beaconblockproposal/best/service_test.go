@@ -76,6 +76,26 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no client monitor specified",
 		},
+		{
+			name: "MonitorMissing",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithMonitor(nil),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithBeaconBlockProposalProviders(map[string]eth2client.BeaconBlockProposalProvider{
+					"one":   mock.NewBeaconBlockProposalProvider(),
+					"two":   mock.NewBeaconBlockProposalProvider(),
+					"three": mock.NewBeaconBlockProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(cache),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
 		{
 			name: "TimeoutMissing",
 			params: []best.Parameter{
@@ -281,6 +301,27 @@ func TestService(t *testing.T) {
 			},
 			err: "failed to add head event handler: error",
 		},
+		{
+			name: "DeadlineNegative",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithClientMonitor(null.New(context.Background())),
+				best.WithEventsProvider(mock.NewEventsProvider()),
+				best.WithChainTimeService(chainTime),
+				best.WithSpecProvider(specProvider),
+				best.WithProcessConcurrency(1),
+				best.WithBeaconBlockProposalProviders(map[string]eth2client.BeaconBlockProposalProvider{
+					"one":   mock.NewBeaconBlockProposalProvider(),
+					"two":   mock.NewBeaconBlockProposalProvider(),
+					"three": mock.NewBeaconBlockProposalProvider(),
+				}),
+				best.WithSignedBeaconBlockProvider(mock.NewSignedBeaconBlockProvider()),
+				best.WithBlockRootToSlotCache(cache),
+				best.WithDeadline(-time.Second),
+			},
+			err: "problem with parameters: deadline cannot be negative",
+		},
 		{
 			name: "Good",
 			params: []best.Parameter{
@@ -20,8 +20,13 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	nullconsistency "github.com/attestantio/vouch/services/consistency/null"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodehealth"
+	nullnodehealth "github.com/attestantio/vouch/services/nodehealth/null"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -29,8 +34,14 @@ import (
 type parameters struct {
 	logLevel                     zerolog.Level
 	clientMonitor                metrics.ClientMonitor
+	monitor                      metrics.Service
 	beaconBlockProposalProviders map[string]eth2client.BeaconBlockProposalProvider
 	timeout                      time.Duration
+	chainTime                    chaintime.Service
+	deadline                     time.Duration
+	nodeHealth                   nodehealth.Service
+	consistency                  consistency.Service
+	hedgeDelay                   time.Duration
 }
 
 // Parameter is the interface for service parameters.
@@ -65,6 +76,21 @@ func WithBeaconBlockProposalProviders(providers map[string]eth2client.BeaconBloc
 	})
 }
 
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithHedgeDelay sets the delay before requesting from an additional provider.
+// If this is 0 requests are made to all providers at the same time.
+func WithHedgeDelay(delay time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.hedgeDelay = delay
+	})
+}
+
 // WithTimeout sets the timeout for requests.
 func WithTimeout(timeout time.Duration) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -72,11 +98,43 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithNodeHealth sets the node health service, used to avoid unhealthy beacon nodes.
+func WithNodeHealth(service nodehealth.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeHealth = service
+	})
+}
+
+// WithConsistency sets the consistency service, used to avoid beacon nodes on the wrong network.
+func WithConsistency(service consistency.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.consistency = service
+	})
+}
+
+// WithChainTime sets the chain time provider for this service.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
+// WithDeadline sets the deadline for requests, as an offset from the start of the slot.
+// If set, the timeouts for a request are reduced as required to complete by the deadline.
+func WithDeadline(deadline time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.deadline = deadline
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
 		logLevel:      zerolog.GlobalLevel(),
 		clientMonitor: nullmetrics.New(context.Background()),
+		monitor:       nullmetrics.New(context.Background()),
+		nodeHealth:    nullnodehealth.New(context.Background()),
+		consistency:   nullconsistency.New(context.Background()),
 	}
 	for _, p := range params {
 		if params != nil {
@@ -84,9 +142,31 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 		}
 	}
 
+	if parameters.clientMonitor == nil {
+		return nil, errors.New("no client monitor specified")
+	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.nodeHealth == nil {
+		return nil, errors.New("no node health service specified")
+	}
+	if parameters.consistency == nil {
+		return nil, errors.New("no consistency service specified")
+	}
+	if parameters.hedgeDelay < 0 {
+		return nil, errors.New("hedge delay cannot be negative")
+	}
 	if parameters.beaconBlockProposalProviders == nil {
 		return nil, errors.New("no beacon block proposal providers specified")
 	}
 
+	if parameters.deadline < 0 {
+		return nil, errors.New("deadline cannot be negative")
+	}
+	if parameters.deadline != 0 && parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified for deadline")
+	}
+
 	return &parameters, nil
 }
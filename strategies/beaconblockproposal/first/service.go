@@ -15,12 +15,18 @@ package first
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -28,16 +34,23 @@ import (
 
 // Service is the provider for beacon block proposals.
 type Service struct {
-	clientMonitor                metrics.ClientMonitor
-	beaconBlockProposalProviders map[string]eth2client.BeaconBlockProposalProvider
-	timeout                      time.Duration
+	clientMonitor                    metrics.ClientMonitor
+	beaconBlockProposalProviders     map[string]eth2client.BeaconBlockProposalProvider
+	timeout                          time.Duration
+	chainTime                        chaintime.Service
+	deadline                         time.Duration
+	nodeHealth                       nodehealth.Service
+	consistency                      consistency.Service
+	hedgeDelay                       time.Duration
+	beaconBlockProposalProviderNames []string
+	reliability                      *reliability.Tracker
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new beacon block propsal strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -49,23 +62,65 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	reliabilityTracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(parameters.logLevel),
+		reliability.WithMonitor(parameters.monitor),
+		reliability.WithOperation("beacon block proposal"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reliability tracker")
+	}
+
+	beaconBlockProposalProviderNames := make([]string, 0, len(parameters.beaconBlockProposalProviders))
+	for name := range parameters.beaconBlockProposalProviders {
+		beaconBlockProposalProviderNames = append(beaconBlockProposalProviderNames, name)
+	}
+	sort.Strings(beaconBlockProposalProviderNames)
+
 	s := &Service{
-		beaconBlockProposalProviders: parameters.beaconBlockProposalProviders,
-		timeout:                      parameters.timeout,
-		clientMonitor:                parameters.clientMonitor,
+		hedgeDelay:                       parameters.hedgeDelay,
+		beaconBlockProposalProviderNames: beaconBlockProposalProviderNames,
+		reliability:                      reliabilityTracker,
+		beaconBlockProposalProviders:     parameters.beaconBlockProposalProviders,
+		timeout:                          parameters.timeout,
+		chainTime:                        parameters.chainTime,
+		deadline:                         parameters.deadline,
+		nodeHealth:                       parameters.nodeHealth,
+		consistency:                      parameters.consistency,
+		clientMonitor:                    parameters.clientMonitor,
 	}
 
 	return s, nil
 }
 
+// requestTimeout returns the timeout for a request for the given slot.
+func (s *Service) requestTimeout(slot phase0.Slot) (time.Duration, error) {
+	if s.deadline == 0 {
+		return s.timeout, nil
+	}
+	timeout, _, err := util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, s.timeout)
+	return timeout, err
+}
+
 // BeaconBlockProposal provides the first beacon block proposal from a number of beacon nodes.
 func (s *Service) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) (*spec.VersionedBeaconBlock, error) {
+	if s.hedgeDelay > 0 {
+		return s.hedgedBeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
+	}
+
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
 	// We create a cancelable context with a timeout.  As soon as the first provider has responded we
 	// cancel the context to cancel the other requests.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 
 	proposalCh := make(chan *spec.VersionedBeaconBlock, 1)
-	for name, provider := range s.beaconBlockProposalProviders {
+	for _, name := range s.nodeHealth.Filter(s.consistency.Filter(s.beaconBlockProposalProviderNames)) {
+		provider := s.beaconBlockProposalProviders[name]
 		go func(ctx context.Context, name string, provider eth2client.BeaconBlockProposalProvider, ch chan *spec.VersionedBeaconBlock) {
 			log := log.With().Str("provider", name).Uint64("slot", uint64(slot)).Logger()
 
@@ -96,3 +151,41 @@ func (s *Service) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, ran
 		return proposal, nil
 	}
 }
+
+// hedgedBeaconBlockProposal obtains the beacon block proposal from providers in order of their historical latency,
+// only requesting from an additional provider if no response has been received within the hedge delay.
+func (s *Service) hedgedBeaconBlockProposal(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) (*spec.VersionedBeaconBlock, error) {
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res, err := s.reliability.Hedged(ctx, s.nodeHealth.Filter(s.consistency.Filter(s.beaconBlockProposalProviderNames)), s.hedgeDelay, func(ctx context.Context, name string) (interface{}, error) {
+		log := log.With().Str("provider", name).Uint64("slot", uint64(slot)).Logger()
+
+		started := time.Now()
+		proposal, err := s.beaconBlockProposalProviders[name].BeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
+		s.clientMonitor.ClientOperation(name, "beacon block proposal", err == nil, time.Since(started))
+		if err != nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to obtain beacon block proposal")
+			return nil, err
+		}
+		if proposal == nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Msg("Returned empty beacon block proposal")
+			return nil, errors.New("empty beacon block proposal")
+		}
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained beacon block proposal")
+
+		return proposal, nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain beacon block proposal")
+		return nil, errors.Wrap(err, "failed to obtain beacon block proposal")
+	}
+
+	return res.(*spec.VersionedBeaconBlock), nil
+}
@@ -0,0 +1,69 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// AttesterDuties obtains attester duties from multiple beacon nodes, reporting any differences.
+func (s *Service) AttesterDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+) (
+	[]*apiv1.AttesterDuty,
+	error,
+) {
+	fetch := func(ctx context.Context, name string) ([]*duty, error) {
+		attesterDuties, err := s.attesterDutiesProviders[name].AttesterDuties(ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, err
+		}
+		duties := make([]*duty, 0, len(attesterDuties))
+		for _, attesterDuty := range attesterDuties {
+			if attesterDuty == nil {
+				continue
+			}
+			duties = append(duties, &duty{
+				key: fmt.Sprintf("attester %d", attesterDuty.ValidatorIndex),
+				content: fmt.Sprintf("slot %d committee %d (length %d, committees %d) position %d pubkey %#x",
+					attesterDuty.Slot,
+					attesterDuty.CommitteeIndex,
+					attesterDuty.CommitteeLength,
+					attesterDuty.CommitteesAtSlot,
+					attesterDuty.ValidatorCommitteeIndex,
+					attesterDuty.PubKey,
+				),
+				value: attesterDuty,
+			})
+		}
+		return duties, nil
+	}
+
+	values, err := s.crossCheck(ctx, "attester duties", epoch, s.attesterDutiesProviderNames, fetch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attester duties")
+	}
+
+	res := make([]*apiv1.AttesterDuty, len(values))
+	for i := range values {
+		res[i] = values[i].(*apiv1.AttesterDuty)
+	}
+	return res, nil
+}
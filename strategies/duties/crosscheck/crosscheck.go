@@ -0,0 +1,185 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// duty is a single duty returned by a provider.
+type duty struct {
+	// key identifies the duty, for example by validator index.
+	key string
+	// content summarises the details of the duty, for comparison across providers.
+	content string
+	value   interface{}
+}
+
+type dutiesResponse struct {
+	provider string
+	duties   []*duty
+}
+
+// fetchFunc fetches duties from the named provider.
+type fetchFunc func(ctx context.Context, name string) ([]*duty, error)
+
+// crossCheck fetches duties from all healthy providers and compares them.
+// A duty reported by any provider is returned, and where providers disagree on the details of
+// a duty those reported by the most providers are used, with ties broken by provider name.
+func (s *Service) crossCheck(ctx context.Context,
+	operation string,
+	epoch phase0.Epoch,
+	names []string,
+	fetch fetchFunc,
+) (
+	[]interface{},
+	error,
+) {
+	started := time.Now()
+	log := log.With().Str("operation", operation).Uint64("epoch", uint64(epoch)).Logger()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	providers := s.nodeHealth.Filter(s.consistency.Filter(names))
+	respCh := make(chan *dutiesResponse, len(providers))
+	errCh := make(chan error, len(providers))
+	for _, name := range providers {
+		go func(ctx context.Context, name string) {
+			duties, err := fetch(ctx, name)
+			s.clientMonitor.ClientOperation(name, operation, err == nil, time.Since(started))
+			if err != nil {
+				errCh <- errors.Wrap(err, name)
+				return
+			}
+			respCh <- &dutiesResponse{
+				provider: name,
+				duties:   duties,
+			}
+		}(ctx, name)
+	}
+
+	// Wait for all responses, or context done.
+	responded := 0
+	errored := 0
+	timedOut := 0
+	responses := make(map[string]*dutiesResponse, len(providers))
+	for timedOut == 0 && responded+errored != len(providers) {
+		select {
+		case <-ctx.Done():
+			// Anyone not responded by now is considered timed out.
+			timedOut = len(providers) - responded - errored
+			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Timeout reached")
+		case err := <-errCh:
+			errored++
+			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
+		case resp := <-respCh:
+			responded++
+			responses[resp.provider] = resp
+			log.Trace().Dur("elapsed", time.Since(started)).Str("provider", resp.provider).Int("duties", len(resp.duties)).Msg("Response")
+		}
+	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	if len(responses) == 0 {
+		return nil, errors.New("no duties obtained")
+	}
+	if len(responses) == 1 {
+		log.Debug().Msg("Only one provider responded; duties cannot be cross-checked")
+	}
+
+	// Responses are considered in provider name order, so that the outcome does not depend on the order in which they arrived.
+	ordered := make([]*dutiesResponse, 0, len(responses))
+	for _, resp := range responses {
+		ordered = append(ordered, resp)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].provider < ordered[j].provider
+	})
+
+	return reconcile(log, operation, ordered), nil
+}
+
+// reconcile combines the duties from multiple responses, reporting any differences.
+func reconcile(log zerolog.Logger, operation string, responses []*dutiesResponse) []interface{} {
+	keys := make([]string, 0)
+	reported := make(map[string]map[string]*duty)
+	for _, resp := range responses {
+		for _, d := range resp.duties {
+			if _, exists := reported[d.key]; !exists {
+				keys = append(keys, d.key)
+				reported[d.key] = make(map[string]*duty)
+			}
+			reported[d.key][resp.provider] = d
+		}
+	}
+
+	res := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		byProvider := reported[key]
+
+		// Providers that did not report the duty.
+		missing := make([]string, 0)
+		for _, resp := range responses {
+			if _, exists := byProvider[resp.provider]; !exists {
+				missing = append(missing, resp.provider)
+			}
+		}
+		if len(missing) > 0 {
+			for _, provider := range missing {
+				monitorMismatch(operation, provider, "missing")
+			}
+			log.Warn().Str("duty", key).Strs("missing", missing).Msg("Providers did not report a duty reported by other providers; acting on it")
+		}
+
+		// Group providers by the details of the duty they reported.
+		contents := make([]string, 0)
+		holders := make(map[string][]string)
+		for _, resp := range responses {
+			d, exists := byProvider[resp.provider]
+			if !exists {
+				continue
+			}
+			if _, exists := holders[d.content]; !exists {
+				contents = append(contents, d.content)
+			}
+			holders[d.content] = append(holders[d.content], resp.provider)
+		}
+		selected := contents[0]
+		for _, content := range contents[1:] {
+			if len(holders[content]) > len(holders[selected]) {
+				selected = content
+			}
+		}
+		for _, content := range contents {
+			if content == selected {
+				continue
+			}
+			for _, provider := range holders[content] {
+				monitorMismatch(operation, provider, "conflict")
+			}
+			log.Warn().Str("duty", key).Strs("providers", holders[content]).Str("details", content).Strs("selected_providers", holders[selected]).Str("selected_details", selected).Msg("Providers disagree on the details of a duty; acting on the majority")
+		}
+
+		res = append(res, byProvider[holders[selected][0]].value)
+	}
+
+	return res
+}
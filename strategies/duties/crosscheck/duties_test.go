@@ -0,0 +1,185 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/strategies/duties/crosscheck"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// dutiesProvider returns fixed duties.
+type dutiesProvider struct {
+	attesterDuties      []*apiv1.AttesterDuty
+	proposerDuties      []*apiv1.ProposerDuty
+	syncCommitteeDuties []*apiv1.SyncCommitteeDuty
+	err                 error
+}
+
+func (p *dutiesProvider) AttesterDuties(_ context.Context, _ phase0.Epoch, _ []phase0.ValidatorIndex) ([]*apiv1.AttesterDuty, error) {
+	return p.attesterDuties, p.err
+}
+
+func (p *dutiesProvider) ProposerDuties(_ context.Context, _ phase0.Epoch, _ []phase0.ValidatorIndex) ([]*apiv1.ProposerDuty, error) {
+	return p.proposerDuties, p.err
+}
+
+func (p *dutiesProvider) SyncCommitteeDuties(_ context.Context, _ phase0.Epoch, _ []phase0.ValidatorIndex) ([]*apiv1.SyncCommitteeDuty, error) {
+	return p.syncCommitteeDuties, p.err
+}
+
+func newService(t *testing.T, providers map[string]*dutiesProvider) *crosscheck.Service {
+	t.Helper()
+
+	attesterDutiesProviders := make(map[string]eth2client.AttesterDutiesProvider)
+	proposerDutiesProviders := make(map[string]eth2client.ProposerDutiesProvider)
+	syncCommitteeDutiesProviders := make(map[string]eth2client.SyncCommitteeDutiesProvider)
+	for name, provider := range providers {
+		attesterDutiesProviders[name] = provider
+		proposerDutiesProviders[name] = provider
+		syncCommitteeDutiesProviders[name] = provider
+	}
+
+	s, err := crosscheck.New(context.Background(),
+		crosscheck.WithLogLevel(zerolog.Disabled),
+		crosscheck.WithTimeout(2*time.Second),
+		crosscheck.WithAttesterDutiesProviders(attesterDutiesProviders),
+		crosscheck.WithProposerDutiesProviders(proposerDutiesProviders),
+		crosscheck.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+	)
+	require.NoError(t, err)
+	return s
+}
+
+func attesterDuty(validatorIndex phase0.ValidatorIndex, slot phase0.Slot) *apiv1.AttesterDuty {
+	return &apiv1.AttesterDuty{
+		ValidatorIndex:   validatorIndex,
+		Slot:             slot,
+		CommitteeIndex:   1,
+		CommitteeLength:  128,
+		CommitteesAtSlot: 64,
+	}
+}
+
+func TestAttesterDuties(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		providers map[string]*dutiesProvider
+		expected  []*apiv1.AttesterDuty
+		err       string
+	}{
+		{
+			name: "Agree",
+			providers: map[string]*dutiesProvider{
+				"a": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10), attesterDuty(2, 11)}},
+				"b": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10), attesterDuty(2, 11)}},
+			},
+			expected: []*apiv1.AttesterDuty{attesterDuty(1, 10), attesterDuty(2, 11)},
+		},
+		{
+			name: "Missing",
+			providers: map[string]*dutiesProvider{
+				"a": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10)}},
+				"b": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10), attesterDuty(2, 11)}},
+			},
+			expected: []*apiv1.AttesterDuty{attesterDuty(1, 10), attesterDuty(2, 11)},
+		},
+		{
+			name: "ConflictMajority",
+			providers: map[string]*dutiesProvider{
+				"a": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 12)}},
+				"b": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10)}},
+				"c": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10)}},
+			},
+			expected: []*apiv1.AttesterDuty{attesterDuty(1, 10)},
+		},
+		{
+			name: "ConflictTie",
+			providers: map[string]*dutiesProvider{
+				"a": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 12)}},
+				"b": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10)}},
+			},
+			expected: []*apiv1.AttesterDuty{attesterDuty(1, 12)},
+		},
+		{
+			name: "Errored",
+			providers: map[string]*dutiesProvider{
+				"a": {err: errors.New("failed")},
+				"b": {attesterDuties: []*apiv1.AttesterDuty{attesterDuty(1, 10)}},
+			},
+			expected: []*apiv1.AttesterDuty{attesterDuty(1, 10)},
+		},
+		{
+			name: "AllErrored",
+			providers: map[string]*dutiesProvider{
+				"a": {err: errors.New("failed")},
+				"b": {err: errors.New("failed")},
+			},
+			err: "failed to obtain attester duties: no duties obtained",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newService(t, test.providers)
+			duties, err := s.AttesterDuties(ctx, 1, nil)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, duties)
+			}
+		})
+	}
+}
+
+func TestProposerDuties(t *testing.T) {
+	ctx := context.Background()
+
+	// Providers disagree on the proposer for slot 33, so both are returned.
+	s := newService(t, map[string]*dutiesProvider{
+		"a": {proposerDuties: []*apiv1.ProposerDuty{{ValidatorIndex: 1, Slot: 32}, {ValidatorIndex: 2, Slot: 33}}},
+		"b": {proposerDuties: []*apiv1.ProposerDuty{{ValidatorIndex: 1, Slot: 32}, {ValidatorIndex: 3, Slot: 33}}},
+	})
+	duties, err := s.ProposerDuties(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*apiv1.ProposerDuty{
+		{ValidatorIndex: 1, Slot: 32},
+		{ValidatorIndex: 2, Slot: 33},
+		{ValidatorIndex: 3, Slot: 33},
+	}, duties)
+}
+
+func TestSyncCommitteeDuties(t *testing.T) {
+	ctx := context.Background()
+
+	s := newService(t, map[string]*dutiesProvider{
+		"a": {syncCommitteeDuties: []*apiv1.SyncCommitteeDuty{}},
+		"b": {syncCommitteeDuties: []*apiv1.SyncCommitteeDuty{{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{5}}}},
+		"c": {syncCommitteeDuties: []*apiv1.SyncCommitteeDuty{{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{5}}}},
+	})
+	duties, err := s.SyncCommitteeDuties(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*apiv1.SyncCommitteeDuty{{ValidatorIndex: 1, ValidatorSyncCommitteeIndices: []phase0.CommitteeIndex{5}}}, duties)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var mismatches *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if mismatches != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	mismatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_duties_crosscheck",
+		Name:      "mismatches_total",
+		Help:      "The number of duties on which a provider disagreed with other providers.",
+	}, []string{"operation", "provider", "type"})
+	return prometheus.Register(mismatches)
+}
+
+// monitorMismatch records a provider that did not report a duty ("missing"), or reported different details ("conflict").
+func monitorMismatch(operation string, provider string, mismatchType string) {
+	if mismatches == nil {
+		return
+	}
+	mismatches.WithLabelValues(operation, provider, mismatchType).Inc()
+}
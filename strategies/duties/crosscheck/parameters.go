@@ -0,0 +1,161 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package crosscheck is a strategy that obtains duties from multiple beacon
+// nodes and compares them, reporting any differences and acting on the union
+// of the duties so that a single beacon node cannot hide duties from Vouch.
+package crosscheck
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/consistency"
+	nullconsistency "github.com/attestantio/vouch/services/consistency/null"
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodehealth"
+	nullnodehealth "github.com/attestantio/vouch/services/nodehealth/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel                     zerolog.Level
+	monitor                      metrics.Service
+	clientMonitor                metrics.ClientMonitor
+	attesterDutiesProviders      map[string]eth2client.AttesterDutiesProvider
+	proposerDutiesProviders      map[string]eth2client.ProposerDutiesProvider
+	syncCommitteeDutiesProviders map[string]eth2client.SyncCommitteeDutiesProvider
+	timeout                      time.Duration
+	nodeHealth                   nodehealth.Service
+	consistency                  consistency.Service
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithClientMonitor sets the client monitor for the service.
+func WithClientMonitor(monitor metrics.ClientMonitor) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.clientMonitor = monitor
+	})
+}
+
+// WithAttesterDutiesProviders sets the attester duties providers.
+func WithAttesterDutiesProviders(providers map[string]eth2client.AttesterDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.attesterDutiesProviders = providers
+	})
+}
+
+// WithProposerDutiesProviders sets the proposer duties providers.
+func WithProposerDutiesProviders(providers map[string]eth2client.ProposerDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.proposerDutiesProviders = providers
+	})
+}
+
+// WithSyncCommitteeDutiesProviders sets the sync committee duties providers.
+func WithSyncCommitteeDutiesProviders(providers map[string]eth2client.SyncCommitteeDutiesProvider) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.syncCommitteeDutiesProviders = providers
+	})
+}
+
+// WithTimeout sets the timeout for requests.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// WithNodeHealth sets the node health service, used to avoid unhealthy beacon nodes.
+func WithNodeHealth(service nodehealth.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeHealth = service
+	})
+}
+
+// WithConsistency sets the consistency service, used to avoid beacon nodes on the wrong network.
+func WithConsistency(service consistency.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.consistency = service
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		monitor:       nullmetrics.New(context.Background()),
+		nodeHealth:    nullnodehealth.New(context.Background()),
+		consistency:   nullconsistency.New(context.Background()),
+		clientMonitor: nullmetrics.New(context.Background()),
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.nodeHealth == nil {
+		return nil, errors.New("no node health service specified")
+	}
+	if parameters.consistency == nil {
+		return nil, errors.New("no consistency service specified")
+	}
+	if parameters.clientMonitor == nil {
+		return nil, errors.New("no client monitor specified")
+	}
+	if len(parameters.attesterDutiesProviders) == 0 {
+		return nil, errors.New("no attester duties providers specified")
+	}
+	if len(parameters.proposerDutiesProviders) == 0 {
+		return nil, errors.New("no proposer duties providers specified")
+	}
+	if len(parameters.syncCommitteeDutiesProviders) == 0 {
+		return nil, errors.New("no sync committee duties providers specified")
+	}
+
+	return &parameters, nil
+}
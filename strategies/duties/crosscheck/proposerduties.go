@@ -0,0 +1,64 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// ProposerDuties obtains proposer duties from multiple beacon nodes, reporting any differences.
+// Duties are identified by both slot and validator, so if beacon nodes disagree on the proposer
+// for a slot the duties of all proposers reported are returned.
+func (s *Service) ProposerDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+) (
+	[]*apiv1.ProposerDuty,
+	error,
+) {
+	fetch := func(ctx context.Context, name string) ([]*duty, error) {
+		proposerDuties, err := s.proposerDutiesProviders[name].ProposerDuties(ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, err
+		}
+		duties := make([]*duty, 0, len(proposerDuties))
+		for _, proposerDuty := range proposerDuties {
+			if proposerDuty == nil {
+				continue
+			}
+			duties = append(duties, &duty{
+				key:     fmt.Sprintf("proposer %d slot %d", proposerDuty.ValidatorIndex, proposerDuty.Slot),
+				content: fmt.Sprintf("pubkey %#x", proposerDuty.PubKey),
+				value:   proposerDuty,
+			})
+		}
+		return duties, nil
+	}
+
+	values, err := s.crossCheck(ctx, "proposer duties", epoch, s.proposerDutiesProviderNames, fetch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer duties")
+	}
+
+	res := make([]*apiv1.ProposerDuty, len(values))
+	for i := range values {
+		res[i] = values[i].(*apiv1.ProposerDuty)
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service is the provider for duties.
+type Service struct {
+	clientMonitor                    metrics.ClientMonitor
+	attesterDutiesProviders          map[string]eth2client.AttesterDutiesProvider
+	attesterDutiesProviderNames      []string
+	proposerDutiesProviders          map[string]eth2client.ProposerDutiesProvider
+	proposerDutiesProviderNames      []string
+	syncCommitteeDutiesProviders     map[string]eth2client.SyncCommitteeDutiesProvider
+	syncCommitteeDutiesProviderNames []string
+	timeout                          time.Duration
+	nodeHealth                       nodehealth.Service
+	consistency                      consistency.Service
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new duties strategy.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "duties").Str("impl", "crosscheck").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	attesterDutiesProviderNames := make([]string, 0, len(parameters.attesterDutiesProviders))
+	for name := range parameters.attesterDutiesProviders {
+		attesterDutiesProviderNames = append(attesterDutiesProviderNames, name)
+	}
+	sort.Strings(attesterDutiesProviderNames)
+	proposerDutiesProviderNames := make([]string, 0, len(parameters.proposerDutiesProviders))
+	for name := range parameters.proposerDutiesProviders {
+		proposerDutiesProviderNames = append(proposerDutiesProviderNames, name)
+	}
+	sort.Strings(proposerDutiesProviderNames)
+	syncCommitteeDutiesProviderNames := make([]string, 0, len(parameters.syncCommitteeDutiesProviders))
+	for name := range parameters.syncCommitteeDutiesProviders {
+		syncCommitteeDutiesProviderNames = append(syncCommitteeDutiesProviderNames, name)
+	}
+	sort.Strings(syncCommitteeDutiesProviderNames)
+
+	s := &Service{
+		clientMonitor:                    parameters.clientMonitor,
+		attesterDutiesProviders:          parameters.attesterDutiesProviders,
+		attesterDutiesProviderNames:      attesterDutiesProviderNames,
+		proposerDutiesProviders:          parameters.proposerDutiesProviders,
+		proposerDutiesProviderNames:      proposerDutiesProviderNames,
+		syncCommitteeDutiesProviders:     parameters.syncCommitteeDutiesProviders,
+		syncCommitteeDutiesProviderNames: syncCommitteeDutiesProviderNames,
+		timeout:                          parameters.timeout,
+		nodeHealth:                       parameters.nodeHealth,
+		consistency:                      parameters.consistency,
+	}
+
+	return s, nil
+}
@@ -0,0 +1,146 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/mock"
+	"github.com/attestantio/vouch/strategies/duties/crosscheck"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	attesterDutiesProviders := map[string]eth2client.AttesterDutiesProvider{
+		"one": mock.NewAttesterDutiesProvider(),
+	}
+	proposerDutiesProviders := map[string]eth2client.ProposerDutiesProvider{
+		"one": mock.NewProposerDutiesProvider(),
+	}
+	syncCommitteeDutiesProviders := map[string]eth2client.SyncCommitteeDutiesProvider{
+		"one": mock.NewSyncCommitteeDutiesProvider(),
+	}
+
+	tests := []struct {
+		name   string
+		params []crosscheck.Parameter
+		err    string
+	}{
+		{
+			name: "TimeoutMissing",
+			params: []crosscheck.Parameter{
+				crosscheck.WithLogLevel(zerolog.Disabled),
+				crosscheck.WithAttesterDutiesProviders(attesterDutiesProviders),
+				crosscheck.WithProposerDutiesProviders(proposerDutiesProviders),
+				crosscheck.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "MonitorMissing",
+			params: []crosscheck.Parameter{
+				crosscheck.WithLogLevel(zerolog.Disabled),
+				crosscheck.WithMonitor(nil),
+				crosscheck.WithTimeout(2 * time.Second),
+				crosscheck.WithAttesterDutiesProviders(attesterDutiesProviders),
+				crosscheck.WithProposerDutiesProviders(proposerDutiesProviders),
+				crosscheck.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "ClientMonitorMissing",
+			params: []crosscheck.Parameter{
+				crosscheck.WithLogLevel(zerolog.Disabled),
+				crosscheck.WithClientMonitor(nil),
+				crosscheck.WithTimeout(2 * time.Second),
+				crosscheck.WithAttesterDutiesProviders(attesterDutiesProviders),
+				crosscheck.WithProposerDutiesProviders(proposerDutiesProviders),
+				crosscheck.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+			},
+			err: "problem with parameters: no client monitor specified",
+		},
+		{
+			name: "AttesterDutiesProvidersMissing",
+			params: []crosscheck.Parameter{
+				crosscheck.WithLogLevel(zerolog.Disabled),
+				crosscheck.WithTimeout(2 * time.Second),
+				crosscheck.WithProposerDutiesProviders(proposerDutiesProviders),
+				crosscheck.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+			},
+			err: "problem with parameters: no attester duties providers specified",
+		},
+		{
+			name: "ProposerDutiesProvidersMissing",
+			params: []crosscheck.Parameter{
+				crosscheck.WithLogLevel(zerolog.Disabled),
+				crosscheck.WithTimeout(2 * time.Second),
+				crosscheck.WithAttesterDutiesProviders(attesterDutiesProviders),
+				crosscheck.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+			},
+			err: "problem with parameters: no proposer duties providers specified",
+		},
+		{
+			name: "SyncCommitteeDutiesProvidersMissing",
+			params: []crosscheck.Parameter{
+				crosscheck.WithLogLevel(zerolog.Disabled),
+				crosscheck.WithTimeout(2 * time.Second),
+				crosscheck.WithAttesterDutiesProviders(attesterDutiesProviders),
+				crosscheck.WithProposerDutiesProviders(proposerDutiesProviders),
+			},
+			err: "problem with parameters: no sync committee duties providers specified",
+		},
+		{
+			name: "Good",
+			params: []crosscheck.Parameter{
+				crosscheck.WithLogLevel(zerolog.Disabled),
+				crosscheck.WithTimeout(2 * time.Second),
+				crosscheck.WithAttesterDutiesProviders(attesterDutiesProviders),
+				crosscheck.WithProposerDutiesProviders(proposerDutiesProviders),
+				crosscheck.WithSyncCommitteeDutiesProviders(syncCommitteeDutiesProviders),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := crosscheck.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInterfaces(t *testing.T) {
+	s, err := crosscheck.New(context.Background(),
+		crosscheck.WithLogLevel(zerolog.Disabled),
+		crosscheck.WithTimeout(2*time.Second),
+		crosscheck.WithAttesterDutiesProviders(map[string]eth2client.AttesterDutiesProvider{"one": mock.NewAttesterDutiesProvider()}),
+		crosscheck.WithProposerDutiesProviders(map[string]eth2client.ProposerDutiesProvider{"one": mock.NewProposerDutiesProvider()}),
+		crosscheck.WithSyncCommitteeDutiesProviders(map[string]eth2client.SyncCommitteeDutiesProvider{"one": mock.NewSyncCommitteeDutiesProvider()}),
+	)
+	require.NoError(t, err)
+	require.Implements(t, (*eth2client.AttesterDutiesProvider)(nil), s)
+	require.Implements(t, (*eth2client.ProposerDutiesProvider)(nil), s)
+	require.Implements(t, (*eth2client.SyncCommitteeDutiesProvider)(nil), s)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosscheck
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SyncCommitteeDuties obtains sync committee duties from multiple beacon nodes, reporting any differences.
+func (s *Service) SyncCommitteeDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	validatorIndices []phase0.ValidatorIndex,
+) (
+	[]*apiv1.SyncCommitteeDuty,
+	error,
+) {
+	fetch := func(ctx context.Context, name string) ([]*duty, error) {
+		syncCommitteeDuties, err := s.syncCommitteeDutiesProviders[name].SyncCommitteeDuties(ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, err
+		}
+		duties := make([]*duty, 0, len(syncCommitteeDuties))
+		for _, syncCommitteeDuty := range syncCommitteeDuties {
+			if syncCommitteeDuty == nil {
+				continue
+			}
+			duties = append(duties, &duty{
+				key:     fmt.Sprintf("sync committee %d", syncCommitteeDuty.ValidatorIndex),
+				content: fmt.Sprintf("positions %v pubkey %#x", syncCommitteeDuty.ValidatorSyncCommitteeIndices, syncCommitteeDuty.PubKey),
+				value:   syncCommitteeDuty,
+			})
+		}
+		return duties, nil
+	}
+
+	values, err := s.crossCheck(ctx, "sync committee duties", epoch, s.syncCommitteeDutiesProviderNames, fetch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain sync committee duties")
+	}
+
+	res := make([]*apiv1.SyncCommitteeDuty, len(values))
+	for i := range values {
+		res[i] = values[i].(*apiv1.SyncCommitteeDuty)
+	}
+	return res, nil
+}
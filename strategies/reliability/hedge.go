@@ -0,0 +1,151 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reliability
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RequestFunc makes a request to the named provider.
+type RequestFunc func(ctx context.Context, name string) (interface{}, error)
+
+type hedgedResponse struct {
+	name string
+	res  interface{}
+	err  error
+}
+
+// Hedged makes a hedged request to the named providers.
+// The request is made to the provider with the lowest historical latency first.  If
+// no successful response has been received after the hedge delay the request is also
+// made to the next fastest provider, and so on; if a request fails the next provider
+// is tried immediately.  As soon as a successful response is received it is returned,
+// and all outstanding requests are cancelled without being recorded against their
+// providers.
+func (t *Tracker) Hedged(ctx context.Context, names []string, delay time.Duration, request RequestFunc) (interface{}, error) {
+	providers := t.fastest(t.Providers(names))
+	if len(providers) == 0 {
+		return nil, errors.New("no providers")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	respCh := make(chan *hedgedResponse, len(providers))
+	next := 0
+	inFlight := 0
+	fire := func() {
+		name := providers[next]
+		next++
+		inFlight++
+		go func() {
+			started := time.Now()
+			res, err := request(ctx, name)
+			if err == nil && res == nil {
+				err = errors.New("empty response")
+			}
+			switch {
+			case err != nil && errors.Is(ctx.Err(), context.Canceled):
+				// Cancelled because another provider responded first, which says
+				// nothing about how long this provider would have taken.
+			case err != nil:
+				t.Failure(ctx, name, time.Since(started))
+			default:
+				t.Success(name, time.Since(started))
+			}
+			respCh <- &hedgedResponse{
+				name: name,
+				res:  res,
+				err:  err,
+			}
+		}()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	resetTimer := func() {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(delay)
+	}
+
+	hedged := false
+	fire()
+	for {
+		select {
+		case <-ctx.Done():
+			monitorHedgedRequest(t.operation, hedged, "timeout")
+			return nil, errors.New("no response before timeout")
+		case <-timer.C:
+			if next < len(providers) {
+				t.log.Trace().Str("provider", providers[next]).Msg("Hedge delay reached; requesting from next provider")
+				hedged = true
+				monitorHedge(t.operation)
+				fire()
+				timer.Reset(delay)
+			}
+		case resp := <-respCh:
+			inFlight--
+			if resp.err == nil {
+				t.Selected(resp.name)
+				monitorHedgedRequest(t.operation, hedged, "succeeded")
+				return resp.res, nil
+			}
+			t.log.Debug().Str("provider", resp.name).Err(resp.err).Msg("Hedged request failed")
+			if next < len(providers) {
+				fire()
+				resetTimer()
+			} else if inFlight == 0 {
+				monitorHedgedRequest(t.operation, hedged, "failed")
+				return nil, errors.New("no successful responses")
+			}
+		}
+	}
+}
+
+// fastest orders the providers by their historical median latency, fastest first.
+// Providers without history are placed after those with history.
+func (t *Tracker) fastest(names []string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	latencies := make(map[string]time.Duration, len(names))
+	for _, name := range names {
+		profile := t.profile(t.provider(name))
+		if profile.Latency50 > 0 {
+			latencies[name] = profile.Latency50
+		}
+	}
+
+	res := make([]string, len(names))
+	copy(res, names)
+	sort.SliceStable(res, func(i, j int) bool {
+		iLatency, iExists := latencies[res[i]]
+		jLatency, jExists := latencies[res[j]]
+		if iExists != jExists {
+			return iExists
+		}
+		return iLatency < jLatency
+	})
+
+	return res
+}
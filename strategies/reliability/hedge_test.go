@@ -0,0 +1,166 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reliability_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// requester provides canned responses with optional delays, and records the providers requested.
+type requester struct {
+	mu        sync.Mutex
+	delays    map[string]time.Duration
+	failures  map[string]bool
+	requested []string
+}
+
+func (r *requester) request(ctx context.Context, name string) (interface{}, error) {
+	r.mu.Lock()
+	r.requested = append(r.requested, name)
+	r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(r.delays[name]):
+	}
+	if r.failures[name] {
+		return nil, errors.New("failed")
+	}
+
+	return name, nil
+}
+
+func (r *requester) providers() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]string, len(r.requested))
+	copy(res, r.requested)
+	return res
+}
+
+func TestHedged(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		history   map[string]time.Duration
+		delays    map[string]time.Duration
+		failures  map[string]bool
+		timeout   time.Duration
+		res       interface{}
+		requested []string
+		err       string
+	}{
+		{
+			name:      "FastestFirst",
+			history:   map[string]time.Duration{"a": 300 * time.Millisecond, "b": 100 * time.Millisecond, "c": 200 * time.Millisecond},
+			timeout:   time.Second,
+			res:       "b",
+			requested: []string{"b"},
+		},
+		{
+			name:      "HedgeTriggered",
+			history:   map[string]time.Duration{"a": 300 * time.Millisecond, "b": 100 * time.Millisecond, "c": 200 * time.Millisecond},
+			delays:    map[string]time.Duration{"b": time.Second},
+			timeout:   2 * time.Second,
+			res:       "c",
+			requested: []string{"b", "c"},
+		},
+		{
+			name:      "FailureTriesNext",
+			history:   map[string]time.Duration{"a": 300 * time.Millisecond, "b": 100 * time.Millisecond, "c": 200 * time.Millisecond},
+			failures:  map[string]bool{"b": true},
+			timeout:   time.Second,
+			res:       "c",
+			requested: []string{"b", "c"},
+		},
+		{
+			name:      "AllFailed",
+			failures:  map[string]bool{"a": true, "b": true, "c": true},
+			timeout:   time.Second,
+			requested: []string{"a", "b", "c"},
+			err:       "no successful responses",
+		},
+		{
+			name:      "Timeout",
+			delays:    map[string]time.Duration{"a": time.Second, "b": time.Second, "c": time.Second},
+			timeout:   250 * time.Millisecond,
+			requested: []string{"a", "b", "c"},
+			err:       "no response before timeout",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker, err := reliability.New(ctx,
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+				reliability.WithMinSamples(1),
+			)
+			require.NoError(t, err)
+			for name, latency := range test.history {
+				tracker.Success(name, latency)
+			}
+
+			r := &requester{
+				delays:   test.delays,
+				failures: test.failures,
+			}
+			ctx, cancel := context.WithTimeout(ctx, test.timeout)
+			defer cancel()
+			res, err := tracker.Hedged(ctx, []string{"a", "b", "c"}, 50*time.Millisecond, r.request)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+			require.Equal(t, test.requested, r.providers())
+		})
+	}
+}
+
+func TestHedgedCancelledNotRecorded(t *testing.T) {
+	ctx := context.Background()
+
+	tracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(zerolog.Disabled),
+		reliability.WithOperation("test"),
+		reliability.WithMinSamples(1),
+	)
+	require.NoError(t, err)
+	tracker.Success("a", 100*time.Millisecond)
+	tracker.Success("b", 200*time.Millisecond)
+
+	r := &requester{
+		delays: map[string]time.Duration{"a": time.Second},
+	}
+	res, err := tracker.Hedged(ctx, []string{"a", "b"}, 50*time.Millisecond, r.request)
+	require.NoError(t, err)
+	require.Equal(t, "b", res)
+
+	// Allow the cancelled request to finish.
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 1, tracker.Profile("a").Samples)
+	require.Equal(t, 2, tracker.Profile("b").Samples)
+}
@@ -0,0 +1,138 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reliability
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var latencies *prometheus.GaugeVec
+var errorRates *prometheus.GaugeVec
+var selectionRates *prometheus.GaugeVec
+var skipped *prometheus.CounterVec
+var hedgedRequests *prometheus.CounterVec
+var hedges *prometheus.CounterVec
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if latencies != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	latencies = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_reliability",
+		Name:      "latency_seconds",
+		Help:      "The recent latency of successful requests to the provider.",
+	}, []string{"operation", "provider", "quantile"})
+	if err := prometheus.Register(latencies); err != nil {
+		return err
+	}
+
+	errorRates = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_reliability",
+		Name:      "error_ratio",
+		Help:      "The recent ratio of failed requests to the provider.",
+	}, []string{"operation", "provider"})
+	if err := prometheus.Register(errorRates); err != nil {
+		return err
+	}
+
+	selectionRates = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_reliability",
+		Name:      "selection_ratio",
+		Help:      "The recent ratio of requests for which the provider's response was selected.",
+	}, []string{"operation", "provider"})
+	if err := prometheus.Register(selectionRates); err != nil {
+		return err
+	}
+
+	skipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_reliability",
+		Name:      "skipped_total",
+		Help:      "The number of requests for which the provider was skipped due to failing.",
+	}, []string{"operation", "provider"})
+	if err := prometheus.Register(skipped); err != nil {
+		return err
+	}
+
+	hedgedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_reliability",
+		Name:      "hedged_requests_total",
+		Help:      "The number of hedged requests, and whether hedging was triggered.",
+	}, []string{"operation", "hedged", "result"})
+	if err := prometheus.Register(hedgedRequests); err != nil {
+		return err
+	}
+
+	hedges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_reliability",
+		Name:      "hedges_total",
+		Help:      "The number of additional requests made due to the hedge delay being reached.",
+	}, []string{"operation"})
+	return prometheus.Register(hedges)
+}
+
+func monitorProfile(operation string, provider string, profile *Profile) {
+	if latencies == nil {
+		return
+	}
+	latencies.WithLabelValues(operation, provider, "0.5").Set(profile.Latency50.Seconds())
+	latencies.WithLabelValues(operation, provider, "0.95").Set(profile.Latency95.Seconds())
+	errorRates.WithLabelValues(operation, provider).Set(profile.ErrorRate)
+	selectionRates.WithLabelValues(operation, provider).Set(profile.SelectionRate)
+}
+
+func monitorSkipped(operation string, provider string) {
+	if skipped == nil {
+		return
+	}
+	skipped.WithLabelValues(operation, provider).Inc()
+}
+
+func monitorHedgedRequest(operation string, hedged bool, result string) {
+	if hedgedRequests == nil {
+		return
+	}
+	if hedged {
+		hedgedRequests.WithLabelValues(operation, "true", result).Inc()
+	} else {
+		hedgedRequests.WithLabelValues(operation, "false", result).Inc()
+	}
+}
+
+func monitorHedge(operation string) {
+	if hedges == nil {
+		return
+	}
+	hedges.WithLabelValues(operation).Inc()
+}
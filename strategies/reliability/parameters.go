@@ -0,0 +1,138 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reliability tracks the historical reliability of the providers
+// used by strategies, allowing them to avoid chronically failing providers,
+// size their timeouts and break ties between otherwise equal responses.
+package reliability
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/vouch/services/metrics"
+	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type parameters struct {
+	logLevel      zerolog.Level
+	monitor       metrics.Service
+	operation     string
+	window        int
+	minSamples    int
+	maxErrorRate  float64
+	retryInterval time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithLogLevel sets the log level for the module.
+func WithLogLevel(logLevel zerolog.Level) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.logLevel = logLevel
+	})
+}
+
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithOperation sets the operation for which provider reliability is tracked.
+func WithOperation(operation string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.operation = operation
+	})
+}
+
+// WithWindow sets the number of recent requests for each provider used to build its profile.
+func WithWindow(window int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.window = window
+	})
+}
+
+// WithMinSamples sets the number of requests required before a provider's profile is acted upon.
+func WithMinSamples(minSamples int) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.minSamples = minSamples
+	})
+}
+
+// WithMaxErrorRate sets the error rate at or above which a provider is considered to be failing.
+func WithMaxErrorRate(maxErrorRate float64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.maxErrorRate = maxErrorRate
+	})
+}
+
+// WithRetryInterval sets the interval after which a failing provider is tried again.
+func WithRetryInterval(interval time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.retryInterval = interval
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		logLevel:      zerolog.GlobalLevel(),
+		monitor:       nullmetrics.New(context.Background()),
+		window:        100,
+		minSamples:    10,
+		maxErrorRate:  0.8,
+		retryInterval: time.Minute,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.operation == "" {
+		return nil, errors.New("no operation specified")
+	}
+	if parameters.window <= 0 {
+		return nil, errors.New("window must be positive")
+	}
+	if parameters.minSamples <= 0 {
+		return nil, errors.New("min samples must be positive")
+	}
+	if parameters.minSamples > parameters.window {
+		return nil, errors.New("min samples cannot be larger than window")
+	}
+	if parameters.maxErrorRate <= 0 || parameters.maxErrorRate > 1 {
+		return nil, errors.New("max error rate must be greater than 0 and at most 1")
+	}
+	if parameters.retryInterval <= 0 {
+		return nil, errors.New("retry interval must be positive")
+	}
+
+	return &parameters, nil
+}
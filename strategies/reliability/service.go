@@ -0,0 +1,317 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reliability
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Profile is a summary of the recent behaviour of a provider.
+type Profile struct {
+	// Samples is the number of requests in the profile.
+	Samples int
+	// ErrorRate is the fraction of requests that failed.
+	ErrorRate float64
+	// SelectionRate is the fraction of requests for which the provider's response was selected.
+	SelectionRate float64
+	// Latency50 is the median latency of successful and slow requests.
+	Latency50 time.Duration
+	// Latency95 is the 95th percentile latency of successful and slow requests.
+	Latency95 time.Duration
+}
+
+type sample struct {
+	latency  time.Duration
+	success  bool
+	slow     bool
+	selected bool
+}
+
+type provider struct {
+	samples   []*sample
+	lastTried time.Time
+}
+
+// Tracker tracks the reliability of providers for a single operation.
+type Tracker struct {
+	log           zerolog.Logger
+	operation     string
+	window        int
+	minSamples    int
+	maxErrorRate  float64
+	retryInterval time.Duration
+
+	mu        sync.Mutex
+	providers map[string]*provider
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new reliability tracker.
+func New(ctx context.Context, params ...Parameter) (*Tracker, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("module", "reliability").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	return &Tracker{
+		log:           log.With().Str("operation", parameters.operation).Logger(),
+		operation:     parameters.operation,
+		window:        parameters.window,
+		minSamples:    parameters.minSamples,
+		maxErrorRate:  parameters.maxErrorRate,
+		retryInterval: parameters.retryInterval,
+		providers:     make(map[string]*provider),
+	}, nil
+}
+
+// Providers returns the names of the providers that should be used for a request.
+// Providers that are failing are left out, except when they have not been tried
+// for the retry interval so that they have a chance to show that they have recovered.
+// If all providers are failing then all are returned.
+func (t *Tracker) Providers(names []string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		p := t.provider(name)
+		if t.failing(p) && now.Sub(p.lastTried) < t.retryInterval {
+			t.log.Trace().Str("provider", name).Msg("Skipping failing provider")
+			monitorSkipped(t.operation, name)
+			continue
+		}
+		res = append(res, name)
+	}
+	if len(res) == 0 {
+		t.log.Debug().Msg("All providers failing; using all")
+		res = append(res, names...)
+	}
+
+	for _, name := range res {
+		t.provider(name).lastTried = now
+	}
+
+	return res
+}
+
+// Success records a successful request to a provider.
+func (t *Tracker) Success(name string, latency time.Duration) {
+	t.record(name, &sample{
+		latency: latency,
+		success: true,
+	})
+}
+
+// Failure records a failed request to a provider.
+// Failures as a result of the request being cancelled by the caller, for example
+// because the provider did not respond before the soft timeout, are recorded as
+// slow requests at the time they were cancelled rather than as errors.
+func (t *Tracker) Failure(ctx context.Context, name string, latency time.Duration) {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		t.record(name, &sample{
+			latency: latency,
+			slow:    true,
+		})
+		return
+	}
+	t.record(name, &sample{
+		latency: latency,
+	})
+}
+
+// Selected records that the response from a provider was selected.
+func (t *Tracker) Selected(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.provider(name)
+	for i := len(p.samples) - 1; i >= 0; i-- {
+		if p.samples[i].success {
+			p.samples[i].selected = true
+			break
+		}
+	}
+	monitorProfile(t.operation, name, t.profile(p))
+}
+
+// Profile returns the profile for a provider.
+func (t *Tracker) Profile(name string) *Profile {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.profile(t.provider(name))
+}
+
+// SoftTimeout returns the soft timeout for a request given its hard timeout.
+// This is the 95th percentile latency of successful and slow requests to all
+// providers, bounded to between a quarter and three quarters of the hard
+// timeout.  Slow requests are included so that providers cut off by the soft
+// timeout push it up rather than dropping out of the calculation.  If there is
+// insufficient history it is half of the hard timeout.
+func (t *Tracker) SoftTimeout(timeout time.Duration) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	latencies := make([]time.Duration, 0)
+	for _, p := range t.providers {
+		for _, s := range p.samples {
+			if s.success || s.slow {
+				latencies = append(latencies, s.latency)
+			}
+		}
+	}
+	if len(latencies) < t.minSamples {
+		return timeout / 2
+	}
+
+	softTimeout := percentile(latencies, 95)
+	if softTimeout < timeout/4 {
+		softTimeout = timeout / 4
+	}
+	if softTimeout > timeout*3/4 {
+		softTimeout = timeout * 3 / 4
+	}
+
+	return softTimeout
+}
+
+// Better returns true if the first provider has historically been more reliable
+// than the second.  Providers are compared by error rate, then by median latency,
+// and finally by selection rate.
+func (t *Tracker) Better(first string, second string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	firstProfile := t.profile(t.provider(first))
+	secondProfile := t.profile(t.provider(second))
+	if firstProfile.Samples < t.minSamples || secondProfile.Samples < t.minSamples {
+		// Not enough information to decide.
+		return false
+	}
+
+	if firstProfile.ErrorRate != secondProfile.ErrorRate {
+		return firstProfile.ErrorRate < secondProfile.ErrorRate
+	}
+	if firstProfile.Latency50 != secondProfile.Latency50 {
+		return firstProfile.Latency50 < secondProfile.Latency50
+	}
+	return firstProfile.SelectionRate > secondProfile.SelectionRate
+}
+
+func (t *Tracker) record(name string, s *sample) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.provider(name)
+	if len(p.samples) == t.window {
+		copy(p.samples, p.samples[1:])
+		p.samples[len(p.samples)-1] = s
+	} else {
+		p.samples = append(p.samples, s)
+	}
+	monitorProfile(t.operation, name, t.profile(p))
+}
+
+// provider returns the provider with the given name, creating it if required.
+// This assumes that the lock is held.
+func (t *Tracker) provider(name string) *provider {
+	p, exists := t.providers[name]
+	if !exists {
+		p = &provider{
+			samples: make([]*sample, 0, t.window),
+		}
+		t.providers[name] = p
+	}
+	return p
+}
+
+// failing returns true if the provider is considered to be failing.
+// This assumes that the lock is held.
+func (t *Tracker) failing(p *provider) bool {
+	if len(p.samples) < t.minSamples {
+		return false
+	}
+	return t.profile(p).ErrorRate >= t.maxErrorRate
+}
+
+// profile builds the profile for a provider.
+// This assumes that the lock is held.
+func (*Tracker) profile(p *provider) *Profile {
+	profile := &Profile{
+		Samples: len(p.samples),
+	}
+	if len(p.samples) == 0 {
+		return profile
+	}
+
+	failures := 0
+	selections := 0
+	latencies := make([]time.Duration, 0, len(p.samples))
+	for _, s := range p.samples {
+		if s.slow {
+			// The request was cut off, so its latency is at least this.
+			latencies = append(latencies, s.latency)
+			continue
+		}
+		if !s.success {
+			failures++
+			continue
+		}
+		if s.selected {
+			selections++
+		}
+		latencies = append(latencies, s.latency)
+	}
+	profile.ErrorRate = float64(failures) / float64(len(p.samples))
+	profile.SelectionRate = float64(selections) / float64(len(p.samples))
+	if len(latencies) > 0 {
+		profile.Latency50 = percentile(latencies, 50)
+		profile.Latency95 = percentile(latencies, 95)
+	}
+
+	return profile
+}
+
+// percentile returns the given percentile of the latencies, using the nearest-rank method.
+// Note that this sorts the supplied latencies.
+func percentile(latencies []time.Duration, pct int) time.Duration {
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	rank := (pct*len(latencies) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return latencies[rank-1]
+}
@@ -0,0 +1,316 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reliability_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []reliability.Parameter
+		err    string
+	}{
+		{
+			name: "MonitorNil",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithMonitor(nil),
+				reliability.WithOperation("test"),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
+		{
+			name: "OperationMissing",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no operation specified",
+		},
+		{
+			name: "WindowZero",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+				reliability.WithWindow(0),
+			},
+			err: "problem with parameters: window must be positive",
+		},
+		{
+			name: "MinSamplesZero",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+				reliability.WithMinSamples(0),
+			},
+			err: "problem with parameters: min samples must be positive",
+		},
+		{
+			name: "MinSamplesLargerThanWindow",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+				reliability.WithWindow(5),
+				reliability.WithMinSamples(10),
+			},
+			err: "problem with parameters: min samples cannot be larger than window",
+		},
+		{
+			name: "MaxErrorRateZero",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+				reliability.WithMaxErrorRate(0),
+			},
+			err: "problem with parameters: max error rate must be greater than 0 and at most 1",
+		},
+		{
+			name: "MaxErrorRateTooHigh",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+				reliability.WithMaxErrorRate(1.5),
+			},
+			err: "problem with parameters: max error rate must be greater than 0 and at most 1",
+		},
+		{
+			name: "RetryIntervalZero",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+				reliability.WithRetryInterval(0),
+			},
+			err: "problem with parameters: retry interval must be positive",
+		},
+		{
+			name: "Good",
+			params: []reliability.Parameter{
+				reliability.WithLogLevel(zerolog.Disabled),
+				reliability.WithOperation("test"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := reliability.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProfile(t *testing.T) {
+	ctx := context.Background()
+
+	tracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(zerolog.Disabled),
+		reliability.WithOperation("test"),
+		reliability.WithWindow(10),
+		reliability.WithMinSamples(5),
+	)
+	require.NoError(t, err)
+
+	// Unknown provider has an empty profile.
+	require.Equal(t, &reliability.Profile{}, tracker.Profile("unknown"))
+
+	for i := 1; i <= 8; i++ {
+		tracker.Success("good", time.Duration(i)*100*time.Millisecond)
+	}
+	tracker.Selected("good")
+	tracker.Failure(ctx, "good", time.Second)
+	tracker.Failure(ctx, "good", time.Second)
+
+	profile := tracker.Profile("good")
+	require.Equal(t, 10, profile.Samples)
+	require.InDelta(t, 0.2, profile.ErrorRate, 1e-9)
+	require.InDelta(t, 0.1, profile.SelectionRate, 1e-9)
+	require.Equal(t, 400*time.Millisecond, profile.Latency50)
+	require.Equal(t, 800*time.Millisecond, profile.Latency95)
+
+	// Further samples roll off the oldest.
+	tracker.Success("good", 100*time.Millisecond)
+	tracker.Success("good", 100*time.Millisecond)
+	profile = tracker.Profile("good")
+	require.Equal(t, 10, profile.Samples)
+	require.InDelta(t, 0.2, profile.ErrorRate, 1e-9)
+
+	// Failures due to cancellation are recorded as slow rather than as errors.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	tracker.Failure(cancelledCtx, "good", time.Second)
+	profile = tracker.Profile("good")
+	require.Equal(t, 10, profile.Samples)
+	require.InDelta(t, 0.2, profile.ErrorRate, 1e-9)
+	require.Equal(t, time.Second, profile.Latency95)
+}
+
+func TestProviders(t *testing.T) {
+	ctx := context.Background()
+
+	tracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(zerolog.Disabled),
+		reliability.WithOperation("test"),
+		reliability.WithWindow(10),
+		reliability.WithMinSamples(5),
+		reliability.WithMaxErrorRate(0.5),
+		reliability.WithRetryInterval(time.Hour),
+	)
+	require.NoError(t, err)
+
+	names := []string{"good", "bad"}
+	require.Equal(t, names, tracker.Providers(names))
+
+	for i := 0; i < 5; i++ {
+		tracker.Success("good", 100*time.Millisecond)
+		tracker.Failure(ctx, "bad", time.Second)
+	}
+
+	// Failing provider was tried recently so is skipped.
+	require.Equal(t, []string{"good"}, tracker.Providers(names))
+
+	// If all providers are failing all are used.
+	require.Equal(t, []string{"bad"}, tracker.Providers([]string{"bad"}))
+}
+
+func TestProvidersRetry(t *testing.T) {
+	ctx := context.Background()
+
+	tracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(zerolog.Disabled),
+		reliability.WithOperation("test"),
+		reliability.WithWindow(10),
+		reliability.WithMinSamples(5),
+		reliability.WithRetryInterval(100*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	names := []string{"good", "bad"}
+	for i := 0; i < 5; i++ {
+		tracker.Success("good", 100*time.Millisecond)
+		tracker.Failure(ctx, "bad", time.Second)
+	}
+	require.Equal(t, names, tracker.Providers(names))
+	require.Equal(t, []string{"good"}, tracker.Providers(names))
+
+	// Failing provider is retried after the retry interval.
+	time.Sleep(150 * time.Millisecond)
+	require.Equal(t, names, tracker.Providers(names))
+}
+
+func TestSoftTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	tracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(zerolog.Disabled),
+		reliability.WithOperation("test"),
+		reliability.WithMinSamples(5),
+	)
+	require.NoError(t, err)
+
+	// Insufficient history.
+	require.Equal(t, time.Second, tracker.SoftTimeout(2*time.Second))
+
+	for i := 0; i < 10; i++ {
+		tracker.Success("fast", 100*time.Millisecond)
+	}
+	// Bounded below.
+	require.Equal(t, 500*time.Millisecond, tracker.SoftTimeout(2*time.Second))
+	require.Equal(t, 100*time.Millisecond, tracker.SoftTimeout(400*time.Millisecond))
+
+	for i := 0; i < 10; i++ {
+		tracker.Success("slow", 800*time.Millisecond)
+	}
+	require.Equal(t, 800*time.Millisecond, tracker.SoftTimeout(2*time.Second))
+	// Bounded above.
+	require.Equal(t, 750*time.Millisecond, tracker.SoftTimeout(time.Second))
+}
+
+func TestSoftTimeoutSlow(t *testing.T) {
+	ctx := context.Background()
+
+	tracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(zerolog.Disabled),
+		reliability.WithOperation("test"),
+		reliability.WithMinSamples(5),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		tracker.Success("fast", 100*time.Millisecond)
+	}
+	require.Equal(t, 500*time.Millisecond, tracker.SoftTimeout(2*time.Second))
+
+	// Requests cut off at the soft timeout raise it.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	for i := 0; i < 10; i++ {
+		tracker.Failure(cancelledCtx, "slow", 600*time.Millisecond)
+	}
+	require.Equal(t, 600*time.Millisecond, tracker.SoftTimeout(2*time.Second))
+
+	// The slow provider is not considered failing, but is ranked behind the fast provider.
+	require.Equal(t, []string{"fast", "slow"}, tracker.Providers([]string{"fast", "slow"}))
+	require.True(t, tracker.Better("fast", "slow"))
+}
+
+func TestBetter(t *testing.T) {
+	ctx := context.Background()
+
+	tracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(zerolog.Disabled),
+		reliability.WithOperation("test"),
+		reliability.WithMinSamples(5),
+	)
+	require.NoError(t, err)
+
+	// Insufficient history.
+	require.False(t, tracker.Better("a", "b"))
+	require.False(t, tracker.Better("b", "a"))
+
+	for i := 0; i < 10; i++ {
+		tracker.Success("a", 100*time.Millisecond)
+		tracker.Success("b", 200*time.Millisecond)
+		tracker.Success("c", 100*time.Millisecond)
+	}
+	tracker.Failure(ctx, "c", time.Second)
+
+	// Lower latency.
+	require.True(t, tracker.Better("a", "b"))
+	require.False(t, tracker.Better("b", "a"))
+
+	// Lower error rate.
+	require.True(t, tracker.Better("b", "c"))
+	require.False(t, tracker.Better("c", "b"))
+
+	// Higher selection rate.
+	for i := 0; i < 10; i++ {
+		tracker.Success("d", 100*time.Millisecond)
+	}
+	tracker.Selected("d")
+	require.True(t, tracker.Better("d", "a"))
+	require.False(t, tracker.Better("a", "d"))
+}
@@ -21,8 +21,21 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/vouch/services/capabilities"
+	nullcapabilities "github.com/attestantio/vouch/services/capabilities/null"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	nullconsistency "github.com/attestantio/vouch/services/consistency/null"
+	"github.com/attestantio/vouch/services/errorclassifier"
+	nullerrorclassifier "github.com/attestantio/vouch/services/errorclassifier/null"
 	"github.com/attestantio/vouch/services/metrics"
 	nullmetrics "github.com/attestantio/vouch/services/metrics/null"
+	"github.com/attestantio/vouch/services/nodehealth"
+	nullnodehealth "github.com/attestantio/vouch/services/nodehealth/null"
+	"github.com/attestantio/vouch/services/recorder"
+	nullrecorder "github.com/attestantio/vouch/services/recorder/null"
+	"github.com/attestantio/vouch/services/scorer"
+	nullscorer "github.com/attestantio/vouch/services/scorer/null"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -30,9 +43,18 @@ import (
 type parameters struct {
 	logLevel                           zerolog.Level
 	clientMonitor                      metrics.ClientMonitor
+	monitor                            metrics.Service
+	recorder                           recorder.Service
+	scorer                             scorer.Service
+	classifier                         errorclassifier.Service
 	processConcurrency                 int64
 	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
 	timeout                            time.Duration
+	nodeHealth                         nodehealth.Service
+	consistency                        consistency.Service
+	capabilities                       capabilities.Service
+	deadline                           time.Duration
+	chainTime                          chaintime.Service
 }
 
 // Parameter is the interface for service parameters.
@@ -60,6 +82,29 @@ func WithClientMonitor(monitor metrics.ClientMonitor) Parameter {
 	})
 }
 
+// WithMonitor sets the monitor for the module.
+func WithMonitor(monitor metrics.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.monitor = monitor
+	})
+}
+
+// WithRecorder sets the recorder for strategy decisions.
+// If this is not set decisions are not recorded.
+func WithRecorder(recorder recorder.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.recorder = recorder
+	})
+}
+
+// WithScorer sets the external scorer.
+// If this is not set only the built-in scores are used.
+func WithScorer(scorer scorer.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.scorer = scorer
+	})
+}
+
 // WithProcessConcurrency sets the concurrency for the service.
 func WithProcessConcurrency(concurrency int64) Parameter {
 	return parameterFunc(func(p *parameters) {
@@ -81,11 +126,61 @@ func WithTimeout(timeout time.Duration) Parameter {
 	})
 }
 
+// WithDeadline sets the deadline for requests, as an offset from the start of the slot.
+// If set, the timeouts for a request are reduced as required to complete by the deadline.
+func WithDeadline(deadline time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.deadline = deadline
+	})
+}
+
+// WithChainTime sets the chain time service.
+func WithChainTime(chainTime chaintime.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.chainTime = chainTime
+	})
+}
+
+// WithClassifier sets the classifier for errors returned by beacon nodes.
+func WithClassifier(classifier errorclassifier.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.classifier = classifier
+	})
+}
+
+// WithNodeHealth sets the node health service, used to avoid unhealthy beacon nodes.
+func WithNodeHealth(service nodehealth.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.nodeHealth = service
+	})
+}
+
+// WithConsistency sets the consistency service, used to avoid beacon nodes on the wrong network.
+func WithConsistency(service consistency.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.consistency = service
+	})
+}
+
+// WithCapabilities sets the capabilities service, used to avoid beacon nodes that do not support sync committee contributions.
+func WithCapabilities(service capabilities.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.capabilities = service
+	})
+}
+
 // parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
 func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	parameters := parameters{
 		logLevel:           zerolog.GlobalLevel(),
 		clientMonitor:      nullmetrics.New(context.Background()),
+		monitor:            nullmetrics.New(context.Background()),
+		scorer:             nullscorer.New(context.Background()),
+		recorder:           nullrecorder.New(context.Background()),
+		classifier:         nullerrorclassifier.New(context.Background()),
+		capabilities:       nullcapabilities.New(context.Background()),
+		nodeHealth:         nullnodehealth.New(context.Background()),
+		consistency:        nullconsistency.New(context.Background()),
 		processConcurrency: int64(runtime.GOMAXPROCS(-1)),
 	}
 	for _, p := range params {
@@ -100,6 +195,27 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 	if parameters.clientMonitor == nil {
 		return nil, errors.New("no client monitor specified")
 	}
+	if parameters.monitor == nil {
+		return nil, errors.New("no monitor specified")
+	}
+	if parameters.scorer == nil {
+		return nil, errors.New("no scorer specified")
+	}
+	if parameters.recorder == nil {
+		return nil, errors.New("no recorder specified")
+	}
+	if parameters.classifier == nil {
+		return nil, errors.New("no classifier specified")
+	}
+	if parameters.capabilities == nil {
+		return nil, errors.New("no capabilities service specified")
+	}
+	if parameters.nodeHealth == nil {
+		return nil, errors.New("no node health service specified")
+	}
+	if parameters.consistency == nil {
+		return nil, errors.New("no consistency service specified")
+	}
 	if parameters.processConcurrency == 0 {
 		return nil, errors.New("no process concurrency specified")
 	}
@@ -107,5 +223,12 @@ func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
 		return nil, errors.New("no sync committee contribution providers specified")
 	}
 
+	if parameters.deadline < 0 {
+		return nil, errors.New("deadline cannot be negative")
+	}
+	if parameters.deadline != 0 && parameters.chainTime == nil {
+		return nil, errors.New("no chain time service specified for deadline")
+	}
+
 	return &parameters, nil
 }
@@ -15,10 +15,21 @@ package best
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/errorclassifier"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -26,17 +37,27 @@ import (
 
 // Service is the provider for attestation data.
 type Service struct {
-	clientMonitor                      metrics.ClientMonitor
-	processConcurrency                 int64
-	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
-	timeout                            time.Duration
+	clientMonitor                          metrics.ClientMonitor
+	processConcurrency                     int64
+	syncCommitteeContributionProviders     map[string]eth2client.SyncCommitteeContributionProvider
+	syncCommitteeContributionProviderNames []string
+	reliability                            *reliability.Tracker
+	recorder                               recorder.Service
+	scorer                                 scorer.Service
+	classifier                             errorclassifier.Service
+	timeout                                time.Duration
+	nodeHealth                             nodehealth.Service
+	consistency                            consistency.Service
+	capabilities                           capabilities.Service
+	deadline                               time.Duration
+	chainTime                              chaintime.Service
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -48,13 +69,47 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	reliabilityTracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(parameters.logLevel),
+		reliability.WithMonitor(parameters.monitor),
+		reliability.WithOperation("sync committee contribution"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reliability tracker")
+	}
+
+	syncCommitteeContributionProviderNames := make([]string, 0, len(parameters.syncCommitteeContributionProviders))
+	for name := range parameters.syncCommitteeContributionProviders {
+		syncCommitteeContributionProviderNames = append(syncCommitteeContributionProviderNames, name)
+	}
+	sort.Strings(syncCommitteeContributionProviderNames)
+
 	s := &Service{
-		timeout:                            parameters.timeout,
-		clientMonitor:                      parameters.clientMonitor,
-		processConcurrency:                 parameters.processConcurrency,
-		syncCommitteeContributionProviders: parameters.syncCommitteeContributionProviders,
+		timeout:                                parameters.timeout,
+		nodeHealth:                             parameters.nodeHealth,
+		consistency:                            parameters.consistency,
+		capabilities:                           parameters.capabilities,
+		deadline:                               parameters.deadline,
+		chainTime:                              parameters.chainTime,
+		clientMonitor:                          parameters.clientMonitor,
+		processConcurrency:                     parameters.processConcurrency,
+		syncCommitteeContributionProviders:     parameters.syncCommitteeContributionProviders,
+		syncCommitteeContributionProviderNames: syncCommitteeContributionProviderNames,
+		reliability:                            reliabilityTracker,
+		recorder:                               parameters.recorder,
+		scorer:                                 parameters.scorer,
+		classifier:                             parameters.classifier,
 	}
 	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
 
 	return s, nil
 }
+
+// timeouts returns the hard and soft timeouts for a request for the given slot.
+func (s *Service) timeouts(slot phase0.Slot) (time.Duration, time.Duration, error) {
+	softTimeout := s.reliability.SoftTimeout(s.timeout)
+	if s.deadline == 0 {
+		return s.timeout, softTimeout, nil
+	}
+	return util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, softTimeout)
+}
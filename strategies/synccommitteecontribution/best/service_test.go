@@ -62,6 +62,16 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no client monitor specified",
 		},
+		{
+			name: "MonitorMissing",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithMonitor(nil),
+				best.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
+			},
+			err: "problem with parameters: no monitor specified",
+		},
 		{
 			name: "SyncCommitteeContributionProvidersNil",
 			params: []best.Parameter{
@@ -90,6 +100,26 @@ func TestService(t *testing.T) {
 			},
 			err: "problem with parameters: no sync committee contribution providers specified",
 		},
+		{
+			name: "DeadlineNegative",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
+				best.WithDeadline(-time.Second),
+			},
+			err: "problem with parameters: deadline cannot be negative",
+		},
+		{
+			name: "DeadlineWithoutChainTime",
+			params: []best.Parameter{
+				best.WithLogLevel(zerolog.Disabled),
+				best.WithTimeout(2 * time.Second),
+				best.WithSyncCommitteeContributionProviders(syncCommitteeContributionProviders),
+				best.WithDeadline(4 * time.Second),
+			},
+			err: "problem with parameters: no chain time service specified for deadline",
+		},
 		{
 			name: "Good",
 			params: []best.Parameter{
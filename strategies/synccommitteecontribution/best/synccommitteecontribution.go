@@ -20,6 +20,9 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/recorder"
+	"github.com/attestantio/vouch/services/scorer"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 )
@@ -27,6 +30,7 @@ import (
 type syncCommitteeContributionResponse struct {
 	provider     string
 	contribution *altair.SyncCommitteeContribution
+	latency      time.Duration
 	score        float64
 }
 
@@ -38,61 +42,93 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slo
 	// We have two timeouts: a soft timeout and a hard timeout.
 	// At the soft timeout, we return if we have any responses so far.
 	// At the hard timeout, we return unconditionally.
-	// The soft timeout is half the duration of the hard timeout.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	softCtx, softCancel := context.WithTimeout(ctx, s.timeout/2)
+	// The soft timeout is based on the historical latency of the providers.
+	// If a deadline is set both timeouts are reduced as required to complete by the deadline.
+	timeout, softTimeout, err := s.timeouts(slot)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, softTimeout)
+
+	// Avoid providers that have been failing.
+	providers := s.reliability.Providers(s.capabilities.Filter(capabilities.CapabilitySyncCommitteeContributions, s.nodeHealth.Filter(s.consistency.Filter(s.syncCommitteeContributionProviderNames))))
+	decision := s.recorder.NewDecision("sync committee contribution", slot, providers)
 
-	respCh := make(chan *syncCommitteeContributionResponse, len(s.syncCommitteeContributionProviders))
-	errCh := make(chan error, len(s.syncCommitteeContributionProviders))
+	respCh := make(chan *syncCommitteeContributionResponse, len(providers))
+	errCh := make(chan error, len(providers))
 	// Kick off the requests.
-	for name, provider := range s.syncCommitteeContributionProviders {
-		go s.syncCommitteeContribution(ctx, started, name, provider, respCh, errCh, slot, subcommitteeIndex, beaconBlockRoot)
+	for _, name := range providers {
+		provider := s.syncCommitteeContributionProviders[name]
+		go s.syncCommitteeContribution(ctx, started, decision, name, provider, respCh, errCh, slot, subcommitteeIndex, beaconBlockRoot)
 	}
 
 	// Wait for all responses (or context done).
 	responded := 0
 	errored := 0
 	timedOut := 0
-	bestScore := float64(0)
-	var bestSyncCommitteeContribution *altair.SyncCommitteeContribution
-	bestProvider := ""
+	responses := make([]*syncCommitteeContributionResponse, 0, len(providers))
 
-	for responded+errored+timedOut != len(s.syncCommitteeContributionProviders) {
+	for responded+errored+timedOut != len(providers) {
 		select {
 		case <-softCtx.Done():
 			// If we have any responses at this point we consider the non-responders timed out.
 			if responded > 0 {
-				timedOut = len(s.syncCommitteeContributionProviders) - responded - errored
+				timedOut = len(providers) - responded - errored
 				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Msg("Soft timeout reached with responses")
 			} else {
 				log.Debug().Dur("elapsed", time.Since(started)).Int("errored", errored).Msg("Soft timeout reached with no responses")
 			}
 		case <-ctx.Done():
 			// Anyone not responded by now is considered errored.
-			errored = len(s.syncCommitteeContributionProviders) - responded
+			errored = len(providers) - responded
 			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Hard timeout reached")
 		case err := <-errCh:
 			errored++
 			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
 		case resp := <-respCh:
 			responded++
-			if bestSyncCommitteeContribution == nil || resp.score > bestScore {
-				bestSyncCommitteeContribution = resp.contribution
-				bestScore = resp.score
-				bestProvider = resp.provider
-			}
+			responses = append(responses, resp)
 			log.Trace().Dur("elapsed", time.Since(started)).Msg("Response")
 		}
 	}
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	// Score the responses together, so that they are all compared using scores on the same scale.
+	candidates := make([]*scorer.Candidate, len(responses))
+	for i, resp := range responses {
+		candidates[i] = &scorer.Candidate{
+			Provider:     resp.provider,
+			Data:         resp.contribution,
+			BuiltinScore: resp.score,
+		}
+	}
+	scores := s.scorer.Score(ctx, "sync committee contribution", slot, candidates)
 	softCancel()
 	cancel()
-	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	bestScore := float64(0)
+	var bestSyncCommitteeContribution *altair.SyncCommitteeContribution
+	bestProvider := ""
+	for i, resp := range responses {
+		decision.Response(resp.provider, resp.latency, scores[i], resp.contribution)
+		if bestSyncCommitteeContribution == nil ||
+			scores[i] > bestScore ||
+			(scores[i] == bestScore && s.reliability.Better(resp.provider, bestProvider)) {
+			bestSyncCommitteeContribution = resp.contribution
+			bestScore = scores[i]
+			bestProvider = resp.provider
+		}
+	}
+
+	s.recorder.Record(decision, bestProvider)
 
 	if bestSyncCommitteeContribution == nil {
 		return nil, errors.New("no sync committee contribution received")
 	}
 	log.Trace().Stringer("sync_committee_contribution", bestSyncCommitteeContribution).Float64("score", bestScore).Msg("Selected best sync committee contribution")
 	if bestProvider != "" {
+		s.reliability.Selected(bestProvider)
 		s.clientMonitor.StrategyOperation("best", bestProvider, "sync committee contribution", time.Since(started))
 	}
 
@@ -101,6 +137,7 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slo
 
 func (s *Service) syncCommitteeContribution(ctx context.Context,
 	started time.Time,
+	decision recorder.Decision,
 	name string,
 	provider eth2client.SyncCommitteeContributionProvider,
 	respCh chan *syncCommitteeContributionResponse,
@@ -112,18 +149,24 @@ func (s *Service) syncCommitteeContribution(ctx context.Context,
 	contribution, err := provider.SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
 	s.clientMonitor.ClientOperation(name, "sync committee contribution", err == nil, time.Since(started))
 	if err != nil {
+		s.classifier.Classify(ctx, provider, "sync committee contribution", err)
+		decision.Failure(name, time.Since(started), err)
+		s.reliability.Failure(ctx, name, time.Since(started))
 		errCh <- err
 		return
 	}
 	log.Trace().Str("provider", name).Dur("elapsed", time.Since(started)).Msg("Obtained sync committee contribution")
 	if contribution == nil {
+		decision.Failure(name, time.Since(started), errors.New("empty sync committee contribution"))
+		s.reliability.Failure(ctx, name, time.Since(started))
 		return
 	}
+	s.reliability.Success(name, time.Since(started))
 
-	score := s.scoreSyncCommitteeContribution(ctx, name, contribution)
 	respCh <- &syncCommitteeContributionResponse{
 		provider:     name,
 		contribution: contribution,
-		score:        score,
+		latency:      time.Since(started),
+		score:        s.scoreSyncCommitteeContribution(ctx, name, contribution),
 	}
 }
@@ -15,10 +15,18 @@ package first
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
 	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/strategies/reliability"
+	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	zerologger "github.com/rs/zerolog/log"
@@ -26,16 +34,24 @@ import (
 
 // Service is the provider for sync committee contributions.
 type Service struct {
-	clientMonitor                      metrics.ClientMonitor
-	syncCommitteeContributionProviders map[string]eth2client.SyncCommitteeContributionProvider
-	timeout                            time.Duration
+	clientMonitor                          metrics.ClientMonitor
+	syncCommitteeContributionProviders     map[string]eth2client.SyncCommitteeContributionProvider
+	timeout                                time.Duration
+	chainTime                              chaintime.Service
+	deadline                               time.Duration
+	nodeHealth                             nodehealth.Service
+	consistency                            consistency.Service
+	capabilities                           capabilities.Service
+	hedgeDelay                             time.Duration
+	syncCommitteeContributionProviderNames []string
+	reliability                            *reliability.Tracker
 }
 
 // module-wide log.
 var log zerolog.Logger
 
 // New creates a new attestation data strategy.
-func New(_ context.Context, params ...Parameter) (*Service, error) {
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	parameters, err := parseAndCheckParameters(params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with parameters")
@@ -47,11 +63,43 @@ func New(_ context.Context, params ...Parameter) (*Service, error) {
 		log = log.Level(parameters.logLevel)
 	}
 
+	reliabilityTracker, err := reliability.New(ctx,
+		reliability.WithLogLevel(parameters.logLevel),
+		reliability.WithMonitor(parameters.monitor),
+		reliability.WithOperation("sync committee contribution"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reliability tracker")
+	}
+
+	syncCommitteeContributionProviderNames := make([]string, 0, len(parameters.syncCommitteeContributionProviders))
+	for name := range parameters.syncCommitteeContributionProviders {
+		syncCommitteeContributionProviderNames = append(syncCommitteeContributionProviderNames, name)
+	}
+	sort.Strings(syncCommitteeContributionProviderNames)
+
 	s := &Service{
-		syncCommitteeContributionProviders: parameters.syncCommitteeContributionProviders,
-		timeout:                            parameters.timeout,
-		clientMonitor:                      parameters.clientMonitor,
+		hedgeDelay:                             parameters.hedgeDelay,
+		syncCommitteeContributionProviderNames: syncCommitteeContributionProviderNames,
+		reliability:                            reliabilityTracker,
+		syncCommitteeContributionProviders:     parameters.syncCommitteeContributionProviders,
+		timeout:                                parameters.timeout,
+		chainTime:                              parameters.chainTime,
+		deadline:                               parameters.deadline,
+		nodeHealth:                             parameters.nodeHealth,
+		consistency:                            parameters.consistency,
+		capabilities:                           parameters.capabilities,
+		clientMonitor:                          parameters.clientMonitor,
 	}
 
 	return s, nil
 }
+
+// requestTimeout returns the timeout for a request for the given slot.
+func (s *Service) requestTimeout(slot phase0.Slot) (time.Duration, error) {
+	if s.deadline == 0 {
+		return s.timeout, nil
+	}
+	timeout, _, err := util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, s.timeout)
+	return timeout, err
+}
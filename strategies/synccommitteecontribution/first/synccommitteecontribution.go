@@ -21,6 +21,7 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/capabilities"
 	"github.com/attestantio/vouch/util"
 	"github.com/pkg/errors"
 )
@@ -30,11 +31,22 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slo
 	started := time.Now()
 	log := util.LogWithID(ctx, log, "strategy_id")
 
+	if s.hedgeDelay > 0 {
+		return s.hedgedSyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
+	}
+
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
 	// We create a cancelable context with a timeout.  When a provider responds we cancel the context to cancel the other requests.
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 
 	respCh := make(chan *altair.SyncCommitteeContribution, 1)
-	for name, provider := range s.syncCommitteeContributionProviders {
+	for _, name := range s.capabilities.Filter(capabilities.CapabilitySyncCommitteeContributions, s.nodeHealth.Filter(s.consistency.Filter(s.syncCommitteeContributionProviderNames))) {
+		provider := s.syncCommitteeContributionProviders[name]
 		go func(ctx context.Context,
 			name string,
 			provider eth2client.SyncCommitteeContributionProvider,
@@ -67,3 +79,43 @@ func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slo
 		return aggregate, nil
 	}
 }
+
+// hedgedSyncCommitteeContribution obtains the sync committee contribution from providers in order of their historical latency,
+// only requesting from an additional provider if no response has been received within the hedge delay.
+func (s *Service) hedgedSyncCommitteeContribution(ctx context.Context, slot phase0.Slot, subcommitteeIndex uint64, beaconBlockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	log := util.LogWithID(ctx, log, "strategy_id")
+
+	// If a deadline is set the timeout is reduced as required to complete by the deadline.
+	timeout, err := s.requestTimeout(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res, err := s.reliability.Hedged(ctx, s.capabilities.Filter(capabilities.CapabilitySyncCommitteeContributions, s.nodeHealth.Filter(s.consistency.Filter(s.syncCommitteeContributionProviderNames))), s.hedgeDelay, func(ctx context.Context, name string) (interface{}, error) {
+		log := log.With().Str("provider", name).Uint64("slot", uint64(slot)).Uint64("subcommittee_index", subcommitteeIndex).Str("beacon_block_root", fmt.Sprintf("%#x", beaconBlockRoot)).Logger()
+
+		started := time.Now()
+		contribution, err := s.syncCommitteeContributionProviders[name].SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
+		s.clientMonitor.ClientOperation(name, "sync committee contribution", err == nil, time.Since(started))
+		if err != nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Err(err).Msg("Failed to obtain sync committee contribution")
+			return nil, err
+		}
+		if contribution == nil {
+			log.Warn().Dur("elapsed", time.Since(started)).Msg("Returned empty sync committee contribution")
+			return nil, errors.New("empty sync committee contribution")
+		}
+		log.Trace().Dur("elapsed", time.Since(started)).Msg("Obtained sync committee contribution")
+
+		return contribution, nil
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to obtain sync committee contribution")
+		return nil, errors.Wrap(err, "failed to obtain sync committee contribution")
+	}
+
+	return res.(*altair.SyncCommitteeContribution), nil
+}
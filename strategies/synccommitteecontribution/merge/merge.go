@@ -0,0 +1,168 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// mergeContributions merges the supplied contributions in to a single contribution.
+// Each contribution's signature is verified against the public keys of its
+// participants before it is used, and contributions that fail verification
+// are ignored.  The verified contribution with the most participants is used
+// as the base, and the remaining verified contributions are added greedily in
+// order of participation if their participants do not overlap with those
+// already present.  If there is only a single contribution, the subcommittee
+// public keys are not available, or no contribution verifies, the contribution
+// with the most participants is returned unaltered.
+// It returns the merged contribution and the providers of its constituent contributions.
+func mergeContributions(responses []*syncCommitteeContributionResponse,
+	pubKeys []e2types.PublicKey,
+	signingRoot phase0.Root,
+) (
+	*altair.SyncCommitteeContribution,
+	[]string,
+) {
+	// Order by participation, falling back to provider name for consistency.
+	sort.Slice(responses, func(i, j int) bool {
+		iCount := responses[i].contribution.AggregationBits.Count()
+		jCount := responses[j].contribution.AggregationBits.Count()
+		if iCount != jCount {
+			return iCount > jCount
+		}
+		return responses[i].provider < responses[j].provider
+	})
+
+	if len(responses) == 1 || pubKeys == nil {
+		monitorMerge(0)
+		return responses[0].contribution, []string{responses[0].provider}
+	}
+
+	var base *altair.SyncCommitteeContribution
+	var bits bitfield.Bitvector128
+	var sigs []e2types.Signature
+	var providers []string
+	for _, response := range responses {
+		sig, err := verifiedSignature(response.contribution, pubKeys, signingRoot)
+		if err != nil {
+			log.Debug().Str("provider", response.provider).Err(err).Msg("Contribution failed verification; ignoring")
+			continue
+		}
+		if base == nil {
+			base = response.contribution
+			bits = make(bitfield.Bitvector128, len(base.AggregationBits))
+			copy(bits, base.AggregationBits)
+			sigs = []e2types.Signature{sig}
+			providers = []string{response.provider}
+			continue
+		}
+		bits, err = addContribution(bits, response.contribution)
+		if err != nil {
+			log.Trace().Str("provider", response.provider).Err(err).Msg("Not merging contribution")
+			continue
+		}
+		sigs = append(sigs, sig)
+		providers = append(providers, response.provider)
+	}
+
+	if base == nil {
+		log.Debug().Msg("No contributions verified; not merging")
+		monitorMerge(0)
+		return responses[0].contribution, []string{responses[0].provider}
+	}
+
+	extraParticipants := bits.Count() - base.AggregationBits.Count()
+	monitorMerge(extraParticipants)
+	if len(providers) == 1 {
+		return base, providers
+	}
+
+	log.Trace().
+		Uint64("base_participants", base.AggregationBits.Count()).
+		Uint64("merged_participants", bits.Count()).
+		Msg("Merged contributions")
+
+	merged := &altair.SyncCommitteeContribution{
+		Slot:              base.Slot,
+		BeaconBlockRoot:   base.BeaconBlockRoot,
+		SubcommitteeIndex: base.SubcommitteeIndex,
+		AggregationBits:   bits,
+	}
+	copy(merged.Signature[:], e2types.AggregateSignatures(sigs).Marshal())
+
+	return merged, providers
+}
+
+// verifiedSignature returns the signature of the contribution if it is valid for
+// the public keys of its participants over the signing root.
+func verifiedSignature(contribution *altair.SyncCommitteeContribution,
+	pubKeys []e2types.PublicKey,
+	signingRoot phase0.Root,
+) (
+	e2types.Signature,
+	error,
+) {
+	if contribution.AggregationBits.Len() != uint64(len(pubKeys)) {
+		return nil, errors.New("aggregation bits length does not match subcommittee size")
+	}
+	participants := make([]e2types.PublicKey, 0, contribution.AggregationBits.Count())
+	for i := range pubKeys {
+		if contribution.AggregationBits.BitAt(uint64(i)) {
+			participants = append(participants, pubKeys[i])
+		}
+	}
+	if len(participants) == 0 {
+		return nil, errors.New("no participants")
+	}
+	sig, err := signature(contribution.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	if !sig.VerifyAggregateCommon(signingRoot[:], participants) {
+		return nil, errors.New("signature does not verify")
+	}
+
+	return sig, nil
+}
+
+// addContribution adds the participants of a contribution to the existing bits, if possible.
+func addContribution(bits bitfield.Bitvector128, contribution *altair.SyncCommitteeContribution) (bitfield.Bitvector128, error) {
+	overlaps, err := bits.Overlaps(contribution.AggregationBits)
+	if err != nil {
+		return bits, errors.Wrap(err, "failed to check for overlap")
+	}
+	if overlaps {
+		return bits, errors.New("participants overlap")
+	}
+	merged, err := bits.Or(contribution.AggregationBits)
+	if err != nil {
+		return bits, errors.Wrap(err, "failed to merge aggregation bits")
+	}
+
+	return merged, nil
+}
+
+// signature decodes a BLS signature.
+func signature(sig phase0.BLSSignature) (e2types.Signature, error) {
+	// Copy the signature to avoid passing memory that contains Go pointers to the BLS library.
+	data := make([]byte, len(sig))
+	copy(data, sig[:])
+	return e2types.BLSSignatureFromBytes(data)
+}
@@ -0,0 +1,182 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// signedContribution creates a contribution signed by the keys at the given indices.
+func signedContribution(t *testing.T,
+	root phase0.Root,
+	keys []*e2types.BLSPrivateKey,
+	indices []uint64,
+) *altair.SyncCommitteeContribution {
+	t.Helper()
+
+	bits := bitfield.NewBitvector128()
+	sigs := make([]e2types.Signature, 0, len(indices))
+	for _, index := range indices {
+		bits.SetBitAt(index, true)
+		sigs = append(sigs, keys[index].Sign(root[:]))
+	}
+	contribution := &altair.SyncCommitteeContribution{
+		Slot:              1,
+		BeaconBlockRoot:   root,
+		SubcommitteeIndex: 2,
+		AggregationBits:   bits,
+	}
+	copy(contribution.Signature[:], e2types.AggregateSignatures(sigs).Marshal())
+
+	return contribution
+}
+
+func TestMergeContributions(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	keys := make([]*e2types.BLSPrivateKey, 128)
+	for i := range keys {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+
+	root := phase0.Root{0x01}
+
+	badSig := signedContribution(t, root, keys, []uint64{6, 7})
+	badSig.Signature = phase0.BLSSignature{0x01}
+	// Claims participants 2 and 3 but is signed by 4 and 5.
+	wrongSigners := signedContribution(t, root, keys, []uint64{4, 5})
+	wrongSigners.AggregationBits = bitfield.NewBitvector128()
+	wrongSigners.AggregationBits.SetBitAt(2, true)
+	wrongSigners.AggregationBits.SetBitAt(3, true)
+
+	subcommittee := make([]e2types.PublicKey, len(keys))
+	for i := range keys {
+		subcommittee[i] = keys[i].PublicKey()
+	}
+
+	tests := []struct {
+		name         string
+		responses    []*syncCommitteeContributionResponse
+		noCommittee  bool
+		participants []uint64
+		unaltered    *altair.SyncCommitteeContribution
+		providers    []string
+	}{
+		{
+			name: "Single",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: signedContribution(t, root, keys, []uint64{0, 1})},
+			},
+			participants: []uint64{0, 1},
+			providers:    []string{"a"},
+		},
+		{
+			name: "Disjoint",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: signedContribution(t, root, keys, []uint64{0, 1})},
+				{provider: "b", contribution: signedContribution(t, root, keys, []uint64{2, 3, 4})},
+			},
+			participants: []uint64{0, 1, 2, 3, 4},
+			providers:    []string{"b", "a"},
+		},
+		{
+			name: "Overlapping",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: signedContribution(t, root, keys, []uint64{0, 1, 2})},
+				{provider: "b", contribution: signedContribution(t, root, keys, []uint64{2, 3})},
+				{provider: "c", contribution: signedContribution(t, root, keys, []uint64{4, 5})},
+			},
+			participants: []uint64{0, 1, 2, 4, 5},
+			providers:    []string{"a", "c"},
+		},
+		{
+			name: "InvalidSignature",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: signedContribution(t, root, keys, []uint64{0, 1, 2})},
+				{provider: "b", contribution: badSig},
+			},
+			participants: []uint64{0, 1, 2},
+			providers:    []string{"a"},
+		},
+		{
+			name: "InvalidBaseSignature",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: signedContribution(t, root, keys, []uint64{0})},
+				{provider: "b", contribution: badSig},
+			},
+			participants: []uint64{0},
+			providers:    []string{"a"},
+		},
+		{
+			name: "WrongSigners",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: signedContribution(t, root, keys, []uint64{0, 1})},
+				{provider: "b", contribution: wrongSigners},
+			},
+			participants: []uint64{0, 1},
+			providers:    []string{"a"},
+		},
+		{
+			name: "NoneVerified",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: wrongSigners},
+				{provider: "b", contribution: badSig},
+			},
+			unaltered: wrongSigners,
+			providers: []string{"a"},
+		},
+		{
+			name: "NoSubcommittee",
+			responses: []*syncCommitteeContributionResponse{
+				{provider: "a", contribution: signedContribution(t, root, keys, []uint64{0})},
+				{provider: "b", contribution: badSig},
+			},
+			noCommittee: true,
+			unaltered:   badSig,
+			providers:   []string{"b"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pubKeys := subcommittee
+			if test.noCommittee {
+				pubKeys = nil
+			}
+			contribution, providers := mergeContributions(test.responses, pubKeys, root)
+			require.Equal(t, test.providers, providers)
+			if test.unaltered != nil {
+				require.Equal(t, test.unaltered, contribution)
+				return
+			}
+			require.Equal(t, len(test.participants), int(contribution.AggregationBits.Count()))
+			participants := make([]e2types.PublicKey, 0, len(test.participants))
+			for _, participant := range test.participants {
+				require.True(t, contribution.AggregationBits.BitAt(participant))
+				participants = append(participants, keys[participant].PublicKey())
+			}
+			sig, err := signature(contribution.Signature)
+			require.NoError(t, err)
+			require.True(t, sig.(*e2types.BLSSignature).VerifyAggregateCommon(root[:], participants))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var extraParticipants prometheus.Histogram
+
+func registerMetrics(ctx context.Context, monitor metrics.Service) error {
+	if extraParticipants != nil {
+		// Already registered.
+		return nil
+	}
+	if monitor == nil {
+		// No monitor.
+		return nil
+	}
+	if monitor.Presenter() == "prometheus" {
+		return registerPrometheusMetrics(ctx)
+	}
+	return nil
+}
+
+func registerPrometheusMetrics(_ context.Context) error {
+	extraParticipants = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "vouch",
+		Subsystem: "strategy_synccommitteecontribution_merge",
+		Name:      "extra_participants",
+		Help:      "The number of participants added by merging over the best single contribution.",
+		Buckets:   []float64{0, 1, 2, 4, 8, 16, 32, 64, 128},
+	})
+	return prometheus.Register(extraParticipants)
+}
+
+func monitorMerge(extra uint64) {
+	if extraParticipants == nil {
+		return
+	}
+	extraParticipants.Observe(float64(extra))
+}
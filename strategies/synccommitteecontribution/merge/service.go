@@ -0,0 +1,163 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/services/chaintime"
+	"github.com/attestantio/vouch/services/consistency"
+	"github.com/attestantio/vouch/services/metrics"
+	"github.com/attestantio/vouch/services/nodehealth"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Service is the provider for sync committee contributions.
+type Service struct {
+	clientMonitor                          metrics.ClientMonitor
+	processConcurrency                     int64
+	syncCommitteeContributionProviders     map[string]eth2client.SyncCommitteeContributionProvider
+	syncCommitteeContributionProviderNames []string
+	timeout                                time.Duration
+	nodeHealth                             nodehealth.Service
+	consistency                            consistency.Service
+	capabilities                           capabilities.Service
+	deadline                               time.Duration
+	chainTime                              chaintime.Service
+	domainProvider                         eth2client.DomainProvider
+	syncCommitteesProvider                 eth2client.SyncCommitteesProvider
+	validatorsProvider                     eth2client.ValidatorsProvider
+	slotsPerEpoch                          uint64
+	subcommitteeSize                       uint64
+	syncCommitteeDomainType                phase0.DomainType
+
+	// Sync committee and public keys used to verify contribution signatures.
+	syncCommitteeMu    sync.Mutex
+	syncCommitteeEpoch phase0.Epoch
+	syncCommittee      []phase0.ValidatorIndex
+	pubKeys            map[phase0.ValidatorIndex]e2types.PublicKey
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new sync committee contribution strategy.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("strategy", "synccommitteecontribution").Str("impl", "merge").Logger()
+	if parameters.logLevel != log.GetLevel() {
+		log = log.Level(parameters.logLevel)
+	}
+
+	if err := registerMetrics(ctx, parameters.monitor); err != nil {
+		return nil, errors.New("failed to register metrics")
+	}
+
+	spec, err := parameters.specProvider.Spec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	tmp, exists := spec["SLOTS_PER_EPOCH"]
+	if !exists {
+		return nil, errors.New("SLOTS_PER_EPOCH not found in spec")
+	}
+	slotsPerEpoch, ok := tmp.(uint64)
+	if !ok {
+		return nil, errors.New("SLOTS_PER_EPOCH of unexpected type")
+	}
+
+	tmp, exists = spec["SYNC_COMMITTEE_SIZE"]
+	if !exists {
+		return nil, errors.New("SYNC_COMMITTEE_SIZE not found in spec")
+	}
+	syncCommitteeSize, ok := tmp.(uint64)
+	if !ok {
+		return nil, errors.New("SYNC_COMMITTEE_SIZE of unexpected type")
+	}
+
+	tmp, exists = spec["SYNC_COMMITTEE_SUBNET_COUNT"]
+	if !exists {
+		return nil, errors.New("SYNC_COMMITTEE_SUBNET_COUNT not found in spec")
+	}
+	syncCommitteeSubnetCount, ok := tmp.(uint64)
+	if !ok {
+		return nil, errors.New("SYNC_COMMITTEE_SUBNET_COUNT of unexpected type")
+	}
+	if syncCommitteeSubnetCount == 0 {
+		return nil, errors.New("SYNC_COMMITTEE_SUBNET_COUNT cannot be 0")
+	}
+
+	tmp, exists = spec["DOMAIN_SYNC_COMMITTEE"]
+	if !exists {
+		return nil, errors.New("DOMAIN_SYNC_COMMITTEE not found in spec")
+	}
+	syncCommitteeDomainType, ok := tmp.(phase0.DomainType)
+	if !ok {
+		return nil, errors.New("DOMAIN_SYNC_COMMITTEE of unexpected type")
+	}
+
+	syncCommitteeContributionProviderNames := make([]string, 0, len(parameters.syncCommitteeContributionProviders))
+	for name := range parameters.syncCommitteeContributionProviders {
+		syncCommitteeContributionProviderNames = append(syncCommitteeContributionProviderNames, name)
+	}
+	sort.Strings(syncCommitteeContributionProviderNames)
+
+	s := &Service{
+		timeout:                                parameters.timeout,
+		nodeHealth:                             parameters.nodeHealth,
+		consistency:                            parameters.consistency,
+		capabilities:                           parameters.capabilities,
+		deadline:                               parameters.deadline,
+		chainTime:                              parameters.chainTime,
+		clientMonitor:                          parameters.clientMonitor,
+		processConcurrency:                     parameters.processConcurrency,
+		syncCommitteeContributionProviders:     parameters.syncCommitteeContributionProviders,
+		syncCommitteeContributionProviderNames: syncCommitteeContributionProviderNames,
+		domainProvider:                         parameters.domainProvider,
+		syncCommitteesProvider:                 parameters.syncCommitteesProvider,
+		validatorsProvider:                     parameters.validatorsProvider,
+		slotsPerEpoch:                          slotsPerEpoch,
+		subcommitteeSize:                       syncCommitteeSize / syncCommitteeSubnetCount,
+		syncCommitteeDomainType:                syncCommitteeDomainType,
+		pubKeys:                                make(map[phase0.ValidatorIndex]e2types.PublicKey),
+	}
+	log.Trace().Int64("process_concurrency", s.processConcurrency).Msg("Set process concurrency")
+
+	return s, nil
+}
+
+// timeouts returns the hard and soft timeouts for a request for the given slot.
+func (s *Service) timeouts(slot phase0.Slot) (time.Duration, time.Duration, error) {
+	softTimeout := s.timeout / 2
+	if s.deadline == 0 {
+		return s.timeout, softTimeout, nil
+	}
+	return util.DeadlineTimeouts(s.chainTime.StartOfSlot(slot).Add(s.deadline), s.timeout, softTimeout)
+}
@@ -0,0 +1,149 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/attestantio/vouch/services/capabilities"
+	"github.com/attestantio/vouch/util"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+type syncCommitteeContributionResponse struct {
+	provider     string
+	contribution *altair.SyncCommitteeContribution
+}
+
+// SyncCommitteeContribution provides the merged sync committee contribution from a number of beacon nodes.
+func (s *Service) SyncCommitteeContribution(ctx context.Context, slot phase0.Slot, subcommitteeIndex uint64, beaconBlockRoot phase0.Root) (*altair.SyncCommitteeContribution, error) {
+	started := time.Now()
+	log := util.LogWithID(ctx, log, "strategy_id")
+
+	// We have two timeouts: a soft timeout and a hard timeout.
+	// At the soft timeout, we return if we have any responses so far.
+	// At the hard timeout, we return unconditionally.
+	// The soft timeout is half the duration of the hard timeout.
+	// If a deadline is set both timeouts are reduced as required to complete by the deadline.
+	timeout, softTimeout, err := s.timeouts(slot)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	softCtx, softCancel := context.WithTimeout(ctx, softTimeout)
+
+	names := s.capabilities.Filter(capabilities.CapabilitySyncCommitteeContributions, s.nodeHealth.Filter(s.consistency.Filter(s.syncCommitteeContributionProviderNames)))
+	respCh := make(chan *syncCommitteeContributionResponse, len(names))
+	errCh := make(chan error, len(names))
+	// Kick off the requests.
+	for _, name := range names {
+		provider := s.syncCommitteeContributionProviders[name]
+		go s.syncCommitteeContribution(ctx, started, name, provider, respCh, errCh, slot, subcommitteeIndex, beaconBlockRoot)
+	}
+
+	// Wait for all responses (or context done).
+	responded := 0
+	errored := 0
+	timedOut := 0
+	responses := make([]*syncCommitteeContributionResponse, 0, len(names))
+
+	for responded+errored+timedOut != len(names) {
+		select {
+		case <-softCtx.Done():
+			// If we have any responses at this point we consider the non-responders timed out.
+			if responded > 0 {
+				timedOut = len(names) - responded - errored
+				log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Msg("Soft timeout reached with responses")
+			} else {
+				log.Debug().Dur("elapsed", time.Since(started)).Int("errored", errored).Msg("Soft timeout reached with no responses")
+			}
+		case <-ctx.Done():
+			// Anyone not responded by now is considered errored.
+			errored = len(names) - responded
+			log.Debug().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Hard timeout reached")
+		case err := <-errCh:
+			errored++
+			log.Debug().Dur("elapsed", time.Since(started)).Err(err).Msg("Responded with error")
+		case resp := <-respCh:
+			responded++
+			responses = append(responses, resp)
+			log.Trace().Dur("elapsed", time.Since(started)).Msg("Response")
+		}
+	}
+
+	// Only verify signatures if there is something to merge.  This is carried out
+	// before the context is cancelled so that it is bounded by the hard timeout.
+	var pubKeys []e2types.PublicKey
+	var signingRoot phase0.Root
+	if len(responses) > 1 {
+		pubKeys, signingRoot, err = s.verificationInfo(ctx, slot, subcommitteeIndex, beaconBlockRoot)
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to obtain information to verify contributions; not merging")
+		}
+	}
+	softCancel()
+	cancel()
+	log.Trace().Dur("elapsed", time.Since(started)).Int("responded", responded).Int("errored", errored).Int("timed_out", timedOut).Msg("Responses")
+
+	if len(responses) == 0 {
+		return nil, errors.New("no sync committee contribution received")
+	}
+
+	contribution, providers := mergeContributions(responses, pubKeys, signingRoot)
+	log.Trace().Stringer("sync_committee_contribution", contribution).Strs("providers", providers).Msg("Merged sync committee contribution")
+	s.clientMonitor.StrategyOperation("merge", providers[0], "sync committee contribution", time.Since(started))
+
+	return contribution, nil
+}
+
+func (s *Service) syncCommitteeContribution(ctx context.Context,
+	started time.Time,
+	name string,
+	provider eth2client.SyncCommitteeContributionProvider,
+	respCh chan *syncCommitteeContributionResponse,
+	errCh chan error,
+	slot phase0.Slot,
+	subcommitteeIndex uint64,
+	beaconBlockRoot phase0.Root,
+) {
+	contribution, err := provider.SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
+	s.clientMonitor.ClientOperation(name, "sync committee contribution", err == nil, time.Since(started))
+	if err != nil {
+		errCh <- errors.Wrap(err, name)
+		return
+	}
+	log.Trace().Str("provider", name).Dur("elapsed", time.Since(started)).Msg("Obtained sync committee contribution")
+	if contribution == nil {
+		errCh <- errors.Errorf("%s: sync committee contribution nil", name)
+		return
+	}
+	if contribution.BeaconBlockRoot != beaconBlockRoot {
+		errCh <- errors.Errorf("%s: sync committee contribution for block root %#x does not match requested root %#x", name, contribution.BeaconBlockRoot, beaconBlockRoot)
+		return
+	}
+	if contribution.Slot != slot || contribution.SubcommitteeIndex != subcommitteeIndex {
+		errCh <- errors.Errorf("%s: sync committee contribution for incorrect slot or subcommittee", name)
+		return
+	}
+
+	respCh <- &syncCommitteeContributionResponse{
+		provider:     name,
+		contribution: contribution,
+	}
+}
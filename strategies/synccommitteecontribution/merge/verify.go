@@ -0,0 +1,115 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// verificationInfo returns the public keys of the members of the given sync
+// subcommittee, in subcommittee order, and the root signed by them.
+func (s *Service) verificationInfo(ctx context.Context,
+	slot phase0.Slot,
+	subcommitteeIndex uint64,
+	beaconBlockRoot phase0.Root,
+) (
+	[]e2types.PublicKey,
+	phase0.Root,
+	error,
+) {
+	epoch := phase0.Epoch(uint64(slot) / s.slotsPerEpoch)
+	pubKeys, err := s.subcommitteePubKeys(ctx, epoch, subcommitteeIndex)
+	if err != nil {
+		return nil, phase0.Root{}, err
+	}
+
+	domain, err := s.domainProvider.Domain(ctx, s.syncCommitteeDomainType, epoch)
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to obtain sync committee domain")
+	}
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: beaconBlockRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	return pubKeys, signingRoot, nil
+}
+
+// subcommitteePubKeys returns the public keys of the members of the given sync subcommittee, in subcommittee order.
+// The sync committee is cached for the most recently requested epoch, and public keys indefinitely.
+func (s *Service) subcommitteePubKeys(ctx context.Context, epoch phase0.Epoch, subcommitteeIndex uint64) ([]e2types.PublicKey, error) {
+	s.syncCommitteeMu.Lock()
+	defer s.syncCommitteeMu.Unlock()
+
+	if s.syncCommittee == nil || s.syncCommitteeEpoch != epoch {
+		syncCommittee, err := s.syncCommitteesProvider.SyncCommitteeAtEpoch(ctx, "head", epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain sync committee")
+		}
+		if syncCommittee == nil {
+			return nil, errors.New("sync committee nil")
+		}
+		s.syncCommittee = syncCommittee.Validators
+		s.syncCommitteeEpoch = epoch
+	}
+
+	start := subcommitteeIndex * s.subcommitteeSize
+	end := start + s.subcommitteeSize
+	if end > uint64(len(s.syncCommittee)) {
+		return nil, fmt.Errorf("no sync subcommittee %d", subcommitteeIndex)
+	}
+	validatorIndices := s.syncCommittee[start:end]
+
+	missing := make([]phase0.ValidatorIndex, 0)
+	for _, validatorIndex := range validatorIndices {
+		if _, exists := s.pubKeys[validatorIndex]; !exists {
+			missing = append(missing, validatorIndex)
+		}
+	}
+	if len(missing) > 0 {
+		validators, err := s.validatorsProvider.Validators(ctx, "head", missing)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators")
+		}
+		for validatorIndex, validator := range validators {
+			if validator.Validator == nil {
+				continue
+			}
+			pubKey, err := e2types.BLSPublicKeyFromBytes(validator.Validator.PublicKey[:])
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid validator public key")
+			}
+			s.pubKeys[validatorIndex] = pubKey
+		}
+	}
+
+	pubKeys := make([]e2types.PublicKey, len(validatorIndices))
+	for i, validatorIndex := range validatorIndices {
+		pubKey, exists := s.pubKeys[validatorIndex]
+		if !exists {
+			return nil, fmt.Errorf("no public key for validator %d", validatorIndex)
+		}
+		pubKeys[i] = pubKey
+	}
+
+	return pubKeys, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BeaconNodeURL provides the URL for the given path on the beacon node at the given address.
+// Addresses without a scheme are assumed to use HTTP.
+func BeaconNodeURL(address string, path string) string {
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+	return fmt.Sprintf("%s%s", strings.TrimSuffix(address, "/"), path)
+}
+
+// NewBeaconNodeHTTPClient provides an HTTP client for requests made directly to beacon nodes rather
+// than through a beacon node client.  Each request is limited to the given timeout, so that a beacon
+// node that stops responding part way through a response cannot hold up the caller.
+func NewBeaconNodeHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+	}
+}
+
+// BeaconNodeRequest sends a request to the beacon node at the given address, returning the status and body of the response.
+// If a body is supplied it is sent as JSON.
+func BeaconNodeRequest(ctx context.Context,
+	client *http.Client,
+	method string,
+	address string,
+	path string,
+	body []byte,
+) (
+	int,
+	[]byte,
+	error,
+) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, BeaconNodeURL(address, path), reqBody)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to create request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to read response")
+	}
+
+	return resp.StatusCode, respBody, nil
+}
+
+// BeaconNodeGet obtains the given path from the beacon node at the given address, decoding the JSON response into res.
+// An error is returned if the beacon node does not respond with a status of 200.
+func BeaconNodeGet(ctx context.Context, client *http.Client, address string, path string, res interface{}) error {
+	status, body, err := BeaconNodeRequest(ctx, client, http.MethodGet, address, path, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("request failed with status %d", status)
+	}
+	if err := json.Unmarshal(body, res); err != nil {
+		return errors.Wrap(err, "failed to parse response")
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeaconNodeURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		path    string
+		url     string
+	}{
+		{
+			name:    "NoScheme",
+			address: "localhost:5051",
+			path:    "/eth/v1/node/version",
+			url:     "http://localhost:5051/eth/v1/node/version",
+		},
+		{
+			name:    "HTTPS",
+			address: "https://node.example.com/",
+			path:    "/eth/v1/node/version",
+			url:     "https://node.example.com/eth/v1/node/version",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.url, util.BeaconNodeURL(test.address, test.path))
+		})
+	}
+}
+
+func TestBeaconNodeGet(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/good":
+			_, _ = w.Write([]byte(`{"data":"value"}`))
+		case "/invalid":
+			_, _ = w.Write([]byte(`{`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"data":"value"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := util.NewBeaconNodeHTTPClient(100 * time.Millisecond)
+
+	tests := []struct {
+		name string
+		path string
+		err  string
+	}{
+		{
+			name: "Good",
+			path: "/good",
+		},
+		{
+			name: "NotFound",
+			path: "/missing",
+			err:  "request failed with status 404",
+		},
+		{
+			name: "Invalid",
+			path: "/invalid",
+			err:  "failed to parse response",
+		},
+		{
+			name: "Timeout",
+			path: "/slow",
+			err:  "failed to send request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := struct {
+				Data string `json:"data"`
+			}{}
+			err := util.BeaconNodeGet(ctx, client, server.URL, test.path, &res)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "value", res.Data)
+			}
+		})
+	}
+}
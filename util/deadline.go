@@ -0,0 +1,60 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Deadline returns the best deadline for the path, as an offset from the start of the slot.
+// The deadline is taken from the closest ancestor of the path that has one, in the same way as Timeout,
+// so for path "a.b" the keys "a.b.deadline", "a.deadline" and finally the top-level "deadline" are checked.
+// A deadline of 0 means that no deadline is set.
+func Deadline(path string) time.Duration {
+	if path == "" {
+		return viper.GetDuration("deadline")
+	}
+
+	key := fmt.Sprintf("%s.deadline", path)
+	if viper.GetDuration(key) != 0 {
+		return viper.GetDuration(key)
+	}
+	// Lop off the child and try again.
+	lastPeriod := strings.LastIndex(path, ".")
+	if lastPeriod == -1 {
+		return Deadline("")
+	}
+	return Deadline(path[0:lastPeriod])
+}
+
+// DeadlineTimeouts returns the hard and soft timeouts for a request that must complete by the given deadline.
+// The hard timeout is the lower of the supplied timeout and the time remaining until the deadline, and the
+// soft timeout is reduced in proportion to the hard timeout.
+// An error is returned if the deadline has already passed.
+func DeadlineTimeouts(deadline time.Time, timeout time.Duration, softTimeout time.Duration) (time.Duration, time.Duration, error) {
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, 0, errors.Errorf("deadline passed %v ago", -remaining)
+	}
+	if remaining >= timeout {
+		return timeout, softTimeout, nil
+	}
+
+	return remaining, time.Duration(float64(softTimeout) * float64(remaining) / float64(timeout)), nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/vouch/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		vars     map[string]string
+		path     string
+		deadline time.Duration
+	}{
+		{
+			name:     "Empty",
+			path:     "",
+			deadline: 0,
+		},
+		{
+			name:     "Unset",
+			path:     "a.b.c",
+			deadline: 0,
+		},
+		{
+			name: "SingleLevel",
+			vars: map[string]string{
+				"a.deadline": "4s",
+			},
+			path:     "a",
+			deadline: 4 * time.Second,
+		},
+		{
+			name: "MultiLevel",
+			vars: map[string]string{
+				"a.deadline": "4s",
+			},
+			path:     "a.b.c",
+			deadline: 4 * time.Second,
+		},
+		{
+			name: "Override",
+			vars: map[string]string{
+				"a.deadline":     "4s",
+				"a.b.c.deadline": "5s",
+			},
+			path:     "a.b.c",
+			deadline: 5 * time.Second,
+		},
+		{
+			name: "TopLevel",
+			vars: map[string]string{
+				"deadline": "3s",
+			},
+			path:     "a.b.c",
+			deadline: 3 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			deadline := util.Deadline(test.path)
+			require.Equal(t, test.deadline, deadline)
+		})
+	}
+}
+
+func TestDeadlineTimeouts(t *testing.T) {
+	tests := []struct {
+		name        string
+		deadline    time.Time
+		timeout     time.Duration
+		softTimeout time.Duration
+		hard        time.Duration
+		soft        time.Duration
+		err         string
+	}{
+		{
+			name:        "Passed",
+			deadline:    time.Now().Add(-time.Second),
+			timeout:     2 * time.Second,
+			softTimeout: time.Second,
+			err:         "deadline passed",
+		},
+		{
+			name:        "Distant",
+			deadline:    time.Now().Add(time.Minute),
+			timeout:     2 * time.Second,
+			softTimeout: time.Second,
+			hard:        2 * time.Second,
+			soft:        time.Second,
+		},
+		{
+			name:        "Close",
+			deadline:    time.Now().Add(time.Second),
+			timeout:     2 * time.Second,
+			softTimeout: time.Second,
+			hard:        time.Second,
+			soft:        500 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hard, soft, err := util.DeadlineTimeouts(test.deadline, test.timeout, test.softTimeout)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.InDelta(t, test.hard, hard, float64(50*time.Millisecond))
+				require.InDelta(t, test.soft, soft, float64(50*time.Millisecond))
+			}
+		})
+	}
+}